@@ -2,46 +2,307 @@ package main
 
 import (
 	"database/sql"
+	"errors"
+	"flag"
 	"fmt"
+	"io"
+	"os"
+	"path/filepath"
+	"regexp"
+	"strconv"
+	"strings"
+	"text/tabwriter"
+
 	"go-template/internal/config"
+	"go-template/internal/migrations"
 	"go-template/internal/postgres"
-	"go-template/pkg/utl/zaplog"
-	"log"
-	"os"
 
 	migrate "github.com/rubenv/sql-migrate"
 )
 
+const usage = `usage: migrations [--dry-run] [--dir <dir>] <command>
+
+The migrations embedded in the binary are run unless --dir names a directory to read them from.
+
+commands:
+  status            list the migrations and when they were applied
+  up [n]            apply the pending migrations, at most n of them
+  down [n]          roll back the last n migrations, 1 by default and all of them with 0
+  redo              roll back the last migration and apply it again
+  to <version>      apply or roll back the migrations until version is the last one applied
+  create <name>     write an empty migration named <next version>_<name>.sql
+`
+
 func main() {
-	err := config.LoadEnv()
+	if err := config.LoadEnv(); err != nil {
+		fmt.Fprintln(os.Stderr, "error loading the env:", err)
+		os.Exit(1)
+	}
+	if err := Run(os.Args[1:], postgres.Connect, os.Stdout); err != nil {
+		fmt.Fprintln(os.Stderr, err)
+		os.Exit(1)
+	}
+}
+
+// migrator runs the commands against the migrations of the source
+type migrator struct {
+	db     *sql.DB
+	source migrate.MigrationSource
+	dryRun bool
+	out    io.Writer
+}
+
+// Run runs the command of args, the database is only connected to by the commands that need it
+func Run(args []string, connect func() (*sql.DB, error), out io.Writer) error {
+	fs := flag.NewFlagSet("migrations", flag.ContinueOnError)
+	fs.SetOutput(out)
+	fs.Usage = func() { fmt.Fprint(out, usage) }
+	dryRun := fs.Bool("dry-run", false, "print the SQL of the planned migrations instead of running it")
+	dir := fs.String("dir", "", "directory of the migrations, instead of the embedded ones")
+	if err := fs.Parse(args); err != nil {
+		return err
+	}
+	if fs.NArg() == 0 {
+		fs.Usage()
+		return errors.New("missing command")
+	}
+	// the flags may follow the command as well
+	cmd := fs.Arg(0)
+	if err := fs.Parse(fs.Args()[1:]); err != nil {
+		return err
+	}
+	cmdArgs := fs.Args()
+
+	if cmd == "create" {
+		if len(cmdArgs) != 1 {
+			return errors.New("create expects the name of the migration")
+		}
+		if *dir == "" {
+			*dir = migrations.Dir
+		}
+		return create(*dir, cmdArgs[0], out)
+	}
+
+	db, err := connect()
+	if err != nil {
+		return fmt.Errorf("failed while fetching db connection: %w", err)
+	}
+	defer db.Close()
+	m := &migrator{
+		db:     db,
+		source: migrations.Source(),
+		dryRun: *dryRun,
+		out:    out,
+	}
+	if *dir != "" {
+		m.source = &migrate.FileMigrationSource{Dir: *dir}
+	}
+
+	switch cmd {
+	case "status":
+		return m.status()
+	case "up":
+		n, err := optionalCount(cmdArgs, 0)
+		if err != nil {
+			return err
+		}
+		return m.exec(migrate.Up, n)
+	case "down":
+		n, err := optionalCount(cmdArgs, 1)
+		if err != nil {
+			return err
+		}
+		return m.exec(migrate.Down, n)
+	case "redo":
+		return m.redo()
+	case "to":
+		if len(cmdArgs) != 1 {
+			return errors.New("to expects the version to migrate to")
+		}
+		version, err := strconv.ParseInt(cmdArgs[0], 10, 64)
+		if err != nil || version < 0 {
+			return fmt.Errorf("invalid version %q", cmdArgs[0])
+		}
+		return m.to(version)
+	default:
+		fs.Usage()
+		return fmt.Errorf("unknown command %q", cmd)
+	}
+}
+
+// optionalCount parses the optional number of migrations of up and down
+func optionalCount(args []string, def int) (int, error) {
+	switch len(args) {
+	case 0:
+		return def, nil
+	case 1:
+		n, err := strconv.Atoi(args[0])
+		if err != nil || n < 0 {
+			return 0, fmt.Errorf("invalid number of migrations %q", args[0])
+		}
+		return n, nil
+	default:
+		return 0, errors.New("too many arguments")
+	}
+}
+
+func (m *migrator) status() error {
+	all, err := m.source.FindMigrations()
+	if err != nil {
+		return err
+	}
+	records, err := migrate.GetMigrationRecords(m.db, migrations.Dialect)
 	if err != nil {
-		log.Println("&&&&")
-		log.Println(err)
-		return
+		return err
+	}
+	applied := map[string]*migrate.MigrationRecord{}
+	for _, r := range records {
+		applied[r.Id] = r
+	}
+
+	w := tabwriter.NewWriter(m.out, 0, 0, 2, ' ', 0)
+	fmt.Fprintln(w, "MIGRATION\tAPPLIED")
+	for _, migration := range all {
+		if r, ok := applied[migration.Id]; ok {
+			fmt.Fprintf(w, "%s\t%s\n", migration.Id, r.AppliedAt.Format("2006-01-02 15:04:05 MST"))
+			delete(applied, migration.Id)
+		} else {
+			fmt.Fprintf(w, "%s\tno\n", migration.Id)
+		}
 	}
-	db, err := postgres.Connect()
+	// applied migrations whose file is gone
+	for _, r := range records {
+		if _, ok := applied[r.Id]; ok {
+			fmt.Fprintf(w, "%s\t%s (missing)\n", r.Id, r.AppliedAt.Format("2006-01-02 15:04:05 MST"))
+		}
+	}
+	return w.Flush()
+}
+
+// exec applies at most n migrations in the direction, all of them with 0
+func (m *migrator) exec(dir migrate.MigrationDirection, n int) error {
+	if m.dryRun {
+		planned, _, err := migrate.PlanMigration(m.db, migrations.Dialect, m.source, dir, n)
+		if err != nil {
+			return err
+		}
+		m.print(planned)
+		return nil
+	}
+	applied, err := migrate.ExecMax(m.db, migrations.Dialect, m.source, dir, n)
 	if err != nil {
-		fmt.Println("failed while fetching db connection", err)
-		zaplog.Logger.Error(err)
+		return fmt.Errorf("failed while executing migration: %w", err)
 	}
+	fmt.Fprintf(m.out, "Applied %d migrations!\n", applied)
+	return nil
+}
 
-	for _, arg := range os.Args {
-		if arg == "down" {
-			runMigration(db, migrate.Down)
+func (m *migrator) redo() error {
+	planned, _, err := migrate.PlanMigration(m.db, migrations.Dialect, m.source, migrate.Down, 1)
+	if err != nil {
+		return err
+	}
+	if len(planned) == 0 {
+		return errors.New("no migration to redo")
+	}
+	if m.dryRun {
+		m.print(planned)
+		m.print([]*migrate.PlannedMigration{{Migration: planned[0].Migration, Queries: planned[0].Up}})
+		return nil
+	}
+	if _, err := migrate.ExecMax(m.db, migrations.Dialect, m.source, migrate.Down, 1); err != nil {
+		return fmt.Errorf("failed while rolling back %s: %w", planned[0].Id, err)
+	}
+	if _, err := migrate.ExecMax(m.db, migrations.Dialect, m.source, migrate.Up, 1); err != nil {
+		return fmt.Errorf("failed while applying %s: %w", planned[0].Id, err)
+	}
+	fmt.Fprintf(m.out, "Reapplied %s!\n", planned[0].Id)
+	return nil
+}
+
+// to applies the migrations up to version, or rolls back the ones after it
+func (m *migrator) to(version int64) error {
+	records, err := migrate.GetMigrationRecords(m.db, migrations.Dialect)
+	if err != nil {
+		return err
+	}
+	var current int64
+	after := 0
+	for _, r := range records {
+		v := (&migrate.Migration{Id: r.Id}).VersionInt()
+		if v > current {
+			current = v
+		}
+		if v > version {
+			after++
+		}
+	}
+	switch {
+	case version > current:
+		if m.dryRun {
+			planned, _, err := migrate.PlanMigrationToVersion(m.db, migrations.Dialect, m.source, migrate.Up, version)
+			if err != nil {
+				return err
+			}
+			m.print(planned)
+			return nil
+		}
+		applied, err := migrate.ExecVersion(m.db, migrations.Dialect, m.source, migrate.Up, version)
+		if err != nil {
+			return fmt.Errorf("failed while executing migration: %w", err)
+		}
+		fmt.Fprintf(m.out, "Applied %d migrations!\n", applied)
+		return nil
+	case after > 0:
+		return m.exec(migrate.Down, after)
+	default:
+		fmt.Fprintf(m.out, "Already at version %d\n", version)
+		return nil
+	}
+}
+
+// print writes the SQL of the planned migrations
+func (m *migrator) print(planned []*migrate.PlannedMigration) {
+	for _, p := range planned {
+		fmt.Fprintf(m.out, "-- %s\n", p.Id)
+		for _, q := range p.Queries {
+			fmt.Fprintln(m.out, strings.TrimSpace(q))
 		}
 	}
-	runMigration(db, migrate.Up)
 }
 
-func runMigration(db *sql.DB, direction migrate.MigrationDirection) {
-	migrations := &migrate.FileMigrationSource{
-		Dir: "internal/migrations",
+var (
+	versionPrefix = regexp.MustCompile(`^(\d+)_`)
+	invalidName   = regexp.MustCompile(`[^a-z0-9]+`)
+)
+
+const migrationTemplate = `-- +migrate Up
+
+-- +migrate Down
+`
+
+// create writes an empty migration whose version follows the last one of dir
+func create(dir string, name string, out io.Writer) error {
+	name = strings.Trim(invalidName.ReplaceAllString(strings.ToLower(name), "_"), "_")
+	if name == "" {
+		return errors.New("invalid migration name")
 	}
-	n, err := migrate.Exec(db, "postgres", migrations, direction)
+	entries, err := os.ReadDir(dir)
 	if err != nil {
-		fmt.Println("failed while executing migration")
-		zaplog.Logger.Error(err)
-		return
+		return err
+	}
+	var last int64
+	for _, e := range entries {
+		if match := versionPrefix.FindStringSubmatch(e.Name()); match != nil {
+			if v, _ := strconv.ParseInt(match[1], 10, 64); v > last {
+				last = v
+			}
+		}
+	}
+	path := filepath.Join(dir, fmt.Sprintf("%d_%s.sql", last+1, name))
+	if err := os.WriteFile(path, []byte(migrationTemplate), 0644); err != nil { //nolint:gosec
+		return err
 	}
-	fmt.Printf("Applied %d migrations!\n", n)
+	fmt.Fprintf(out, "Created %s\n", path)
+	return nil
 }
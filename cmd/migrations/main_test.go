@@ -0,0 +1,138 @@
+package main_test
+
+import (
+	"bytes"
+	"database/sql"
+	"errors"
+	"os"
+	"path/filepath"
+	"testing"
+	"time"
+
+	main "go-template/cmd/migrations"
+
+	"github.com/DATA-DOG/go-sqlmock"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestRun(t *testing.T) {
+	appliedAt := time.Date(2022, 1, 2, 3, 4, 5, 0, time.UTC)
+	records := func(mock sqlmock.Sqlmock, ids ...string) {
+		mock.ExpectExec("create table if not exists \"gorp_migrations\"").WillReturnResult(sqlmock.NewResult(0, 0))
+		rows := sqlmock.NewRows([]string{"id", "applied_at"})
+		for _, id := range ids {
+			rows.AddRow(id, appliedAt)
+		}
+		mock.ExpectQuery("SELECT \\* FROM \"gorp_migrations\"").WillReturnRows(rows)
+	}
+	cases := map[string]struct {
+		args    []string
+		init    func(mock sqlmock.Sqlmock)
+		connect error
+		want    []string
+		err     string
+	}{
+		"Failure_MissingCommand": {
+			err: "missing command",
+		},
+		"Failure_UnknownCommand": {
+			args: []string{"sideways"},
+			err:  "unknown command \"sideways\"",
+		},
+		"Failure_InvalidCount": {
+			args: []string{"up", "two"},
+			err:  "invalid number of migrations \"two\"",
+		},
+		"Failure_Connect": {
+			args:    []string{"status"},
+			connect: errors.New("connection refused"),
+			err:     "failed while fetching db connection: connection refused",
+		},
+		"Success_Status": {
+			args: []string{"status"},
+			init: func(mock sqlmock.Sqlmock) { records(mock, "1_create_roles.sql") },
+			want: []string{
+				"1_create_roles.sql          2022-01-02 03:04:05 UTC",
+				"2_create_users.sql          no",
+			},
+		},
+		"Success_UpDryRun": {
+			args: []string{"--dry-run", "up", "1"},
+			init: func(mock sqlmock.Sqlmock) { records(mock) },
+			want: []string{"-- 1_create_roles.sql\nCREATE TABLE public.roles"},
+		},
+		"Success_DownDryRun": {
+			args: []string{"down", "--dry-run"},
+			init: func(mock sqlmock.Sqlmock) { records(mock, "1_create_roles.sql", "2_create_users.sql") },
+			want: []string{"-- 2_create_users.sql", "DROP TABLE users;"},
+		},
+		"Success_RedoDryRun": {
+			args: []string{"--dry-run", "redo"},
+			init: func(mock sqlmock.Sqlmock) { records(mock, "1_create_roles.sql") },
+			want: []string{"DROP TABLE roles;", "CREATE TABLE public.roles"},
+		},
+		"Failure_RedoNothingApplied": {
+			args: []string{"redo"},
+			init: func(mock sqlmock.Sqlmock) { records(mock) },
+			err:  "no migration to redo",
+		},
+		"Success_ToCurrent": {
+			args: []string{"to", "1"},
+			init: func(mock sqlmock.Sqlmock) { records(mock, "1_create_roles.sql") },
+			want: []string{"Already at version 1"},
+		},
+		"Success_ToDownDryRun": {
+			args: []string{"--dry-run", "to", "1"},
+			init: func(mock sqlmock.Sqlmock) {
+				records(mock, "1_create_roles.sql", "2_create_users.sql", "3_create_feature_flags.sql")
+				records(mock, "1_create_roles.sql", "2_create_users.sql", "3_create_feature_flags.sql")
+			},
+			want: []string{"-- 3_create_feature_flags.sql", "-- 2_create_users.sql"},
+		},
+	}
+	for name, tt := range cases {
+		t.Run(name, func(t *testing.T) {
+			db, mock, err := sqlmock.New()
+			if err != nil {
+				t.Fatal(err)
+			}
+			if tt.init != nil {
+				tt.init(mock)
+			}
+			mock.ExpectClose()
+			connect := func() (*sql.DB, error) { return db, tt.connect }
+
+			out := &bytes.Buffer{}
+			err = main.Run(tt.args, connect, out)
+			if tt.err != "" {
+				assert.EqualError(t, err, tt.err)
+				return
+			}
+			assert.Nil(t, err)
+			for _, want := range tt.want {
+				assert.Contains(t, out.String(), want)
+			}
+			assert.Nil(t, mock.ExpectationsWereMet())
+		})
+	}
+}
+
+func TestRun_Create(t *testing.T) {
+	dir := t.TempDir()
+	assert.Nil(t, os.WriteFile(filepath.Join(dir, "9_create_things.sql"), nil, 0600))
+	connect := func() (*sql.DB, error) {
+		t.Fatal("create must not connect to the database")
+		return nil, nil
+	}
+
+	out := &bytes.Buffer{}
+	assert.Nil(t, main.Run([]string{"--dir", dir, "create", "Add user Version"}, connect, out))
+
+	content, err := os.ReadFile(filepath.Join(dir, "10_add_user_version.sql"))
+	assert.Nil(t, err)
+	assert.Equal(t, "-- +migrate Up\n\n-- +migrate Down\n", string(content))
+	assert.Contains(t, out.String(), "10_add_user_version.sql")
+
+	assert.EqualError(t, main.Run([]string{"--dir", dir, "create"}, connect, out),
+		"create expects the name of the migration")
+}
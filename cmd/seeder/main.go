@@ -1,58 +1,141 @@
 package main
 
 import (
+	"context"
+	"database/sql"
+	"errors"
+	"flag"
 	"fmt"
-	"go-template/internal/config"
-	"go-template/pkg/utl/zaplog"
-	"log"
+	"io"
 	"os"
-	"os/exec"
 	"strings"
+	"text/tabwriter"
 
-	"golang.org/x/exp/slices"
+	"go-template/internal/config"
+	"go-template/internal/postgres"
+	"go-template/internal/seeder"
+	"go-template/internal/seeder/fake"
 )
 
-func stripFileExtension(fileName string) string {
-	s := strings.TrimRight(fileName, ".go")
-	return s
-}
+const usage = `usage: seeder [--env <environment>] <command>
+
+The environment defaults to ENVIRONMENT_NAME, or local, it selects the .env file and the seeders that run.
+
+commands:
+  list              list the seeders in the order they run in and the version they seeded
+  run [name...]     run the seeders named and their dependencies, every seeder by default
+  fake              insert generated roles and users for load and demo environments, see the flags below
+
+flags:
+`
 
 func main() {
-	err := config.LoadEnv()
+	if err := Run(os.Args[1:], connect, os.Stdout); err != nil {
+		fmt.Fprintln(os.Stderr, err)
+		os.Exit(1)
+	}
+}
+
+// connect loads the .env files of the environment and connects to its database
+func connect(env string) (*sql.DB, error) {
+	if err := os.Setenv("ENVIRONMENT_NAME", env); err != nil {
+		return nil, err
+	}
+	if err := config.LoadEnv(); err != nil {
+		return nil, fmt.Errorf("error loading the env: %w", err)
+	}
+	return postgres.Connect()
+}
+
+// Run runs the command of args against the database of the environment returned by connect
+func Run(args []string, connect func(env string) (*sql.DB, error), out io.Writer) error {
+	fs := flag.NewFlagSet("seeder", flag.ContinueOnError)
+	fs.SetOutput(out)
+	fs.Usage = func() {
+		fmt.Fprint(out, usage)
+		fs.PrintDefaults()
+	}
+	// LoadEnv falls back to the local environment as well
+	defaultEnv := os.Getenv("ENVIRONMENT_NAME")
+	if defaultEnv == "" {
+		defaultEnv = "local"
+	}
+	env := fs.String("env", defaultEnv, "environment to seed")
+	opts := fake.Options{}
+	fs.Int64Var(&opts.Seed, "seed", 1, "fake: seed of the generated data, the same seed generates the same data")
+	fs.IntVar(&opts.Roles, "roles", 10, "fake: number of roles")
+	fs.IntVar(&opts.Users, "users", 1000, "fake: number of users")
+	fs.IntVar(&opts.Passwords, "passwords", 5, "fake: number of passwords the users share")
+	fs.IntVar(&opts.BatchSize, "batch", 5000, "fake: number of users copied in a transaction")
+	if err := fs.Parse(args); err != nil {
+		return err
+	}
+	if fs.NArg() == 0 {
+		fs.Usage()
+		return errors.New("missing command")
+	}
+	cmd := fs.Arg(0)
+	if err := fs.Parse(fs.Args()[1:]); err != nil {
+		return err
+	}
+	if cmd != "list" && cmd != "run" && cmd != "fake" {
+		fs.Usage()
+		return fmt.Errorf("unknown command %q", cmd)
+	}
+	ctx := context.Background()
+	if cmd == "fake" {
+		db, err := connect(*env)
+		if err != nil {
+			return fmt.Errorf("failed while fetching db connection: %w", err)
+		}
+		defer db.Close()
+		return fake.Seed(ctx, db, opts, out)
+	}
+
+	registry, err := Registry()
 	if err != nil {
-		fmt.Println("erorr while loading the env")
-		return
+		return err
 	}
-	base, _ := os.Getwd()
-	base += "/cmd/seeder"
-	fmt.Println(base)
-	files, err := os.ReadDir(base)
+	if _, err := registry.Plan(fs.Args()...); err != nil {
+		return err
+	}
+	db, err := connect(*env)
 	if err != nil {
-		log.Fatal(err)
+		return fmt.Errorf("failed while fetching db connection: %w", err)
 	}
+	defer db.Close()
 
-	for _, file := range files {
-		if slices.Contains([]string{"main.go", "seed", "output", "exec", "build", "utls"}, file.Name()) {
-			continue
-		}
+	if cmd == "run" {
+		return registry.Run(ctx, db, *env, out, fs.Args()...)
+	}
+	return list(ctx, db, registry, out)
+}
 
-		filepath := base + "/" + file.Name()
-		// outputPath := base + "/output"
-		files, err := os.ReadDir(filepath)
-		if err != nil {
-			log.Fatal(err)
-		}
-		for _, file := range files {
-			fmt.Println(filepath + "/" + file.Name())
-			cmd := exec.
-				Command("go", "build", "-o",
-					fmt.Sprintf("./cmd/seeder/exec/build/%s", stripFileExtension(file.Name())), filepath+"/"+file.Name())
-			data, err := cmd.CombinedOutput()
-			if err != nil {
-				zaplog.Logger.Error(string(data), err)
-			}
-			fmt.Println(err)
+func list(ctx context.Context, db *sql.DB, registry *seeder.Registry, out io.Writer) error {
+	seeders, err := registry.List()
+	if err != nil {
+		return err
+	}
+	versions, err := seeder.Versions(ctx, db)
+	if err != nil {
+		return err
+	}
+	w := tabwriter.NewWriter(out, 0, 0, 2, ' ', 0)
+	fmt.Fprintln(w, "SEEDER\tVERSION\tSEEDED\tDEPENDS ON\tENVIRONMENTS")
+	for _, s := range seeders {
+		seeded := "no"
+		if v, ok := versions[s.Name]; ok {
+			seeded = fmt.Sprint(v)
 		}
+		fmt.Fprintf(w, "%s\t%d\t%s\t%s\t%s\n", s.Name, s.Version, seeded, joinOr(s.DependsOn, "-"), joinOr(s.Envs, "all"))
+	}
+	return w.Flush()
+}
 
+// joinOr joins the values, or returns none when there are none
+func joinOr(values []string, none string) string {
+	if len(values) == 0 {
+		return none
 	}
+	return strings.Join(values, ",")
 }
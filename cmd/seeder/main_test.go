@@ -0,0 +1,93 @@
+package main_test
+
+import (
+	"bytes"
+	"database/sql"
+	"testing"
+
+	main "go-template/cmd/seeder"
+
+	"github.com/DATA-DOG/go-sqlmock"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestRun(t *testing.T) {
+	cases := map[string]struct {
+		args []string
+		init func(mock sqlmock.Sqlmock)
+		env  string
+		want []string
+		err  string
+	}{
+		"Failure_MissingCommand": {
+			err: "missing command",
+		},
+		"Failure_UnknownCommand": {
+			args: []string{"seed"},
+			err:  "unknown command \"seed\"",
+		},
+		"Failure_UnknownSeeder": {
+			args: []string{"run", "companies"},
+			err:  "unknown seeder companies",
+		},
+		"Failure_FakeOptions": {
+			args: []string{"fake", "--env", "load", "--roles", "0"},
+			env:  "load",
+			err:  "invalid options: at least a role, a password and a batch of one user are needed",
+		},
+		"Success_List": {
+			args: []string{"--env", "production", "list"},
+			init: func(mock sqlmock.Sqlmock) {
+				mock.ExpectExec("CREATE TABLE IF NOT EXISTS public.seed_versions").
+					WillReturnResult(sqlmock.NewResult(0, 0))
+				mock.ExpectQuery("SELECT name, version FROM public.seed_versions").
+					WillReturnRows(sqlmock.NewRows([]string{"name", "version"}).AddRow("roles", 1))
+			},
+			env: "production",
+			want: []string{
+				"feature_flags  1        no      -           local",
+				"roles          1        1       -           all",
+				"users          1        no      roles       all",
+			},
+		},
+		"Success_RunSkipped": {
+			args: []string{"run", "--env", "production", "feature_flags"},
+			init: func(mock sqlmock.Sqlmock) {
+				mock.ExpectExec("CREATE TABLE IF NOT EXISTS public.seed_versions").
+					WillReturnResult(sqlmock.NewResult(0, 0))
+				mock.ExpectQuery("SELECT name, version FROM public.seed_versions").
+					WillReturnRows(sqlmock.NewRows([]string{"name", "version"}))
+			},
+			env:  "production",
+			want: []string{"feature_flags: skipped in production"},
+		},
+	}
+	for name, tt := range cases {
+		t.Run(name, func(t *testing.T) {
+			db, mock, err := sqlmock.New()
+			if err != nil {
+				t.Fatal(err)
+			}
+			if tt.init != nil {
+				tt.init(mock)
+			}
+			mock.ExpectClose()
+			connect := func(env string) (*sql.DB, error) {
+				assert.Equal(t, tt.env, env)
+				return db, nil
+			}
+
+			out := &bytes.Buffer{}
+			err = main.Run(tt.args, connect, out)
+			if tt.err != "" {
+				assert.EqualError(t, err, tt.err)
+				return
+			}
+			assert.Nil(t, err)
+			for _, want := range tt.want {
+				assert.Contains(t, out.String(), want)
+			}
+			assert.Nil(t, mock.ExpectationsWereMet())
+		})
+	}
+}
@@ -0,0 +1,70 @@
+package main
+
+import (
+	"context"
+	"database/sql"
+
+	"go-template/internal/seeder"
+	"go-template/models"
+	"go-template/pkg/utl/secure"
+
+	"github.com/volatiletech/sqlboiler/v4/queries/qm"
+)
+
+// roles are the roles of the service, inserted in the order of their ids
+var roles = []struct {
+	accessLevel int
+	name        string
+}{
+	{100, "SUPER_ADMIN"},
+	{120, "COMPANY_ADMIN"},
+	{130, "LOCATION_ADMIN"},
+	{200, "USER"},
+	{110, "ADMIN"},
+}
+
+// Registry returns the seeders of the service
+func Registry() (*seeder.Registry, error) {
+	r := seeder.NewRegistry()
+	for _, s := range []seeder.Seeder{
+		{Name: "roles", Version: 1, Seed: seedRoles},
+		{Name: "users", Version: 1, DependsOn: []string{"roles"}, Seed: seedUsers},
+		// local setups run without the rate limit and with the sql queries logged
+		{Name: "feature_flags", Version: 1, Envs: []string{"local"}, Seed: seedFeatureFlags},
+	} {
+		if err := r.Register(s); err != nil {
+			return nil, err
+		}
+	}
+	return r, nil
+}
+
+func seedRoles(ctx context.Context, tx *sql.Tx) error {
+	for _, role := range roles {
+		if _, err := tx.ExecContext(ctx, `INSERT INTO public.roles (access_level, name, created_at, updated_at)
+			SELECT $1, $2::text, now(), now() WHERE NOT EXISTS (SELECT 1 FROM public.roles WHERE name = $2::text)`,
+			role.accessLevel, role.name); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+func seedUsers(ctx context.Context, tx *sql.Tx) error {
+	role, err := models.Roles(qm.Where("name = ?", "SUPER_ADMIN")).One(ctx, tx)
+	if err != nil {
+		return err
+	}
+	sec := secure.New(1, nil)
+	_, err = tx.ExecContext(ctx, `INSERT INTO public.users
+		(first_name, last_name, username, password, email, active, role_id, created_at, updated_at)
+		VALUES ($1, $2, $3, $4, $5, true, $6, now(), now()) ON CONFLICT DO NOTHING`,
+		"Mohammed Ali", "Chherawalla", "admin", sec.Hash("adminuser"), "johndoe@mail.com", role.ID)
+	return err
+}
+
+func seedFeatureFlags(ctx context.Context, tx *sql.Tx) error {
+	_, err := tx.ExecContext(ctx, `UPDATE public.feature_flags SET enabled = true, updated_at = now()
+		WHERE name IN ('throttle_bypass', 'sql_debug')`)
+	return err
+}
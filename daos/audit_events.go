@@ -0,0 +1,137 @@
+package daos
+
+import (
+	"context"
+	"database/sql"
+	"fmt"
+	"strings"
+	"time"
+
+	"github.com/volatiletech/null/v8"
+)
+
+// AuditEvent is a row of the audit_events table, it has no sqlboiler model and is read with raw SQL
+type AuditEvent struct {
+	ID         int64
+	Action     string
+	ActorID    null.Int
+	TargetType string
+	TargetID   null.String
+	IP         null.String
+	RequestID  null.String
+	Diff       null.JSON
+	CreatedAt  time.Time
+}
+
+// AuditEventFilter narrows down the audit events, the unset fields match every event
+type AuditEventFilter struct {
+	Action     null.String
+	ActorID    null.Int
+	TargetType null.String
+	TargetID   null.String
+	From       null.Time
+	To         null.Time
+}
+
+const auditEventColumns = `id, action, actor_id, target_type, target_id, ip, request_id, diff, created_at`
+
+func scanAuditEvent(row interface{ Scan(...interface{}) error }) (AuditEvent, error) {
+	var event AuditEvent
+	err := row.Scan(&event.ID, &event.Action, &event.ActorID, &event.TargetType, &event.TargetID, &event.IP,
+		&event.RequestID, &event.Diff, &event.CreatedAt)
+	return event, err
+}
+
+// where returns the WHERE clause of the filter along with its arguments
+func (f AuditEventFilter) where() (string, []interface{}) {
+	var conditions []string
+	var args []interface{}
+	add := func(condition string, arg interface{}) {
+		args = append(args, arg)
+		conditions = append(conditions, fmt.Sprintf(condition, len(args)))
+	}
+	if f.Action.Valid {
+		add("action = $%d", f.Action.String)
+	}
+	if f.ActorID.Valid {
+		add("actor_id = $%d", f.ActorID.Int)
+	}
+	if f.TargetType.Valid {
+		add("target_type = $%d", f.TargetType.String)
+	}
+	if f.TargetID.Valid {
+		add("target_id = $%d", f.TargetID.String)
+	}
+	if f.From.Valid {
+		add("created_at >= $%d", f.From.Time)
+	}
+	if f.To.Valid {
+		add("created_at < $%d", f.To.Time)
+	}
+	if len(conditions) == 0 {
+		return "", nil
+	}
+	return " WHERE " + strings.Join(conditions, " AND "), args
+}
+
+// CreateAuditEventTx stores the event and returns it with its id and creation time
+func CreateAuditEventTx(event AuditEvent, ctx context.Context, tx *sql.Tx) (AuditEvent, error) {
+	contextExecutor := GetContextExecutor(tx, ctx)
+	row := contextExecutor.QueryRowContext(ctx,
+		`INSERT INTO audit_events (action, actor_id, target_type, target_id, ip, request_id, diff, created_at)
+		VALUES ($1, $2, $3, $4, $5, $6, $7, $8)
+		RETURNING `+auditEventColumns,
+		event.Action, event.ActorID, event.TargetType, event.TargetID, event.IP, event.RequestID, event.Diff,
+		time.Now().UTC())
+	return scanAuditEvent(row)
+}
+
+// CreateAuditEvent ...
+func CreateAuditEvent(event AuditEvent, ctx context.Context) (AuditEvent, error) {
+	return CreateAuditEventTx(event, ctx, nil)
+}
+
+// FindAuditEventsWithCount returns a page of the events matching the filter, newest first, along with the
+// number of events matching the filter
+func FindAuditEventsWithCount(filter AuditEventFilter, limit, offset int, ctx context.Context) (
+	[]AuditEvent, int64, error,
+) {
+	contextExecutor := GetReadContextExecutor(nil, ctx)
+	where, args := filter.where()
+
+	var count int64
+	err := contextExecutor.QueryRowContext(ctx, `SELECT count(*) FROM audit_events`+where, args...).Scan(&count)
+	if err != nil {
+		return nil, 0, err
+	}
+
+	args = append(args, limit, offset)
+	rows, err := contextExecutor.QueryContext(ctx,
+		fmt.Sprintf(`SELECT `+auditEventColumns+` FROM audit_events`+where+
+			` ORDER BY created_at DESC, id DESC LIMIT $%d OFFSET $%d`, len(args)-1, len(args)),
+		args...)
+	if err != nil {
+		return nil, 0, err
+	}
+	defer rows.Close()
+
+	events := []AuditEvent{}
+	for rows.Next() {
+		event, err := scanAuditEvent(rows)
+		if err != nil {
+			return nil, 0, err
+		}
+		events = append(events, event)
+	}
+	return events, count, rows.Err()
+}
+
+// DeleteAuditEventsBefore deletes the events created before the given time and returns the number of deleted rows
+func DeleteAuditEventsBefore(before time.Time, ctx context.Context) (int64, error) {
+	contextExecutor := GetContextExecutor(nil, ctx)
+	result, err := contextExecutor.ExecContext(ctx, `DELETE FROM audit_events WHERE created_at < $1`, before)
+	if err != nil {
+		return 0, err
+	}
+	return result.RowsAffected()
+}
@@ -0,0 +1,128 @@
+package daos_test
+
+import (
+	"context"
+	"database/sql/driver"
+	"fmt"
+	"regexp"
+	"testing"
+	"time"
+
+	"go-template/daos"
+	"go-template/testutls"
+
+	"github.com/DATA-DOG/go-sqlmock"
+	"github.com/stretchr/testify/assert"
+	"github.com/volatiletech/null/v8"
+)
+
+var auditEventColumns = []string{
+	"id", "action", "actor_id", "target_type", "target_id", "ip", "request_id", "diff", "created_at",
+}
+
+func TestCreateAuditEvent(t *testing.T) {
+	mock, db, _ := testutls.SetupMockDB(t)
+	defer db.Close()
+	createdAt := time.Date(2023, 1, 1, 0, 0, 0, 0, time.UTC)
+	mock.ExpectQuery(regexp.QuoteMeta(`INSERT INTO audit_events`)).
+		WithArgs("user_updated", null.IntFrom(1), "user", null.StringFrom("2"), null.StringFrom("127.0.0.1"),
+			null.StringFrom("rid"), null.JSONFrom([]byte(`{"first_name":{"from":"a","to":"b"}}`)), sqlmock.AnyArg()).
+		WillReturnRows(sqlmock.NewRows(auditEventColumns).
+			AddRow(7, "user_updated", 1, "user", "2", "127.0.0.1", "rid", []byte(`{"first_name":{"from":"a","to":"b"}}`),
+				createdAt))
+
+	event, err := daos.CreateAuditEvent(daos.AuditEvent{
+		Action:     "user_updated",
+		ActorID:    null.IntFrom(1),
+		TargetType: "user",
+		TargetID:   null.StringFrom("2"),
+		IP:         null.StringFrom("127.0.0.1"),
+		RequestID:  null.StringFrom("rid"),
+		Diff:       null.JSONFrom([]byte(`{"first_name":{"from":"a","to":"b"}}`)),
+	}, context.Background())
+	assert.Nil(t, err)
+	assert.Equal(t, int64(7), event.ID)
+	assert.Equal(t, createdAt, event.CreatedAt)
+	assert.Nil(t, mock.ExpectationsWereMet())
+}
+
+func TestFindAuditEventsWithCount(t *testing.T) {
+	from := time.Date(2023, 1, 1, 0, 0, 0, 0, time.UTC)
+	cases := []struct {
+		name      string
+		filter    daos.AuditEventFilter
+		where     string
+		args      []driver.Value
+		countErr  error
+		wantCount int64
+		want      []daos.AuditEvent
+		wantErr   bool
+	}{
+		{
+			name:      "Success_NoFilter",
+			where:     `SELECT count(*) FROM audit_events`,
+			wantCount: 1,
+			want: []daos.AuditEvent{{
+				ID: 1, Action: "login_failed", TargetType: "user", TargetID: null.StringFrom("2"), Diff: null.JSON{JSON: []byte{}},
+				CreatedAt: from,
+			}},
+		},
+		{
+			name: "Success_Filter",
+			filter: daos.AuditEventFilter{
+				Action:  null.StringFrom("login_failed"),
+				ActorID: null.IntFrom(2),
+				From:    null.TimeFrom(from),
+			},
+			where:     `SELECT count(*) FROM audit_events WHERE action = $1 AND actor_id = $2 AND created_at >= $3`,
+			args:      []driver.Value{"login_failed", 2, from},
+			wantCount: 1,
+			want: []daos.AuditEvent{{
+				ID: 1, Action: "login_failed", TargetType: "user", TargetID: null.StringFrom("2"), Diff: null.JSON{JSON: []byte{}},
+				CreatedAt: from,
+			}},
+		},
+		{
+			name:     "Failure",
+			where:    `SELECT count(*) FROM audit_events`,
+			countErr: fmt.Errorf("connection refused"),
+			wantErr:  true,
+		},
+	}
+	for _, tt := range cases {
+		t.Run(tt.name, func(t *testing.T) {
+			mock, db, _ := testutls.SetupMockDB(t)
+			defer db.Close()
+			count := mock.ExpectQuery(regexp.QuoteMeta(tt.where)).WithArgs(tt.args...)
+			if tt.countErr != nil {
+				count.WillReturnError(tt.countErr)
+			} else {
+				count.WillReturnRows(sqlmock.NewRows([]string{"count"}).AddRow(tt.wantCount))
+				mock.ExpectQuery(regexp.QuoteMeta(`ORDER BY created_at DESC, id DESC LIMIT`)).
+					WithArgs(append(tt.args, 10, 20)...).
+					WillReturnRows(sqlmock.NewRows(auditEventColumns).
+						AddRow(1, "login_failed", nil, "user", "2", nil, nil, nil, from))
+			}
+
+			events, total, err := daos.FindAuditEventsWithCount(tt.filter, 10, 20, context.Background())
+			assert.Equal(t, tt.wantErr, err != nil)
+			assert.Equal(t, tt.wantCount, total)
+			assert.Equal(t, tt.want, events)
+			assert.Nil(t, mock.ExpectationsWereMet())
+		})
+	}
+}
+
+func TestDeleteAuditEventsBefore(t *testing.T) {
+	mock, db, _ := testutls.SetupMockDB(t)
+	defer db.Close()
+	before := time.Date(2023, 1, 1, 0, 0, 0, 0, time.UTC)
+	mock.ExpectExec(regexp.QuoteMeta(`DELETE FROM audit_events WHERE created_at < $1`)).
+		WithArgs(before).
+		WillReturnResult(sqlmock.NewResult(0, 3))
+
+	deleted, err := daos.DeleteAuditEventsBefore(before, context.Background())
+	assert.Nil(t, err)
+	assert.Equal(t, int64(3), deleted)
+	assert.Nil(t, mock.ExpectationsWereMet())
+}
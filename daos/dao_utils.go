@@ -1,12 +1,17 @@
 package daos
 
 import (
+	"context"
 	"database/sql"
 
 	"github.com/volatiletech/sqlboiler/v4/boil"
 )
 
-func GetContextExecutor(tx *sql.Tx) (contextExecutor boil.ContextExecutor) {
+// GetContextExecutor returns tx, else the transaction WithTx put in ctx, else the database
+func GetContextExecutor(tx *sql.Tx, ctx context.Context) (contextExecutor boil.ContextExecutor) {
+	if tx == nil {
+		tx = TxFromContext(ctx)
+	}
 	if tx == nil {
 		contextExecutor = boil.GetContextDB()
 	} else {
@@ -1,34 +1,41 @@
 package daos_test
 
 import (
+	"context"
 	"database/sql"
 	"go-template/daos"
 	"testing"
 
 	"github.com/stretchr/testify/assert"
+	"github.com/volatiletech/sqlboiler/v4/boil"
 )
 
 func TestGetContextExecutor(t *testing.T) {
 
 	cases := []struct {
 		name string
-		res  *sql.Tx
-		err  error
+		tx   *sql.Tx
+		res  boil.ContextExecutor
 	}{
 		{
 			name: "Passing role type value",
+			tx:   &sql.Tx{},
 			res:  &sql.Tx{},
 		},
+		{
+			name: "Passing no transaction",
+			res:  boil.GetContextDB(),
+		},
 	}
 
 	// Loop through the test cases
 	for _, tt := range cases {
 
 		t.Run(tt.name, func(t *testing.T) {
-			response := daos.GetContextExecutor(&sql.Tx{})
+			response := daos.GetContextExecutor(tt.tx, context.Background())
 
 			// Check if the response is equal to the expected value
-			assert.Equal(t, response, tt.res)
+			assert.Equal(t, tt.res, response)
 
 		})
 	}
@@ -0,0 +1,110 @@
+package daos
+
+import (
+	"context"
+	"database/sql"
+	"time"
+
+	"github.com/lib/pq"
+	"github.com/volatiletech/null/v8"
+)
+
+// FeatureFlag is a row of the feature_flags table, it has no sqlboiler model and is read with raw SQL
+type FeatureFlag struct {
+	ID                int
+	Name              string
+	Description       null.String
+	Enabled           bool
+	RolloutPercentage int
+	Roles             []string
+	UserIDs           []int
+	CreatedAt         null.Time
+	UpdatedAt         null.Time
+}
+
+const featureFlagColumns = `id, name, description, enabled, rollout_percentage, roles, user_ids, created_at, updated_at`
+
+func scanFeatureFlag(row interface{ Scan(...interface{}) error }) (FeatureFlag, error) {
+	var flag FeatureFlag
+	var userIDs pq.Int64Array
+	err := row.Scan(&flag.ID, &flag.Name, &flag.Description, &flag.Enabled, &flag.RolloutPercentage,
+		pq.Array(&flag.Roles), &userIDs, &flag.CreatedAt, &flag.UpdatedAt)
+	if err != nil {
+		return FeatureFlag{}, err
+	}
+	flag.UserIDs = make([]int, len(userIDs))
+	for i, id := range userIDs {
+		flag.UserIDs[i] = int(id)
+	}
+	return flag, nil
+}
+
+// FindAllFeatureFlags returns every feature flag ordered by name
+func FindAllFeatureFlags(ctx context.Context) ([]FeatureFlag, error) {
+	contextExecutor := GetReadContextExecutor(nil, ctx)
+	rows, err := contextExecutor.QueryContext(ctx,
+		`SELECT `+featureFlagColumns+` FROM feature_flags ORDER BY name`)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	flags := []FeatureFlag{}
+	for rows.Next() {
+		flag, err := scanFeatureFlag(rows)
+		if err != nil {
+			return nil, err
+		}
+		flags = append(flags, flag)
+	}
+	return flags, rows.Err()
+}
+
+// FindFeatureFlagByName returns the feature flag with the given name, or sql.ErrNoRows
+func FindFeatureFlagByName(name string, ctx context.Context) (*FeatureFlag, error) {
+	contextExecutor := GetReadContextExecutor(nil, ctx)
+	row := contextExecutor.QueryRowContext(ctx,
+		`SELECT `+featureFlagColumns+` FROM feature_flags WHERE name = $1`, name)
+	flag, err := scanFeatureFlag(row)
+	if err != nil {
+		return nil, err
+	}
+	return &flag, nil
+}
+
+// UpsertFeatureFlagTx creates the feature flag or updates the flag with the same name
+func UpsertFeatureFlagTx(flag FeatureFlag, ctx context.Context, tx *sql.Tx) (FeatureFlag, error) {
+	contextExecutor := GetContextExecutor(tx, ctx)
+	userIDs := make(pq.Int64Array, len(flag.UserIDs))
+	for i, id := range flag.UserIDs {
+		userIDs[i] = int64(id)
+	}
+	if flag.Roles == nil {
+		flag.Roles = []string{}
+	}
+	now := time.Now().UTC()
+	row := contextExecutor.QueryRowContext(ctx,
+		`INSERT INTO feature_flags (name, description, enabled, rollout_percentage, roles, user_ids, created_at, updated_at)
+		VALUES ($1, $2, $3, $4, $5, $6, $7, $7)
+		ON CONFLICT (name) DO UPDATE SET description = EXCLUDED.description, enabled = EXCLUDED.enabled,
+			rollout_percentage = EXCLUDED.rollout_percentage, roles = EXCLUDED.roles, user_ids = EXCLUDED.user_ids,
+			updated_at = EXCLUDED.updated_at
+		RETURNING `+featureFlagColumns,
+		flag.Name, flag.Description, flag.Enabled, flag.RolloutPercentage, pq.Array(flag.Roles), userIDs, now)
+	return scanFeatureFlag(row)
+}
+
+// UpsertFeatureFlag ...
+func UpsertFeatureFlag(flag FeatureFlag, ctx context.Context) (FeatureFlag, error) {
+	return UpsertFeatureFlagTx(flag, ctx, nil)
+}
+
+// DeleteFeatureFlag deletes the feature flag with the given name and returns the number of deleted rows
+func DeleteFeatureFlag(name string, ctx context.Context) (int64, error) {
+	contextExecutor := GetContextExecutor(nil, ctx)
+	result, err := contextExecutor.ExecContext(ctx, `DELETE FROM feature_flags WHERE name = $1`, name)
+	if err != nil {
+		return 0, err
+	}
+	return result.RowsAffected()
+}
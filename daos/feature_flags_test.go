@@ -0,0 +1,142 @@
+package daos_test
+
+import (
+	"context"
+	"database/sql"
+	"fmt"
+	"regexp"
+	"testing"
+
+	"go-template/daos"
+	"go-template/testutls"
+
+	"github.com/DATA-DOG/go-sqlmock"
+	"github.com/lib/pq"
+	"github.com/stretchr/testify/assert"
+	"github.com/volatiletech/null/v8"
+)
+
+var featureFlagColumns = []string{
+	"id", "name", "description", "enabled", "rollout_percentage", "roles", "user_ids", "created_at", "updated_at",
+}
+
+func TestFindAllFeatureFlags(t *testing.T) {
+	cases := []struct {
+		name    string
+		rows    *sqlmock.Rows
+		want    []daos.FeatureFlag
+		wantErr bool
+	}{
+		{
+			name: "Success",
+			rows: sqlmock.NewRows(featureFlagColumns).
+				AddRow(1, "new_checkout", "Checkout v2", true, 25, "{SUPER_ADMIN,USER}", "{1,2}", nil, nil),
+			want: []daos.FeatureFlag{{
+				ID:                1,
+				Name:              "new_checkout",
+				Description:       null.StringFrom("Checkout v2"),
+				Enabled:           true,
+				RolloutPercentage: 25,
+				Roles:             []string{"SUPER_ADMIN", "USER"},
+				UserIDs:           []int{1, 2},
+			}},
+		},
+		{
+			name:    "Failure",
+			wantErr: true,
+		},
+	}
+	for _, tt := range cases {
+		t.Run(tt.name, func(t *testing.T) {
+			mock, db, _ := testutls.SetupMockDB(t)
+			defer db.Close()
+			query := mock.ExpectQuery(regexp.QuoteMeta(`FROM feature_flags ORDER BY name`))
+			if tt.wantErr {
+				query.WillReturnError(fmt.Errorf("connection refused"))
+			} else {
+				query.WillReturnRows(tt.rows)
+			}
+
+			flags, err := daos.FindAllFeatureFlags(context.Background())
+			assert.Equal(t, tt.wantErr, err != nil)
+			assert.Equal(t, tt.want, flags)
+			assert.Nil(t, mock.ExpectationsWereMet())
+		})
+	}
+}
+
+func TestFindFeatureFlagByName(t *testing.T) {
+	cases := map[string]struct {
+		rows    *sqlmock.Rows
+		want    *daos.FeatureFlag
+		wantErr error
+	}{
+		"Success": {
+			rows: sqlmock.NewRows(featureFlagColumns).AddRow(1, "beta", nil, true, 100, "{USER}", "{}", nil, nil),
+			want: &daos.FeatureFlag{
+				ID:                1,
+				Name:              "beta",
+				Enabled:           true,
+				RolloutPercentage: 100,
+				Roles:             []string{"USER"},
+				UserIDs:           []int{},
+			},
+		},
+		"Failure_NotFound": {
+			rows:    sqlmock.NewRows(featureFlagColumns),
+			wantErr: sql.ErrNoRows,
+		},
+	}
+	for name, tt := range cases {
+		t.Run(name, func(t *testing.T) {
+			mock, db, _ := testutls.SetupMockDB(t)
+			defer db.Close()
+			mock.ExpectQuery(regexp.QuoteMeta(`FROM feature_flags WHERE name = $1`)).
+				WithArgs("beta").
+				WillReturnRows(tt.rows)
+
+			flag, err := daos.FindFeatureFlagByName("beta", context.Background())
+			assert.Equal(t, tt.wantErr, err)
+			assert.Equal(t, tt.want, flag)
+			assert.Nil(t, mock.ExpectationsWereMet())
+		})
+	}
+}
+
+func TestUpsertFeatureFlag(t *testing.T) {
+	mock, db, _ := testutls.SetupMockDB(t)
+	defer db.Close()
+	mock.ExpectQuery(regexp.QuoteMeta(`INSERT INTO feature_flags`)).
+		WithArgs("new_checkout", null.String{}, true, 50, pq.Array([]string{}), pq.Int64Array{3}, sqlmock.AnyArg()).
+		WillReturnRows(sqlmock.NewRows(featureFlagColumns).
+			AddRow(1, "new_checkout", nil, true, 50, "{}", "{3}", nil, nil))
+
+	flag, err := daos.UpsertFeatureFlag(daos.FeatureFlag{
+		Name:              "new_checkout",
+		Enabled:           true,
+		RolloutPercentage: 50,
+		UserIDs:           []int{3},
+	}, context.Background())
+	assert.Nil(t, err)
+	assert.Equal(t, daos.FeatureFlag{
+		ID:                1,
+		Name:              "new_checkout",
+		Enabled:           true,
+		RolloutPercentage: 50,
+		Roles:             []string{},
+		UserIDs:           []int{3},
+	}, flag)
+	assert.Nil(t, mock.ExpectationsWereMet())
+}
+
+func TestDeleteFeatureFlag(t *testing.T) {
+	mock, db, _ := testutls.SetupMockDB(t)
+	defer db.Close()
+	mock.ExpectExec(regexp.QuoteMeta(`DELETE FROM feature_flags WHERE name = $1`)).
+		WithArgs("new_checkout").
+		WillReturnResult(sqlmock.NewResult(0, 1))
+
+	deleted, err := daos.DeleteFeatureFlag("new_checkout", context.Background())
+	assert.Nil(t, err)
+	assert.Equal(t, int64(1), deleted)
+}
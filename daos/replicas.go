@@ -0,0 +1,207 @@
+package daos
+
+import (
+	"context"
+	"database/sql"
+	"sync"
+	"sync/atomic"
+	"time"
+
+	"go-template/pkg/utl/clock"
+	"go-template/pkg/utl/zaplog"
+
+	"github.com/volatiletech/sqlboiler/v4/boil"
+	"go.uber.org/zap"
+)
+
+type primaryCtxKey struct{}
+
+// replicas serves the reads of GetReadContextExecutor, they go to the primary while it is nil
+var replicas *ReplicaSet
+
+// WriteWindows remembers the users that wrote recently. Behind a load balancer the windows must be shared by the
+// instances of the app, the next read of a user may be served by another instance than their write.
+type WriteWindows interface {
+	// Start starts the window of the user, which runs for window
+	Start(ctx context.Context, userID int, window time.Duration) error
+	// Running returns whether the window of the user is running
+	Running(ctx context.Context, userID int) (bool, error)
+}
+
+// ReplicaSet spreads the reads over the healthy read replicas and keeps the write windows of the users, in which
+// their reads are served by the primary until the replicas have caught up with their writes
+type ReplicaSet struct {
+	pools   []*sql.DB
+	window  time.Duration
+	windows WriteWindows
+	next    uint32
+
+	mu      sync.RWMutex
+	healthy []bool
+}
+
+// NewReplicaSet returns the replica set of pools, the reads of a user go to the primary for window after their
+// writes. The replicas are healthy until CheckHealth finds otherwise.
+func NewReplicaSet(pools []*sql.DB, window time.Duration, windows WriteWindows) *ReplicaSet {
+	healthy := make([]bool, len(pools))
+	for i := range healthy {
+		healthy[i] = true
+	}
+	return &ReplicaSet{pools: pools, window: window, windows: windows, healthy: healthy}
+}
+
+// SetReplicas routes the reads outside of transactions to r, nil routes every read to the primary
+func SetReplicas(r *ReplicaSet) {
+	replicas = r
+}
+
+// WithPrimary returns a context whose reads are served by the primary, e.g. the reads of a mutation
+func WithPrimary(ctx context.Context) context.Context {
+	return context.WithValue(ctx, primaryCtxKey{}, true)
+}
+
+// UsesPrimary returns whether the reads with ctx are served by the primary regardless of the replicas
+func UsesPrimary(ctx context.Context) bool {
+	primary, _ := ctx.Value(primaryCtxKey{}).(bool)
+	return primary
+}
+
+// GetReadContextExecutor returns the executor of a read-only query: tx, else the transaction WithTx put in ctx,
+// else a healthy replica unless ctx uses the primary, else the database
+func GetReadContextExecutor(tx *sql.Tx, ctx context.Context) boil.ContextExecutor {
+	if tx == nil {
+		tx = TxFromContext(ctx)
+	}
+	if tx != nil || replicas == nil || UsesPrimary(ctx) {
+		return GetContextExecutor(tx, ctx)
+	}
+	if db := replicas.pick(); db != nil {
+		return db
+	}
+	return boil.GetContextDB()
+}
+
+// pick returns the healthy replicas in turn, or nil when none is healthy
+func (r *ReplicaSet) pick() *sql.DB {
+	r.mu.RLock()
+	defer r.mu.RUnlock()
+	healthy := make([]*sql.DB, 0, len(r.pools))
+	for i, pool := range r.pools {
+		if r.healthy[i] {
+			healthy = append(healthy, pool)
+		}
+	}
+	if len(healthy) == 0 {
+		return nil
+	}
+	return healthy[atomic.AddUint32(&r.next, 1)%uint32(len(healthy))]
+}
+
+// MarkWrite starts the window in which the reads of the user are served by the primary
+func (r *ReplicaSet) MarkWrite(ctx context.Context, userID int) {
+	if r.window <= 0 {
+		return
+	}
+	if err := r.windows.Start(ctx, userID, r.window); err != nil {
+		zaplog.Error(ctx, "write window not started", zap.Int("user", userID), zap.Error(err))
+	}
+}
+
+// WroteRecently returns whether the user wrote within the window, their reads then need the primary. The reads
+// go to the primary as well when the window cannot be looked up.
+func (r *ReplicaSet) WroteRecently(ctx context.Context, userID int) bool {
+	if r.window <= 0 {
+		return false
+	}
+	running, err := r.windows.Running(ctx, userID)
+	if err != nil {
+		zaplog.Error(ctx, "write window not looked up", zap.Int("user", userID), zap.Error(err))
+		return true
+	}
+	return running
+}
+
+// CheckHealth pings every replica, the replicas that do not answer serve no reads until they answer again
+func (r *ReplicaSet) CheckHealth(ctx context.Context) {
+	errs := make([]error, len(r.pools))
+	for i, pool := range r.pools {
+		errs[i] = pool.PingContext(ctx)
+	}
+
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	for i, err := range errs {
+		healthy := err == nil
+		if healthy == r.healthy[i] {
+			continue
+		}
+		if healthy {
+			zaplog.Info(ctx, "read replica healthy", zap.Int("replica", i))
+		} else {
+			zaplog.Error(ctx, "read replica unhealthy", zap.Int("replica", i), zap.Error(err))
+		}
+		r.healthy[i] = healthy
+	}
+}
+
+// Run checks the health of the replicas every interval until ctx is done, each check is bound by the interval
+func (r *ReplicaSet) Run(ctx context.Context, interval time.Duration) {
+	ticker := time.NewTicker(interval)
+	defer ticker.Stop()
+	for {
+		select {
+		case <-ctx.Done():
+			return
+		case <-ticker.C:
+			checkCtx, cancel := context.WithTimeout(ctx, interval)
+			r.CheckHealth(checkCtx)
+			cancel()
+		}
+	}
+}
+
+// Close closes the pools of the replicas
+func (r *ReplicaSet) Close() error {
+	var err error
+	for _, pool := range r.pools {
+		if closeErr := pool.Close(); closeErr != nil {
+			err = closeErr
+		}
+	}
+	return err
+}
+
+// memoryWriteWindows keeps the write windows in the memory of the process
+type memoryWriteWindows struct {
+	clock clock.Clock
+
+	mu     sync.Mutex
+	writes map[int]time.Time
+}
+
+// NewMemoryWriteWindows returns write windows that are only seen by this process, for a single instance of the app
+func NewMemoryWriteWindows(c clock.Clock) WriteWindows {
+	return &memoryWriteWindows{clock: c, writes: map[int]time.Time{}}
+}
+
+// Start starts the window of the user and forgets the windows that are over
+func (w *memoryWriteWindows) Start(ctx context.Context, userID int, window time.Duration) error {
+	w.mu.Lock()
+	defer w.mu.Unlock()
+	now := w.clock.Now()
+	for id, until := range w.writes {
+		if !now.Before(until) {
+			delete(w.writes, id)
+		}
+	}
+	w.writes[userID] = now.Add(window)
+	return nil
+}
+
+// Running ...
+func (w *memoryWriteWindows) Running(ctx context.Context, userID int) (bool, error) {
+	w.mu.Lock()
+	defer w.mu.Unlock()
+	until, ok := w.writes[userID]
+	return ok && w.clock.Now().Before(until), nil
+}
@@ -0,0 +1,166 @@
+package daos_test
+
+import (
+	"context"
+	"database/sql"
+	"fmt"
+	"testing"
+	"time"
+
+	"go-template/daos"
+	"go-template/testutls"
+
+	"github.com/DATA-DOG/go-sqlmock"
+	"github.com/stretchr/testify/assert"
+)
+
+// mockReplicas returns a replica set of n sqlmock pools whose pings are expected
+func mockReplicas(t *testing.T, n int, clock *testutls.FakeClock) (*daos.ReplicaSet, []*sql.DB, []sqlmock.Sqlmock) {
+	pools := make([]*sql.DB, n)
+	mocks := make([]sqlmock.Sqlmock, n)
+	for i := range pools {
+		db, mock, err := sqlmock.New(sqlmock.MonitorPingsOption(true))
+		if err != nil {
+			t.Fatalf("an error '%s' was not expected when opening a stub database connection", err)
+		}
+		pools[i], mocks[i] = db, mock
+	}
+	return daos.NewReplicaSet(pools, 5*time.Second, daos.NewMemoryWriteWindows(clock)), pools, mocks
+}
+
+func TestGetReadContextExecutor(t *testing.T) {
+	_, primary, _ := testutls.SetupMockDB(t)
+	defer primary.Close()
+	replicas, pools, mocks := mockReplicas(t, 1, &testutls.FakeClock{})
+	defer replicas.Close()
+
+	cases := []struct {
+		name      string
+		replicas  *daos.ReplicaSet
+		ctx       context.Context
+		unhealthy bool
+		want      interface{}
+	}{
+		{
+			name: "NoReplicas",
+			ctx:  context.Background(),
+			want: primary,
+		},
+		{
+			name:     "Replica",
+			replicas: replicas,
+			ctx:      context.Background(),
+			want:     pools[0],
+		},
+		{
+			name:     "Primary",
+			replicas: replicas,
+			ctx:      daos.WithPrimary(context.Background()),
+			want:     primary,
+		},
+		{
+			name:      "UnhealthyReplica",
+			replicas:  replicas,
+			ctx:       context.Background(),
+			unhealthy: true,
+			want:      primary,
+		},
+	}
+	for _, tt := range cases {
+		t.Run(tt.name, func(t *testing.T) {
+			daos.SetReplicas(tt.replicas)
+			defer daos.SetReplicas(nil)
+			if tt.unhealthy {
+				mocks[0].ExpectPing().WillReturnError(fmt.Errorf("connection refused"))
+				replicas.CheckHealth(context.Background())
+				defer func() {
+					mocks[0].ExpectPing()
+					replicas.CheckHealth(context.Background())
+				}()
+			}
+			assert.Equal(t, tt.want, daos.GetReadContextExecutor(nil, tt.ctx))
+		})
+	}
+}
+
+func TestGetReadContextExecutorInTx(t *testing.T) {
+	mock, primary, _ := testutls.SetupMockDB(t)
+	defer primary.Close()
+	replicas, _, _ := mockReplicas(t, 1, &testutls.FakeClock{})
+	defer replicas.Close()
+	daos.SetReplicas(replicas)
+	defer daos.SetReplicas(nil)
+
+	// the reads of a transaction see its writes
+	mock.ExpectBegin()
+	mock.ExpectCommit()
+	err := daos.WithTx(context.Background(), func(ctx context.Context) error {
+		assert.Equal(t, daos.TxFromContext(ctx), daos.GetReadContextExecutor(nil, ctx))
+		return nil
+	})
+	assert.Nil(t, err)
+	assert.Nil(t, mock.ExpectationsWereMet())
+}
+
+func TestReplicaSetRoundRobin(t *testing.T) {
+	_, primary, _ := testutls.SetupMockDB(t)
+	defer primary.Close()
+	replicas, pools, mocks := mockReplicas(t, 3, &testutls.FakeClock{})
+	defer replicas.Close()
+	daos.SetReplicas(replicas)
+	defer daos.SetReplicas(nil)
+
+	mocks[0].ExpectPing()
+	mocks[1].ExpectPing().WillReturnError(fmt.Errorf("connection refused"))
+	mocks[2].ExpectPing()
+	replicas.CheckHealth(context.Background())
+
+	// the unhealthy replica is skipped
+	used := map[interface{}]int{}
+	for i := 0; i < 4; i++ {
+		used[daos.GetReadContextExecutor(nil, context.Background())]++
+	}
+	assert.Equal(t, map[interface{}]int{pools[0]: 2, pools[2]: 2}, used)
+	for _, mock := range mocks {
+		assert.Nil(t, mock.ExpectationsWereMet())
+	}
+}
+
+func TestReplicaSetWriteWindow(t *testing.T) {
+	clock := &testutls.FakeClock{Time: time.Date(2023, 1, 1, 0, 0, 0, 0, time.UTC)}
+	replicas, _, _ := mockReplicas(t, 1, clock)
+	defer replicas.Close()
+
+	ctx := context.Background()
+	assert.False(t, replicas.WroteRecently(ctx, 1))
+	replicas.MarkWrite(ctx, 1)
+	assert.True(t, replicas.WroteRecently(ctx, 1))
+	assert.False(t, replicas.WroteRecently(ctx, 2))
+
+	clock.Time = clock.Time.Add(5 * time.Second)
+	assert.False(t, replicas.WroteRecently(ctx, 1))
+
+	// no window is started when it is 0
+	replicas = daos.NewReplicaSet(nil, 0, daos.NewMemoryWriteWindows(clock))
+	replicas.MarkWrite(ctx, 1)
+	assert.False(t, replicas.WroteRecently(ctx, 1))
+}
+
+// failingWriteWindows fails to start and look up every window
+type failingWriteWindows struct{}
+
+func (failingWriteWindows) Start(context.Context, int, time.Duration) error {
+	return fmt.Errorf("connection refused")
+}
+
+func (failingWriteWindows) Running(context.Context, int) (bool, error) {
+	return false, fmt.Errorf("connection refused")
+}
+
+func TestReplicaSetWriteWindowUnavailable(t *testing.T) {
+	replicas := daos.NewReplicaSet(nil, 5*time.Second, failingWriteWindows{})
+
+	// the reads go to the primary when the windows cannot be looked up
+	replicas.MarkWrite(context.Background(), 1)
+	assert.True(t, replicas.WroteRecently(context.Background(), 1))
+}
@@ -0,0 +1,163 @@
+package daos
+
+import (
+	"context"
+
+	"go-template/models"
+
+	"github.com/volatiletech/sqlboiler/v4/queries/qm"
+)
+
+//go:generate mockgen -source=repositories.go -destination=../testutls/repositories.go -package=testutls
+
+// UserRepository reads and writes the users
+type UserRepository interface {
+	FindByUserName(username string, ctx context.Context) (*models.User, error)
+	FindByEmail(email string, ctx context.Context) (*models.User, error)
+	FindByToken(token string, ctx context.Context) (*models.User, error)
+	FindByID(userID int, ctx context.Context) (*models.User, error)
+	FindAllWithCount(queryMods []qm.QueryMod, ctx context.Context) (models.UserSlice, int64, error)
+	Create(user models.User, ctx context.Context) (models.User, error)
+	Update(user models.User, ctx context.Context) (models.User, error)
+	// UpdateAtVersion returns ErrVersionConflict when the user no longer has version
+	UpdateAtVersion(user models.User, version int, ctx context.Context) (models.User, error)
+	Delete(user models.User, ctx context.Context) (int64, error)
+}
+
+// RoleRepository reads and writes the roles
+type RoleRepository interface {
+	FindByID(roleID int, ctx context.Context) (*models.Role, error)
+	Create(role models.Role, ctx context.Context) (models.Role, error)
+}
+
+// AuditEventRepository writes and reads the audit log
+type AuditEventRepository interface {
+	Create(event AuditEvent, ctx context.Context) (AuditEvent, error)
+	FindAllWithCount(filter AuditEventFilter, limit, offset int, ctx context.Context) ([]AuditEvent, int64, error)
+}
+
+// FeatureFlagRepository reads and writes the feature flags
+type FeatureFlagRepository interface {
+	FindByName(name string, ctx context.Context) (*FeatureFlag, error)
+	Upsert(flag FeatureFlag, ctx context.Context) (FeatureFlag, error)
+	Delete(name string, ctx context.Context) (int64, error)
+}
+
+// PostgresUserRepository is the UserRepository of the users table, it takes part in the transaction of daos.WithTx
+type PostgresUserRepository struct{}
+
+// NewUserRepository ...
+func NewUserRepository() *PostgresUserRepository {
+	return &PostgresUserRepository{}
+}
+
+// FindByUserName ...
+func (PostgresUserRepository) FindByUserName(username string, ctx context.Context) (*models.User, error) {
+	return FindUserByUserName(username, ctx)
+}
+
+// FindByEmail ...
+func (PostgresUserRepository) FindByEmail(email string, ctx context.Context) (*models.User, error) {
+	return FindUserByEmail(email, ctx)
+}
+
+// FindByToken ...
+func (PostgresUserRepository) FindByToken(token string, ctx context.Context) (*models.User, error) {
+	return FindUserByToken(token, ctx)
+}
+
+// FindByID ...
+func (PostgresUserRepository) FindByID(userID int, ctx context.Context) (*models.User, error) {
+	return FindUserByID(userID, ctx)
+}
+
+// FindAllWithCount ...
+func (PostgresUserRepository) FindAllWithCount(queryMods []qm.QueryMod, ctx context.Context) (
+	models.UserSlice, int64, error,
+) {
+	return FindAllUsersWithCount(queryMods, ctx)
+}
+
+// Create ...
+func (PostgresUserRepository) Create(user models.User, ctx context.Context) (models.User, error) {
+	return CreateUser(user, ctx)
+}
+
+// Update ...
+func (PostgresUserRepository) Update(user models.User, ctx context.Context) (models.User, error) {
+	return UpdateUser(user, ctx)
+}
+
+// UpdateAtVersion ...
+func (PostgresUserRepository) UpdateAtVersion(user models.User, version int, ctx context.Context) (
+	models.User, error,
+) {
+	return UpdateUserAtVersion(user, version, ctx)
+}
+
+// Delete ...
+func (PostgresUserRepository) Delete(user models.User, ctx context.Context) (int64, error) {
+	return DeleteUser(user, ctx)
+}
+
+// PostgresRoleRepository is the RoleRepository of the roles table, it takes part in the transaction of daos.WithTx
+type PostgresRoleRepository struct{}
+
+// NewRoleRepository ...
+func NewRoleRepository() *PostgresRoleRepository {
+	return &PostgresRoleRepository{}
+}
+
+// FindByID ...
+func (PostgresRoleRepository) FindByID(roleID int, ctx context.Context) (*models.Role, error) {
+	return FindRoleByID(roleID, ctx)
+}
+
+// Create ...
+func (PostgresRoleRepository) Create(role models.Role, ctx context.Context) (models.Role, error) {
+	return CreateRole(role, ctx)
+}
+
+// PostgresAuditEventRepository is the AuditEventRepository of the audit_events table, it takes part in the
+// transaction of daos.WithTx
+type PostgresAuditEventRepository struct{}
+
+// NewAuditEventRepository ...
+func NewAuditEventRepository() *PostgresAuditEventRepository {
+	return &PostgresAuditEventRepository{}
+}
+
+// Create ...
+func (PostgresAuditEventRepository) Create(event AuditEvent, ctx context.Context) (AuditEvent, error) {
+	return CreateAuditEvent(event, ctx)
+}
+
+// FindAllWithCount ...
+func (PostgresAuditEventRepository) FindAllWithCount(filter AuditEventFilter, limit, offset int, ctx context.Context) (
+	[]AuditEvent, int64, error,
+) {
+	return FindAuditEventsWithCount(filter, limit, offset, ctx)
+}
+
+// PostgresFeatureFlagRepository is the FeatureFlagRepository of the feature_flags table
+type PostgresFeatureFlagRepository struct{}
+
+// NewFeatureFlagRepository ...
+func NewFeatureFlagRepository() *PostgresFeatureFlagRepository {
+	return &PostgresFeatureFlagRepository{}
+}
+
+// FindByName ...
+func (PostgresFeatureFlagRepository) FindByName(name string, ctx context.Context) (*FeatureFlag, error) {
+	return FindFeatureFlagByName(name, ctx)
+}
+
+// Upsert ...
+func (PostgresFeatureFlagRepository) Upsert(flag FeatureFlag, ctx context.Context) (FeatureFlag, error) {
+	return UpsertFeatureFlag(flag, ctx)
+}
+
+// Delete ...
+func (PostgresFeatureFlagRepository) Delete(name string, ctx context.Context) (int64, error) {
+	return DeleteFeatureFlag(name, ctx)
+}
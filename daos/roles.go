@@ -11,7 +11,7 @@ import (
 
 // CreateRoleTx ...
 func CreateRoleTx(role models.Role, ctx context.Context, tx *sql.Tx) (models.Role, error) {
-	contextExecutor := GetContextExecutor(tx)
+	contextExecutor := GetContextExecutor(tx, ctx)
 
 	err := role.Insert(ctx, contextExecutor, boil.Infer())
 	return role, err
@@ -24,6 +24,6 @@ func CreateRole(role models.Role, ctx context.Context) (models.Role, error) {
 
 // FindRoleByID ...
 func FindRoleByID(roleID int, ctx context.Context) (*models.Role, error) {
-	contextExecutor := GetContextExecutor(nil)
+	contextExecutor := GetReadContextExecutor(nil, ctx)
 	return models.FindRole(ctx, contextExecutor, roleID)
 }
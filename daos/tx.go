@@ -0,0 +1,128 @@
+package daos
+
+import (
+	"context"
+	"database/sql"
+	"errors"
+	"fmt"
+	"time"
+
+	"github.com/lib/pq"
+	"github.com/volatiletech/sqlboiler/v4/boil"
+)
+
+const (
+	// maxTxAttempts is the number of times WithTx runs a transaction that fails on serialization or a deadlock
+	maxTxAttempts = 3
+	// txRetryBackoff is multiplied by the attempt to get the wait before retrying a transaction
+	txRetryBackoff = 20 * time.Millisecond
+	// serializationFailure is the SQLSTATE of a transaction that could not be serialized with a concurrent one
+	serializationFailure = "40001"
+	// deadlockDetected is the SQLSTATE of a transaction postgres aborted to break a deadlock
+	deadlockDetected = "40P01"
+)
+
+type txCtxKey struct{}
+
+// txState is the transaction WithTx puts in the context along with the number of savepoints taken in it
+type txState struct {
+	tx         *sql.Tx
+	savepoints int
+}
+
+// TxFromContext returns the transaction WithTx put in the context, or nil outside of one
+func TxFromContext(ctx context.Context) *sql.Tx {
+	if state, ok := ctx.Value(txCtxKey{}).(*txState); ok {
+		return state.tx
+	}
+	return nil
+}
+
+// WithTx runs fn in a transaction that every DAO called with the context given to fn takes part in. The
+// transaction is committed when fn returns nil and rolled back when it returns an error or panics.
+//
+// A WithTx nested in another one runs fn under a savepoint, so only the changes of the inner fn are rolled back
+// when it fails. The outermost WithTx runs fn again when the transaction fails on serialization or a deadlock, fn
+// must then have no effect outside of the database. The transaction must not be used by several goroutines at once.
+//
+// The transaction has the default isolation level of the database, READ COMMITTED for postgres, which never fails
+// on serialization. Use WithTxOptions for the transactions that need a stricter level.
+func WithTx(ctx context.Context, fn func(ctx context.Context) error) error {
+	return WithTxOptions(ctx, nil, fn)
+}
+
+// WithTxOptions is WithTx with the options the transaction is begun with, such as a REPEATABLE READ or SERIALIZABLE
+// isolation level under which the concurrent transactions fail on serialization rather than overwrite each other.
+// The options are ignored when ctx is already in a transaction, fn then runs under a savepoint of that one.
+func WithTxOptions(ctx context.Context, opts *sql.TxOptions, fn func(ctx context.Context) error) error {
+	if state, ok := ctx.Value(txCtxKey{}).(*txState); ok {
+		return withSavepoint(ctx, state, fn)
+	}
+	var err error
+	for attempt := 1; attempt <= maxTxAttempts; attempt++ {
+		err = runTx(ctx, opts, fn)
+		if !(IsSerializationFailure(err) || IsDeadlock(err)) || attempt == maxTxAttempts {
+			break
+		}
+		select {
+		case <-ctx.Done():
+			return err
+		case <-time.After(time.Duration(attempt) * txRetryBackoff):
+		}
+	}
+	return err
+}
+
+// IsSerializationFailure returns whether err is postgres failing to serialize the transaction with a concurrent one
+func IsSerializationFailure(err error) bool {
+	var pqErr *pq.Error
+	return errors.As(err, &pqErr) && pqErr.Code == serializationFailure
+}
+
+// IsDeadlock returns whether err is postgres aborting the transaction to break a deadlock with a concurrent one
+func IsDeadlock(err error) bool {
+	var pqErr *pq.Error
+	return errors.As(err, &pqErr) && pqErr.Code == deadlockDetected
+}
+
+func runTx(ctx context.Context, opts *sql.TxOptions, fn func(ctx context.Context) error) error {
+	tx, err := boil.BeginTx(ctx, opts)
+	if err != nil {
+		return err
+	}
+	defer func() {
+		if p := recover(); p != nil {
+			_ = tx.Rollback()
+			panic(p)
+		}
+	}()
+
+	if err := fn(context.WithValue(ctx, txCtxKey{}, &txState{tx: tx})); err != nil {
+		_ = tx.Rollback()
+		return err
+	}
+	return tx.Commit()
+}
+
+func withSavepoint(ctx context.Context, state *txState, fn func(ctx context.Context) error) error {
+	state.savepoints++
+	name := fmt.Sprintf("sp_%d", state.savepoints)
+	if _, err := state.tx.ExecContext(ctx, "SAVEPOINT "+name); err != nil {
+		return err
+	}
+	defer func() {
+		if p := recover(); p != nil {
+			_, _ = state.tx.ExecContext(ctx, "ROLLBACK TO SAVEPOINT "+name)
+			panic(p)
+		}
+	}()
+
+	if err := fn(ctx); err != nil {
+		if _, rollbackErr := state.tx.ExecContext(ctx, "ROLLBACK TO SAVEPOINT "+name); rollbackErr != nil {
+			return rollbackErr
+		}
+		return err
+	}
+	_, err := state.tx.ExecContext(ctx, "RELEASE SAVEPOINT "+name)
+	return err
+}
@@ -0,0 +1,202 @@
+package daos_test
+
+import (
+	"context"
+	"database/sql"
+	"database/sql/driver"
+	"fmt"
+	"regexp"
+	"testing"
+
+	"go-template/daos"
+	"go-template/testutls"
+
+	"github.com/DATA-DOG/go-sqlmock"
+	"github.com/lib/pq"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestWithTx(t *testing.T) {
+	serializationFailure := &pq.Error{Code: "40001", Message: "could not serialize access"}
+	deadlock := &pq.Error{Code: "40P01", Message: "deadlock detected"}
+	deleteFlag := func(ctx context.Context) error {
+		_, err := daos.DeleteFeatureFlag("beta", ctx)
+		return err
+	}
+	expectDelete := func(mock sqlmock.Sqlmock) {
+		mock.ExpectExec(regexp.QuoteMeta(`DELETE FROM feature_flags WHERE name = $1`)).
+			WithArgs("beta").
+			WillReturnResult(sqlmock.NewResult(0, 1))
+	}
+	cases := []struct {
+		name      string
+		init      func(mock sqlmock.Sqlmock)
+		opts      *sql.TxOptions
+		fn        func(ctx context.Context) error
+		wantErr   error
+		wantPanic bool
+		wantCalls int
+	}{
+		{
+			name: "Success_Commit",
+			init: func(mock sqlmock.Sqlmock) {
+				mock.ExpectBegin()
+				expectDelete(mock)
+				mock.ExpectCommit()
+			},
+			fn:        deleteFlag,
+			wantCalls: 1,
+		},
+		{
+			name: "Failure_Rollback",
+			init: func(mock sqlmock.Sqlmock) {
+				mock.ExpectBegin()
+				expectDelete(mock)
+				mock.ExpectRollback()
+			},
+			fn: func(ctx context.Context) error {
+				if err := deleteFlag(ctx); err != nil {
+					return err
+				}
+				return fmt.Errorf("flag in use")
+			},
+			wantErr:   fmt.Errorf("flag in use"),
+			wantCalls: 1,
+		},
+		{
+			name: "Failure_PanicRollsBack",
+			init: func(mock sqlmock.Sqlmock) {
+				mock.ExpectBegin()
+				mock.ExpectRollback()
+			},
+			fn: func(ctx context.Context) error {
+				panic("unexpected")
+			},
+			wantPanic: true,
+			wantCalls: 1,
+		},
+		{
+			name: "Success_NestedSavepoints",
+			init: func(mock sqlmock.Sqlmock) {
+				mock.ExpectBegin()
+				mock.ExpectExec("SAVEPOINT sp_1").WillReturnResult(driver.ResultNoRows)
+				expectDelete(mock)
+				mock.ExpectExec("ROLLBACK TO SAVEPOINT sp_1").WillReturnResult(driver.ResultNoRows)
+				mock.ExpectExec("SAVEPOINT sp_2").WillReturnResult(driver.ResultNoRows)
+				expectDelete(mock)
+				mock.ExpectExec("RELEASE SAVEPOINT sp_2").WillReturnResult(driver.ResultNoRows)
+				mock.ExpectCommit()
+			},
+			fn: func(ctx context.Context) error {
+				// the failure of the first savepoint is undone and the transaction goes on
+				err := daos.WithTx(ctx, func(ctx context.Context) error {
+					_ = deleteFlag(ctx)
+					return fmt.Errorf("flag in use")
+				})
+				if err == nil {
+					return fmt.Errorf("the savepoint should have failed")
+				}
+				return daos.WithTx(ctx, deleteFlag)
+			},
+			wantCalls: 1,
+		},
+		{
+			name: "Success_RetriedOnSerializationFailure",
+			init: func(mock sqlmock.Sqlmock) {
+				mock.ExpectBegin()
+				expectDelete(mock)
+				mock.ExpectCommit().WillReturnError(serializationFailure)
+				mock.ExpectBegin()
+				expectDelete(mock)
+				mock.ExpectCommit()
+			},
+			fn:        deleteFlag,
+			wantCalls: 2,
+		},
+		{
+			name: "Success_SerializableRetriedOnSerializationFailure",
+			init: func(mock sqlmock.Sqlmock) {
+				mock.ExpectBegin()
+				mock.ExpectExec(regexp.QuoteMeta(`DELETE FROM feature_flags`)).WillReturnError(serializationFailure)
+				mock.ExpectRollback()
+				mock.ExpectBegin()
+				expectDelete(mock)
+				mock.ExpectCommit()
+			},
+			opts:      &sql.TxOptions{Isolation: sql.LevelSerializable},
+			fn:        deleteFlag,
+			wantCalls: 2,
+		},
+		{
+			name: "Success_RetriedOnDeadlock",
+			init: func(mock sqlmock.Sqlmock) {
+				mock.ExpectBegin()
+				mock.ExpectExec(regexp.QuoteMeta(`DELETE FROM feature_flags`)).WillReturnError(deadlock)
+				mock.ExpectRollback()
+				mock.ExpectBegin()
+				expectDelete(mock)
+				mock.ExpectCommit()
+			},
+			fn:        deleteFlag,
+			wantCalls: 2,
+		},
+		{
+			name: "Failure_SerializationRetriesExhausted",
+			init: func(mock sqlmock.Sqlmock) {
+				for i := 0; i < 3; i++ {
+					mock.ExpectBegin()
+					mock.ExpectExec(regexp.QuoteMeta(`DELETE FROM feature_flags`)).WillReturnError(serializationFailure)
+					mock.ExpectRollback()
+				}
+			},
+			fn:        deleteFlag,
+			wantErr:   serializationFailure,
+			wantCalls: 3,
+		},
+		{
+			name: "Failure_Begin",
+			init: func(mock sqlmock.Sqlmock) {
+				mock.ExpectBegin().WillReturnError(fmt.Errorf("connection refused"))
+			},
+			fn:      deleteFlag,
+			wantErr: fmt.Errorf("connection refused"),
+		},
+	}
+	for _, tt := range cases {
+		t.Run(tt.name, func(t *testing.T) {
+			mock, db, _ := testutls.SetupMockDB(t)
+			defer db.Close()
+			tt.init(mock)
+
+			calls := 0
+			fn := func(ctx context.Context) error {
+				calls++
+				assert.NotNil(t, daos.TxFromContext(ctx))
+				return tt.fn(ctx)
+			}
+			if tt.wantPanic {
+				assert.Panics(t, func() { _ = daos.WithTx(context.Background(), fn) })
+			} else if tt.opts != nil {
+				err := daos.WithTxOptions(context.Background(), tt.opts, fn)
+				assert.Equal(t, tt.wantErr, err)
+			} else {
+				err := daos.WithTx(context.Background(), fn)
+				assert.Equal(t, tt.wantErr, err)
+			}
+			assert.Equal(t, tt.wantCalls, calls)
+			assert.Nil(t, mock.ExpectationsWereMet())
+		})
+	}
+}
+
+func TestIsSerializationFailure(t *testing.T) {
+	assert.True(t, daos.IsSerializationFailure(fmt.Errorf("update: %w", &pq.Error{Code: "40001"})))
+	assert.False(t, daos.IsSerializationFailure(&pq.Error{Code: "23505"}))
+	assert.False(t, daos.IsSerializationFailure(nil))
+}
+
+func TestIsDeadlock(t *testing.T) {
+	assert.True(t, daos.IsDeadlock(fmt.Errorf("update: %w", &pq.Error{Code: "40P01"})))
+	assert.False(t, daos.IsDeadlock(&pq.Error{Code: "40001"}))
+	assert.False(t, daos.IsDeadlock(nil))
+}
@@ -3,44 +3,47 @@ package daos
 import (
 	"context"
 	"database/sql"
+	"errors"
 	"fmt"
+	"time"
 
 	"go-template/models"
 
+	"github.com/volatiletech/null/v8"
 	"github.com/volatiletech/sqlboiler/v4/boil"
 	"github.com/volatiletech/sqlboiler/v4/queries/qm"
 )
 
 // FindUserByUserName finds user by username
 func FindUserByUserName(username string, ctx context.Context) (*models.User, error) {
-	contextExecutor := GetContextExecutor(nil)
+	contextExecutor := GetReadContextExecutor(nil, ctx)
 	return models.Users(qm.Where(fmt.Sprintf("%s=?", models.UserColumns.Username), username)).
 		One(ctx, contextExecutor)
 }
 
 // FindUserByEmail ...
 func FindUserByEmail(email string, ctx context.Context) (*models.User, error) {
-	contextExecutor := GetContextExecutor(nil)
+	contextExecutor := GetReadContextExecutor(nil, ctx)
 	return models.Users(qm.Where(fmt.Sprintf("%s=?", models.UserColumns.Email), email)).
 		One(ctx, contextExecutor)
 }
 
 // FindUserByToken ...
 func FindUserByToken(token string, ctx context.Context) (*models.User, error) {
-	contextExecutor := GetContextExecutor(nil)
+	contextExecutor := GetReadContextExecutor(nil, ctx)
 	return models.Users(qm.Where(fmt.Sprintf("%s=?", models.UserColumns.Token), token)).
 		One(ctx, contextExecutor)
 }
 
 // FindUserByID ...
 func FindUserByID(userID int, ctx context.Context) (*models.User, error) {
-	contextExecutor := GetContextExecutor(nil)
+	contextExecutor := GetReadContextExecutor(nil, ctx)
 	return models.FindUser(ctx, contextExecutor, userID)
 }
 
 // CreateUserTx ...
 func CreateUserTx(user models.User, ctx context.Context, tx *sql.Tx) (models.User, error) {
-	contextExecutor := GetContextExecutor(tx)
+	contextExecutor := GetContextExecutor(tx, ctx)
 
 	err := user.Insert(ctx, contextExecutor, boil.Infer())
 	return user, err
@@ -51,28 +54,71 @@ func CreateUser(user models.User, ctx context.Context) (models.User, error) {
 	return CreateUserTx(user, ctx, nil)
 }
 
-// UpdateUserTx ...
+// ErrVersionConflict is returned when the user was updated by someone else since it was read
+var ErrVersionConflict = errors.New("the user was modified concurrently")
+
+// UpdateUserTx updates the user, and returns it with its next version and the new updated_at. sql.ErrNoRows is
+// returned when the user does not exist.
 func UpdateUserTx(user models.User, ctx context.Context, tx *sql.Tx) (models.User, error) {
-	contextExecutor := GetContextExecutor(tx)
-	_, err := user.Update(ctx, contextExecutor, boil.Infer())
-	return user, err
+	return updateUser(user, null.Int{}, ctx, tx)
 }
 
-// UpdateUserTx ...
+// UpdateUser ...
 func UpdateUser(user models.User, ctx context.Context) (models.User, error) {
 	return UpdateUserTx(user, ctx, nil)
 }
 
+// UpdateUserAtVersionTx updates the user if it still has version, the version the caller read it with.
+// ErrVersionConflict is returned when another update went first, and sql.ErrNoRows when the user does not exist.
+func UpdateUserAtVersionTx(user models.User, version int, ctx context.Context, tx *sql.Tx) (models.User, error) {
+	return updateUser(user, null.IntFrom(version), ctx, tx)
+}
+
+// UpdateUserAtVersion ...
+func UpdateUserAtVersion(user models.User, version int, ctx context.Context) (models.User, error) {
+	return UpdateUserAtVersionTx(user, version, ctx, nil)
+}
+
+// updateUser bumps the version of the user in the database rather than writing the one it was read with, so that
+// the updates that do not check the version do not fail when another update went first
+func updateUser(user models.User, version null.Int, ctx context.Context, tx *sql.Tx) (models.User, error) {
+	contextExecutor := GetContextExecutor(tx, ctx)
+	user.UpdatedAt = null.TimeFrom(time.Now().In(boil.GetLocation()))
+	query := `UPDATE users SET first_name = $1, last_name = $2, username = $3, password = $4, email = $5, ` +
+		`mobile = $6, address = $7, active = $8, last_login = $9, last_password_change = $10, token = $11, ` +
+		`role_id = $12, updated_at = $13, deleted_at = $14, version = version + 1 WHERE id = $15`
+	args := []interface{}{user.FirstName, user.LastName, user.Username, user.Password, user.Email, user.Mobile,
+		user.Address, user.Active, user.LastLogin, user.LastPasswordChange, user.Token, user.RoleID, user.UpdatedAt,
+		user.DeletedAt, user.ID}
+	if version.Valid {
+		query += ` AND version = $16`
+		args = append(args, version.Int)
+	}
+	err := contextExecutor.QueryRowContext(ctx, query+` RETURNING version`, args...).Scan(&user.Version)
+	if !errors.Is(err, sql.ErrNoRows) || !version.Valid {
+		return user, err
+	}
+	// no row has the version, either the user has another one or it is gone
+	exists, err := models.UserExists(ctx, contextExecutor, user.ID)
+	if err != nil {
+		return user, err
+	}
+	if !exists {
+		return user, sql.ErrNoRows
+	}
+	return user, ErrVersionConflict
+}
+
 // DeleteUser ...
 func DeleteUser(user models.User, ctx context.Context) (int64, error) {
-	contextExecutor := GetContextExecutor(nil)
+	contextExecutor := GetContextExecutor(nil, ctx)
 	rowsAffected, err := user.Delete(ctx, contextExecutor)
 	return rowsAffected, err
 }
 
 // FindAllUsersWithCount ... This will get all the users that match the queryMod filter and also return the count
 func FindAllUsersWithCount(queryMods []qm.QueryMod, ctx context.Context) (models.UserSlice, int64, error) {
-	contextExecutor := GetContextExecutor(nil)
+	contextExecutor := GetReadContextExecutor(nil, ctx)
 	users, err := models.Users(queryMods...).All(ctx, contextExecutor)
 	if err != nil {
 		return models.UserSlice{}, 0, err
@@ -2,6 +2,7 @@ package daos_test
 
 import (
 	"context"
+	"database/sql"
 	"database/sql/driver"
 	"fmt"
 	"log"
@@ -68,6 +69,7 @@ func TestCreateUserTx(t *testing.T) {
 			"token",
 			"role_id",
 			"deleted_at",
+			"version",
 		}).AddRow(
 			testutls.MockUser().FirstName,
 			testutls.MockUser().LastName,
@@ -80,6 +82,7 @@ func TestCreateUserTx(t *testing.T) {
 			testutls.MockUser().Token,
 			testutls.MockUser().RoleID,
 			testutls.MockUser().DeletedAt,
+			1,
 		)
 		mock.ExpectQuery(regexp.QuoteMeta(`INSERT INTO "users"`)).
 			WithArgs().
@@ -265,16 +268,45 @@ func TestFindUserByUserName(t *testing.T) {
 }
 
 func TestUpdateUserTx(t *testing.T) {
-
+	intPointer := func(i int) *int { return &i }
 	cases := []struct {
-		name string
-		req  models.User
-		err  error
+		name    string
+		req     models.User
+		version *int
+		updated bool
+		exists  bool
+		err     error
 	}{
 		{
-			name: "Passing user type value",
-			req:  models.User{},
-			err:  nil,
+			name:    "Passing user type value",
+			req:     models.User{ID: 1, Version: 3},
+			updated: true,
+			err:     nil,
+		},
+		{
+			name: "Missing user",
+			req:  models.User{ID: 1, Version: 3},
+			err:  sql.ErrNoRows,
+		},
+		{
+			name:    "Passing the version",
+			req:     models.User{ID: 1, Version: 3},
+			version: intPointer(3),
+			updated: true,
+			err:     nil,
+		},
+		{
+			name:    "Updated concurrently",
+			req:     models.User{ID: 1, Version: 3},
+			version: intPointer(3),
+			exists:  true,
+			err:     daos.ErrVersionConflict,
+		},
+		{
+			name:    "Missing user at version",
+			req:     models.User{ID: 1, Version: 3},
+			version: intPointer(3),
+			err:     sql.ErrNoRows,
 		},
 	}
 
@@ -296,14 +328,41 @@ func TestUpdateUserTx(t *testing.T) {
 		}()
 		boil.SetDB(db)
 
-		result := driver.Result(driver.RowsAffected(1))
-		// get access_token
-		mock.ExpectExec(regexp.QuoteMeta(`UPDATE "users" `)).
-			WillReturnResult(result)
+		// the version is bumped by the database, and only checked when the caller passed one
+		args := []driver.Value{sqlmock.AnyArg(), sqlmock.AnyArg(), sqlmock.AnyArg(), sqlmock.AnyArg(), sqlmock.AnyArg(),
+			sqlmock.AnyArg(), sqlmock.AnyArg(), sqlmock.AnyArg(), sqlmock.AnyArg(), sqlmock.AnyArg(),
+			sqlmock.AnyArg(), sqlmock.AnyArg(), sqlmock.AnyArg(), sqlmock.AnyArg(), 1}
+		where := `WHERE id = $15 RETURNING version`
+		if tt.version != nil {
+			args = append(args, *tt.version)
+			where = `WHERE id = $15 AND version = $16 RETURNING version`
+		}
+		rows := sqlmock.NewRows([]string{"version"})
+		if tt.updated {
+			rows.AddRow(4)
+		}
+		mock.ExpectQuery(regexp.QuoteMeta(`version = version + 1 ` + where)).
+			WithArgs(args...).
+			WillReturnRows(rows)
+		if tt.version != nil && !tt.updated {
+			mock.ExpectQuery(regexp.QuoteMeta(`select exists(select 1 from "users" where "id"=$1 limit 1)`)).
+				WithArgs(1).
+				WillReturnRows(sqlmock.NewRows([]string{"exists"}).AddRow(tt.exists))
+		}
 
 		t.Run(tt.name, func(t *testing.T) {
-			_, err := daos.UpdateUser(tt.req, context.Background())
-			assert.Equal(t, err, tt.err)
+			var user models.User
+			if tt.version != nil {
+				user, err = daos.UpdateUserAtVersion(tt.req, *tt.version, context.Background())
+			} else {
+				user, err = daos.UpdateUser(tt.req, context.Background())
+			}
+			assert.Equal(t, tt.err, err)
+			if tt.updated {
+				assert.Equal(t, 4, user.Version)
+			}
+			assert.True(t, user.UpdatedAt.Valid)
+			assert.Nil(t, mock.ExpectationsWereMet())
 		})
 	}
 }
@@ -2,6 +2,37 @@
 
 package gqlmodels
 
+type AuditEvent struct {
+	ID         string  `json:"id"`
+	Action     string  `json:"action"`
+	ActorID    *string `json:"actorId"`
+	TargetType string  `json:"targetType"`
+	TargetID   *string `json:"targetId"`
+	IP         *string `json:"ip"`
+	RequestID  *string `json:"requestId"`
+	Diff       *string `json:"diff"`
+	CreatedAt  int     `json:"createdAt"`
+}
+
+type AuditEventFilter struct {
+	Action     *string `json:"action"`
+	ActorID    *string `json:"actorId"`
+	TargetType *string `json:"targetType"`
+	TargetID   *string `json:"targetId"`
+	From       *int    `json:"from"`
+	To         *int    `json:"to"`
+}
+
+type AuditEventPagination struct {
+	Limit int `json:"limit"`
+	Page  int `json:"page"`
+}
+
+type AuditEventsPayload struct {
+	Total       int           `json:"total"`
+	AuditEvents []*AuditEvent `json:"auditEvents"`
+}
+
 type BooleanFilter struct {
 	IsTrue  *bool `json:"isTrue"`
 	IsFalse *bool `json:"isFalse"`
@@ -12,6 +43,39 @@ type ChangePasswordResponse struct {
 	Ok bool `json:"ok"`
 }
 
+type FeatureFlag struct {
+	Name              string   `json:"name"`
+	Description       *string  `json:"description"`
+	Enabled           bool     `json:"enabled"`
+	RolloutPercentage int      `json:"rolloutPercentage"`
+	Roles             []string `json:"roles"`
+	UserIds           []string `json:"userIds"`
+	CreatedAt         *int     `json:"createdAt"`
+	UpdatedAt         *int     `json:"updatedAt"`
+}
+
+type FeatureFlagDeletePayload struct {
+	Name string `json:"name"`
+}
+
+type FeatureFlagInput struct {
+	Name              string   `json:"name"`
+	Description       *string  `json:"description"`
+	Enabled           bool     `json:"enabled"`
+	RolloutPercentage *int     `json:"rolloutPercentage"`
+	Roles             []string `json:"roles"`
+	UserIds           []string `json:"userIds"`
+}
+
+type FeatureFlagPayload struct {
+	FeatureFlag *FeatureFlag `json:"featureFlag"`
+}
+
+type FeatureFlagValue struct {
+	Name    string `json:"name"`
+	Enabled bool   `json:"enabled"`
+}
+
 type FloatFilter struct {
 	EqualTo           *float64  `json:"equalTo"`
 	NotEqualTo        *float64  `json:"notEqualTo"`
@@ -155,6 +219,7 @@ type User struct {
 	CreatedAt          *int    `json:"createdAt"`
 	DeletedAt          *int    `json:"deletedAt"`
 	UpdatedAt          *int    `json:"updatedAt"`
+	Version            int     `json:"version"`
 }
 
 type UserCreateInput struct {
@@ -193,6 +258,7 @@ type UserUpdateInput struct {
 	LastName  *string `json:"lastName"`
 	Mobile    *string `json:"mobile"`
 	Address   *string `json:"address"`
+	Version   *int    `json:"version"`
 }
 
 type UserWhere struct {
@@ -0,0 +1,189 @@
+// Package audit records the security-relevant and administrative actions in the audit_events table
+package audit
+
+import (
+	"context"
+	"encoding/json"
+	"time"
+
+	"go-template/daos"
+	"go-template/internal/middleware/auth"
+	"go-template/pkg/utl/throttle"
+	"go-template/pkg/utl/zaplog"
+
+	"github.com/volatiletech/null/v8"
+	"go.uber.org/zap"
+)
+
+// The actions recorded in the audit log
+const (
+	LoginSucceeded  = "login_succeeded"
+	LoginFailed     = "login_failed"
+	PasswordChanged = "password_changed"
+	TokenRefreshed  = "token_refreshed"
+	UserCreated     = "user_created"
+	UserUpdated     = "user_updated"
+	UserDeleted     = "user_deleted"
+	RoleCreated     = "role_created"
+	// the feature flag actions are named after the mutation rather than the change
+	FeatureFlagUpserted = "feature_flag.upsert"
+	FeatureFlagDeleted  = "feature_flag.delete"
+)
+
+// The types of the targets of the actions
+const (
+	TargetUser        = "user"
+	TargetRole        = "role"
+	TargetFeatureFlag = "feature_flag"
+)
+
+// redacted replaces the values of the secret fields in the diff, a change of these fields is still recorded
+var redacted = json.RawMessage(`"[REDACTED]"`)
+
+// secretFields are the JSON fields of the models whose values never end up in the audit log
+var secretFields = map[string]bool{
+	"password": true,
+	"token":    true,
+}
+
+// Event is an action to record. The actor defaults to the user of the context, and the IP address and the
+// request id are always taken from the context.
+type Event struct {
+	Action     string
+	ActorID    int
+	TargetType string
+	TargetID   string
+	// Before and After are the target before and after the action, they are marshalled to JSON and only
+	// the fields that differ are kept in the diff
+	Before interface{}
+	After  interface{}
+}
+
+// Change is the value of a field before and after the action
+type Change struct {
+	From json.RawMessage `json:"from,omitempty"`
+	To   json.RawMessage `json:"to,omitempty"`
+}
+
+// Record stores the event with events. The action the event describes has already happened, so a failure is
+// logged rather than returned. Within daos.WithTx the event is committed along with the action, under a
+// savepoint so that the failure does not abort the transaction.
+func Record(ctx context.Context, events daos.AuditEventRepository, event Event) {
+	row := NewAuditEvent(ctx, event)
+	create := func(ctx context.Context) error {
+		_, err := events.Create(row, ctx)
+		return err
+	}
+	var err error
+	if daos.TxFromContext(ctx) != nil {
+		err = daos.WithTx(ctx, create)
+	} else {
+		err = create(ctx)
+	}
+	if err != nil {
+		zaplog.Error(ctx, "audit event not recorded", zap.String("action", event.Action), zap.Error(err))
+	}
+}
+
+// NewAuditEvent returns the row stored for the event
+func NewAuditEvent(ctx context.Context, event Event) daos.AuditEvent {
+	actorID := event.ActorID
+	if actorID == 0 {
+		actorID = auth.UserIDFromContext(ctx)
+	}
+	ip := throttle.IPFromContext(ctx)
+	row := daos.AuditEvent{
+		Action:     event.Action,
+		TargetType: event.TargetType,
+		TargetID:   null.NewString(event.TargetID, event.TargetID != ""),
+		IP:         null.NewString(ip, ip != ""),
+		ActorID:    null.NewInt(actorID, actorID != 0),
+	}
+	if rid, ok := ctx.Value(zaplog.RequestIdCtxKey).(string); ok && rid != "" {
+		row.RequestID = null.StringFrom(rid)
+	}
+	if diff := Diff(event.Before, event.After); len(diff) != 0 {
+		if data, err := json.Marshal(diff); err == nil {
+			row.Diff = null.JSONFrom(data)
+		}
+	}
+	return row
+}
+
+// Diff returns the fields of the JSON representations of before and after that differ, either can be nil
+// when the target is created or deleted
+func Diff(before, after interface{}) map[string]Change {
+	from, to := fields(before), fields(after)
+	diff := map[string]Change{}
+	for key, value := range from {
+		if next, ok := to[key]; !ok || string(next) != string(value) {
+			diff[key] = Change{From: value, To: to[key]}
+		}
+	}
+	for key, value := range to {
+		if _, ok := from[key]; !ok {
+			diff[key] = Change{To: value}
+		}
+	}
+	for key, change := range diff {
+		if secretFields[key] {
+			diff[key] = Change{From: redact(change.From), To: redact(change.To)}
+		}
+	}
+	return diff
+}
+
+// fields returns the raw JSON values of the fields of v, the null ones are left out
+func fields(v interface{}) map[string]json.RawMessage {
+	result := map[string]json.RawMessage{}
+	if v == nil {
+		return result
+	}
+	data, err := json.Marshal(v)
+	if err != nil {
+		return result
+	}
+	_ = json.Unmarshal(data, &result)
+	for key, value := range result {
+		if string(value) == "null" {
+			delete(result, key)
+		}
+	}
+	return result
+}
+
+func redact(value json.RawMessage) json.RawMessage {
+	if value == nil {
+		return nil
+	}
+	return redacted
+}
+
+// Prune deletes the events older than the retention and returns the number of deleted events
+func Prune(ctx context.Context, retention time.Duration) (int64, error) {
+	return daos.DeleteAuditEventsBefore(time.Now().Add(-retention), ctx)
+}
+
+// RunPruner prunes the events on interval until ctx is done, retentionDays is read on every run so a reloaded
+// configuration is picked up, and nothing is pruned while it is 0
+func RunPruner(ctx context.Context, interval time.Duration, retentionDays func() int) {
+	ticker := time.NewTicker(interval)
+	defer ticker.Stop()
+	for {
+		select {
+		case <-ctx.Done():
+			return
+		case <-ticker.C:
+			days := retentionDays()
+			if days <= 0 {
+				continue
+			}
+			n, err := Prune(ctx, time.Duration(days)*24*time.Hour)
+			if err != nil {
+				zaplog.Error(ctx, "audit events not pruned", zap.Error(err))
+				continue
+			}
+			zaplog.Info(ctx, "audit events pruned", zap.Int64("deleted", n))
+		}
+	}
+}
@@ -0,0 +1,163 @@
+package audit_test
+
+import (
+	"context"
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"regexp"
+	"testing"
+	"time"
+
+	"go-template/daos"
+	"go-template/internal/audit"
+	"go-template/internal/middleware/auth"
+	"go-template/models"
+	"go-template/pkg/utl/throttle"
+	"go-template/pkg/utl/zaplog"
+	"go-template/testutls"
+
+	"github.com/DATA-DOG/go-sqlmock"
+	"github.com/labstack/echo/v4"
+	"github.com/stretchr/testify/assert"
+	"github.com/volatiletech/null/v8"
+)
+
+func TestDiff(t *testing.T) {
+	user := models.User{
+		ID:        1,
+		FirstName: null.StringFrom("Mac"),
+		Password:  null.StringFrom("hash"),
+		Version:   1,
+	}
+	updated := user
+	updated.FirstName = null.StringFrom("Jack")
+	updated.Password = null.StringFrom("new hash")
+	updated.Version = 2
+
+	cases := []struct {
+		name   string
+		before interface{}
+		after  interface{}
+		want   string
+	}{
+		{
+			name:  "Created",
+			after: user,
+			want:  `{"first_name":{"to":"Mac"},"id":{"to":1},"password":{"to":"[REDACTED]"},"version":{"to":1}}`,
+		},
+		{
+			name:   "Updated",
+			before: user,
+			after:  updated,
+			want: `{"first_name":{"from":"Mac","to":"Jack"},"password":{"from":"[REDACTED]","to":"[REDACTED]"},` +
+				`"version":{"from":1,"to":2}}`,
+		},
+		{
+			name:   "Deleted",
+			before: user,
+			want:   `{"first_name":{"from":"Mac"},"id":{"from":1},"password":{"from":"[REDACTED]"},"version":{"from":1}}`,
+		},
+		{
+			name:   "Unchanged",
+			before: user,
+			after:  user,
+			want:   `{}`,
+		},
+	}
+	for _, tt := range cases {
+		t.Run(tt.name, func(t *testing.T) {
+			data, err := json.Marshal(audit.Diff(tt.before, tt.after))
+			assert.Nil(t, err)
+			assert.JSONEq(t, tt.want, string(data))
+		})
+	}
+}
+
+func TestNewAuditEvent(t *testing.T) {
+	e := echo.New()
+	req := httptest.NewRequest(http.MethodPost, "/graphql", nil)
+	req.Header.Set(echo.HeaderXRealIP, "10.0.0.1")
+	c := e.NewContext(req, httptest.NewRecorder())
+	var ctx context.Context
+	_ = throttle.GqlMiddleware()(func(c echo.Context) error {
+		ctx = c.Request().Context()
+		return nil
+	})(c)
+	ctx = context.WithValue(ctx, zaplog.RequestIdCtxKey, "rid")
+	ctx = context.WithValue(ctx, auth.UserCtxKey, testutls.MockUser())
+
+	row := audit.NewAuditEvent(ctx, audit.Event{
+		Action:     audit.UserUpdated,
+		TargetType: audit.TargetUser,
+		TargetID:   "2",
+		Before:     map[string]string{"address": "Pune"},
+		After:      map[string]string{"address": "Mumbai"},
+	})
+	assert.Equal(t, audit.UserUpdated, row.Action)
+	assert.Equal(t, null.IntFrom(testutls.MockID), row.ActorID)
+	assert.Equal(t, audit.TargetUser, row.TargetType)
+	assert.Equal(t, null.StringFrom("2"), row.TargetID)
+	assert.Equal(t, null.StringFrom("10.0.0.1"), row.IP)
+	assert.Equal(t, null.StringFrom("rid"), row.RequestID)
+	assert.JSONEq(t, `{"address":{"from":"Pune","to":"Mumbai"}}`, string(row.Diff.JSON))
+
+	// the actor of the event wins over the user of the context, e.g. on login
+	row = audit.NewAuditEvent(context.Background(), audit.Event{Action: audit.LoginSucceeded, ActorID: 3})
+	assert.Equal(t, null.IntFrom(3), row.ActorID)
+	assert.False(t, row.IP.Valid)
+	assert.False(t, row.RequestID.Valid)
+	assert.False(t, row.Diff.Valid)
+}
+
+func TestRecord(t *testing.T) {
+	mock, db, _ := testutls.SetupMockDB(t)
+	defer db.Close()
+	mock.ExpectQuery(regexp.QuoteMeta(`INSERT INTO audit_events`)).
+		WithArgs(audit.RoleCreated, null.IntFrom(3), audit.TargetRole, null.StringFrom("4"), null.String{},
+			null.String{}, sqlmock.AnyArg(), sqlmock.AnyArg()).
+		WillReturnError(context.DeadlineExceeded)
+
+	// the failure is only logged
+	audit.Record(context.Background(), daos.NewAuditEventRepository(), audit.Event{
+		Action:     audit.RoleCreated,
+		ActorID:    3,
+		TargetType: audit.TargetRole,
+		TargetID:   "4",
+		After:      models.Role{ID: 4, Name: "ADMIN"},
+	})
+	assert.Nil(t, mock.ExpectationsWereMet())
+}
+
+func TestPrune(t *testing.T) {
+	mock, db, _ := testutls.SetupMockDB(t)
+	defer db.Close()
+	mock.ExpectExec(regexp.QuoteMeta(`DELETE FROM audit_events WHERE created_at < $1`)).
+		WithArgs(sqlmock.AnyArg()).
+		WillReturnResult(sqlmock.NewResult(0, 5))
+
+	deleted, err := audit.Prune(context.Background(), 90*24*time.Hour)
+	assert.Nil(t, err)
+	assert.Equal(t, int64(5), deleted)
+	assert.Nil(t, mock.ExpectationsWereMet())
+}
+
+func TestRecordInTx(t *testing.T) {
+	mock, db, _ := testutls.SetupMockDB(t)
+	defer db.Close()
+	mock.ExpectBegin()
+	mock.ExpectExec("SAVEPOINT sp_1").WillReturnResult(sqlmock.NewResult(0, 0))
+	mock.ExpectQuery(regexp.QuoteMeta(`INSERT INTO audit_events`)).WillReturnError(context.DeadlineExceeded)
+	mock.ExpectExec("ROLLBACK TO SAVEPOINT sp_1").WillReturnResult(sqlmock.NewResult(0, 0))
+	mock.ExpectCommit()
+
+	// the event that cannot be written is rolled back to its savepoint and the transaction commits
+	err := daos.WithTx(context.Background(), func(ctx context.Context) error {
+		audit.Record(ctx, daos.NewAuditEventRepository(), audit.Event{
+			Action: audit.LoginSucceeded, ActorID: 1, TargetType: audit.TargetUser,
+		})
+		return nil
+	})
+	assert.Nil(t, err)
+	assert.Nil(t, mock.ExpectationsWereMet())
+}
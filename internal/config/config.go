@@ -3,72 +3,315 @@ package config
 import (
 	"fmt"
 	"os"
+	"reflect"
+	"strconv"
+	"strings"
+	"time"
 
-	"go-template/pkg/utl/convert"
+	"github.com/go-playground/validator"
+	"github.com/mitchellh/mapstructure"
+	"github.com/spf13/viper"
 )
 
-// Load returns Configuration struct
+// envKeys maps every configuration key to the environment variable overriding it
+var envKeys = map[string]string{
+	"server.port":                       "SERVER_PORT",
+	"server.debug":                      "SERVER_DEBUG",
+	"server.read_timeout_seconds":       "SERVER_READ_TIMEOUT",
+	"server.write_timeout_seconds":      "SERVER_WRITE_TIMEOUT",
+	"server.drain_seconds":              "SERVER_DRAIN_SECONDS",
+	"server.shutdown_timeout_seconds":   "SERVER_SHUTDOWN_TIMEOUT",
+	"server.tls_cert_file":              "SERVER_TLS_CERT_FILE",
+	"server.tls_key_file":               "SERVER_TLS_KEY_FILE",
+	"server.h2c":                        "SERVER_H2C",
+	"server.redirect_port":              "SERVER_REDIRECT_PORT",
+	"database.log_queries":              "DB_LOG_QUERIES",
+	"database.timeout_seconds":          "DB_TIMEOUT_SECONDS",
+	"database.migrate_on_boot":          "DB_MIGRATE_ON_BOOT",
+	"database.replica_dsns":             "DB_REPLICA_DSNS",
+	"database.write_window_seconds":     "DB_WRITE_WINDOW_SECONDS",
+	"database.replica_check_seconds":    "DB_REPLICA_CHECK_SECONDS",
+	"database.max_open_conns":           "DB_MAX_OPEN_CONNS",
+	"database.max_idle_conns":           "DB_MAX_IDLE_CONNS",
+	"database.max_lifetime_seconds":     "DB_CONN_MAX_LIFETIME_SECONDS",
+	"database.slow_query_ms":            "DB_SLOW_QUERY_MS",
+	"jwt.min_secret_length":             "JWT_MIN_SECRET_LENGTH",
+	"jwt.duration_minutes":              "JWT_DURATION_MINUTES",
+	"jwt.refresh_duration_minutes":      "JWT_REFRESH_DURATION",
+	"jwt.max_refresh_minutes":           "JWT_MAX_REFRESH",
+	"jwt.signing_algorithm":             "JWT_SIGNING_ALGORITHM",
+	"application.min_password_strength": "APP_MIN_PASSWORD_STR",
+	"admin.port":                        "ADMIN_PORT",
+	"tracing.service_name":              "SERVICE_NAME",
+	"tracing.exporter":                  "TRACING_EXPORTER",
+	"tracing.endpoint":                  "OTEL_EXPORTER_OTLP_ENDPOINT",
+	"tracing.insecure":                  "INSECURE_MODE",
+	"tracing.access_token":              "SIGNOZ_ACCESS_TOKEN",
+	"tracing.sample_ratio":              "TRACING_SAMPLE_RATIO",
+	"log.level":                         "LOG_LEVEL",
+	"log.format":                        "LOG_FORMAT",
+	"body_log.enabled":                  "BODY_LOG_ENABLED",
+	"body_log.max_bytes":                "BODY_LOG_MAX_BYTES",
+	"body_log.redact_fields":            "BODY_LOG_REDACT_FIELDS",
+	"health.check_timeout_seconds":      "HEALTH_CHECK_TIMEOUT_SECONDS",
+	"secrets.provider":                  "SECRETS_PROVIDER",
+	"secrets.dir":                       "SECRETS_DIR",
+	"secrets.json_env":                  "SECRETS_JSON_ENV",
+	"secrets.json_file":                 "SECRETS_JSON_FILE",
+	"secrets.refresh_seconds":           "SECRETS_REFRESH_SECONDS",
+	"throttle.limit":                    "THROTTLE_LIMIT",
+	"throttle.window_seconds":           "THROTTLE_WINDOW_SECONDS",
+	"cors.allow_origins":                "CORS_ALLOW_ORIGINS",
+	"cors.allow_methods":                "CORS_ALLOW_METHODS",
+	"cors.allow_headers":                "CORS_ALLOW_HEADERS",
+	"cors.allow_credentials":            "CORS_ALLOW_CREDENTIALS",
+	"security.hsts_max_age_seconds":     "SECURITY_HSTS_MAX_AGE",
+	"security.csp":                      "SECURITY_CSP",
+	"security.playground_csp":           "SECURITY_PLAYGROUND_CSP",
+	"security.referrer_policy":          "SECURITY_REFERRER_POLICY",
+	"security.permissions_policy":       "SECURITY_PERMISSIONS_POLICY",
+	"websocket.allow_origins":           "WEBSOCKET_ALLOW_ORIGINS",
+	"limits.body_bytes":                 "LIMITS_BODY_BYTES",
+	"limits.max_upload_bytes":           "LIMITS_MAX_UPLOAD_BYTES",
+	"limits.max_memory_bytes":           "LIMITS_MAX_MEMORY_BYTES",
+	"limits.operation_timeout_seconds":  "LIMITS_OPERATION_TIMEOUT",
+	"limits.operation_timeouts":         "LIMITS_OPERATION_TIMEOUTS",
+	"feature_flags.refresh_seconds":     "FEATURE_FLAGS_REFRESH_SECONDS",
+	"audit.retention_days":              "AUDIT_RETENTION_DAYS",
+}
+
+const (
+	// defaultCSP suits the JSON responses of the API
+	defaultCSP = "default-src 'none'; frame-ancestors 'none'; base-uri 'none'; form-action 'none'"
+	// defaultPlaygroundCSP lets the playground load GraphiQL from its CDN and open subscriptions
+	defaultPlaygroundCSP = "default-src 'self'; " +
+		"script-src 'self' 'unsafe-inline' https://cdn.jsdelivr.net; " +
+		"style-src 'self' 'unsafe-inline' https://cdn.jsdelivr.net; " +
+		"img-src 'self' data: https://cdn.jsdelivr.net; font-src 'self' data: https://cdn.jsdelivr.net; " +
+		"connect-src 'self' ws: wss:; frame-ancestors 'none'"
+)
+
+// defaults are used for the keys that are set neither in the config file nor in the environment
+var defaults = map[string]interface{}{
+	"jwt.signing_algorithm":            "HS256",
+	"server.shutdown_timeout_seconds":  10,
+	"tracing.sample_ratio":             1,
+	"log.level":                        "info",
+	"log.format":                       "json",
+	"body_log.enabled":                 false,
+	"body_log.max_bytes":               4096,
+	"health.check_timeout_seconds":     2,
+	"secrets.provider":                 "env",
+	"throttle.limit":                   5,
+	"throttle.window_seconds":          10,
+	"cors.allow_origins":               []string{"*"},
+	"cors.allow_methods":               []string{"HEAD", "POST", "GET", "PATCH", "DELETE", "PUT"},
+	"cors.allow_headers":               []string{"Authorization", "Content-Type", "X-Request-ID"},
+	"security.hsts_max_age_seconds":    5184000,
+	"security.csp":                     defaultCSP,
+	"security.playground_csp":          defaultPlaygroundCSP,
+	"security.referrer_policy":         "no-referrer",
+	"security.permissions_policy":      "accelerometer=(), camera=(), geolocation=(), microphone=(), payment=(), usb=()",
+	"limits.body_bytes":                1 << 20,
+	"limits.max_upload_bytes":          32 << 20,
+	"limits.max_memory_bytes":          8 << 20,
+	"limits.operation_timeout_seconds": 10,
+	"feature_flags.refresh_seconds":    30,
+	"audit.retention_days":             90,
+	"database.write_window_seconds":    5,
+	"database.replica_check_seconds":   10,
+	"database.max_open_conns":          25,
+	"database.max_idle_conns":          5,
+	"database.max_lifetime_seconds":    1800,
+	"database.slow_query_ms":           200,
+}
+
+// Load returns the configuration read from the YAML file named by CONFIG_FILE, if any, and from the environment.
+// The .env files are expected to have been loaded into the environment with LoadEnv beforehand.
 func Load() (*Configuration, error) {
-	cfg := &Configuration{
-		Server: &Server{
-			Port:         fmt.Sprintf(":%d", convert.StringToInt(os.Getenv("SERVER_PORT"))),
-			Debug:        convert.StringToBool(os.Getenv("SERVER_DEBUG")),
-			ReadTimeout:  convert.StringToInt(os.Getenv("SERVER_READ_TIMEOUT")),
-			WriteTimeout: convert.StringToInt(os.Getenv("SERVER_WRITE_TIMEOUT")),
-		},
-		DB: &Database{
-			LogQueries: convert.StringToBool(os.Getenv("DB_LOG_QUERIES")),
-			Timeout:    convert.StringToInt(os.Getenv("DB_TIMEOUT_SECONDS")),
-		},
-		JWT: &JWT{
-			MinSecretLength:  convert.StringToInt(os.Getenv("JWT_MIN_SECRET_LENGTH")),
-			DurationMinutes:  convert.StringToInt(os.Getenv("JWT_DURATION_MINUTES")),
-			RefreshDuration:  convert.StringToInt(os.Getenv("JWT_REFRESH_DURATION")),
-			MaxRefresh:       convert.StringToInt(os.Getenv("JWT_MAX_REFRESH")),
-			SigningAlgorithm: os.Getenv("JWT_SIGNING_ALGORITHM"),
-		},
-		App: &Application{
-			MinPasswordStr: convert.StringToInt(os.Getenv("APP_MIN_PASSWORD_STR")),
-		},
+	return LoadFile(os.Getenv("CONFIG_FILE"))
+}
+
+// LoadFile returns the configuration layered from the defaults, the YAML file at path and the environment,
+// each layer overriding the previous one. The configuration is validated and every problem found is
+// reported in a single error.
+func LoadFile(path string) (*Configuration, error) {
+	return load(path, os.LookupEnv)
+}
+
+// Reload returns the configuration read again from the YAML file and the .env files, the variables of the
+// process keep their precedence over the files
+func Reload() (*Configuration, error) {
+	lookup, err := reloadedEnv()
+	if err != nil {
+		return nil, err
+	}
+	path, _ := lookup("CONFIG_FILE")
+	return load(path, lookup)
+}
+
+func load(path string, lookup func(string) (string, bool)) (*Configuration, error) {
+	v := viper.New()
+	for key, value := range defaults {
+		v.SetDefault(key, value)
+	}
+	if path != "" {
+		v.SetConfigFile(path)
+		if err := v.ReadInConfig(); err != nil {
+			return nil, fmt.Errorf("error reading config file %s: %w", path, err)
+		}
 	}
-	if len(os.Getenv("SERVER_PORT")) == 0 {
-		return nil, fmt.Errorf("error loading port from .env ")
+	for key, env := range envKeys {
+		// empty variables are treated as unset
+		if value, ok := lookup(env); ok && value != "" {
+			v.Set(key, value)
+		}
 	}
-	if len(os.Getenv("DB_TIMEOUT_SECONDS")) == 0 {
-		return nil, fmt.Errorf("error loading db timeout from .env ")
+
+	cfg := &Configuration{
+		Server:    &Server{},
+		DB:        &Database{},
+		JWT:       &JWT{},
+		App:       &Application{},
+		Admin:     &Admin{},
+		Tracing:   &Tracing{},
+		Log:       &Log{},
+		BodyLog:   &BodyLog{},
+		Health:    &Health{},
+		Secrets:   &Secrets{},
+		Throttle:  &Throttle{},
+		Cors:      &Cors{},
+		Security:  &Security{},
+		Websocket: &Websocket{},
+		Limits:    &Limits{},
+		Flags:     &FeatureFlags{},
+		Audit:     &Audit{},
 	}
-	if len(os.Getenv("JWT_MIN_SECRET_LENGTH")) == 0 {
-		return nil, fmt.Errorf("error loading jwt min secret length from .env ")
+	var problems []string
+	err := v.Unmarshal(cfg, func(dc *mapstructure.DecoderConfig) {
+		dc.TagName = "json"
+	})
+	if err != nil {
+		if merr, ok := err.(*mapstructure.Error); ok {
+			problems = append(problems, merr.Errors...)
+		} else {
+			problems = append(problems, err.Error())
+		}
+	}
+	cfg.Server.Port = normalizePort(cfg.Server.Port)
+	cfg.Admin.Port = normalizePort(cfg.Admin.Port)
+	cfg.Server.RedirectPort = normalizePort(cfg.Server.RedirectPort)
+
+	problems = append(problems, Validate(cfg)...)
+	if _, err := cfg.Limits.OperationTimeouts(); err != nil {
+		problems = append(problems, err.Error())
 	}
-	if len(os.Getenv("APP_MIN_PASSWORD_STR")) == 0 {
-		return nil, fmt.Errorf("error loading application password string from .env ")
+	if cfg.Cors.AllowCredentials && contains(cfg.Cors.AllowOrigins, "*") {
+		problems = append(problems, "cors.allow_credentials (CORS_ALLOW_CREDENTIALS) can not be set when every origin is allowed")
 	}
-	if len(os.Getenv("SERVER_READ_TIMEOUT")) == 0 || len(os.Getenv("SERVER_WRITE_TIMEOUT")) == 0 {
-		return nil, fmt.Errorf("error loading server timeout from .env ")
+	if len(problems) != 0 {
+		return nil, fmt.Errorf("invalid configuration: %s", strings.Join(problems, "; "))
 	}
 	return cfg, nil
 }
 
+// Validate returns a description of every field of the configuration that fails its validation rules
+func Validate(cfg *Configuration) []string {
+	validate := validator.New()
+	validate.RegisterTagNameFunc(func(field reflect.StructField) string {
+		return strings.SplitN(field.Tag.Get("json"), ",", 2)[0]
+	})
+	err := validate.Struct(cfg)
+	if err == nil {
+		return nil
+	}
+	verrs, ok := err.(validator.ValidationErrors)
+	if !ok {
+		return []string{err.Error()}
+	}
+	problems := make([]string, 0, len(verrs))
+	for _, fe := range verrs {
+		// the namespace starts with the name of the root struct, e.g. Configuration.server.port
+		key := strings.SplitN(fe.Namespace(), ".", 2)[1]
+		if env, ok := envKeys[key]; ok {
+			key = fmt.Sprintf("%s (%s)", key, env)
+		}
+		if fe.Tag() == "required" {
+			problems = append(problems, fmt.Sprintf("%s is required", key))
+		} else {
+			problems = append(problems, fmt.Sprintf("%s must satisfy %s=%s, got %v", key, fe.Tag(), fe.Param(), fe.Value()))
+		}
+	}
+	return problems
+}
+
+// normalizePort prefixes a bare port number with a colon so that it can be used as a listen address
+func normalizePort(port string) string {
+	if port == "" || strings.Contains(port, ":") {
+		return port
+	}
+	return ":" + port
+}
+
 // Configuration holds data necessary for configuring application
 type Configuration struct {
-	Server *Server      `json:"server,omitempty"`
-	DB     *Database    `json:"database,omitempty"`
-	JWT    *JWT         `json:"jwt,omitempty"`
-	App    *Application `json:"application,omitempty"`
+	Server    *Server       `json:"server,omitempty"`
+	DB        *Database     `json:"database,omitempty"`
+	JWT       *JWT          `json:"jwt,omitempty"`
+	App       *Application  `json:"application,omitempty"`
+	Admin     *Admin        `json:"admin,omitempty"`
+	Tracing   *Tracing      `json:"tracing,omitempty"`
+	Log       *Log          `json:"log,omitempty"`
+	BodyLog   *BodyLog      `json:"body_log,omitempty"`
+	Health    *Health       `json:"health,omitempty"`
+	Secrets   *Secrets      `json:"secrets,omitempty"`
+	Throttle  *Throttle     `json:"throttle,omitempty"`
+	Cors      *Cors         `json:"cors,omitempty"`
+	Security  *Security     `json:"security,omitempty"`
+	Websocket *Websocket    `json:"websocket,omitempty"`
+	Limits    *Limits       `json:"limits,omitempty"`
+	Flags     *FeatureFlags `json:"feature_flags,omitempty"`
+	Audit     *Audit        `json:"audit,omitempty"`
 }
 
 // Database holds data necessary for database configuration
 type Database struct {
 	LogQueries bool `json:"log_queries,omitempty"`
-	Timeout    int  `json:"timeout_seconds,omitempty"`
+	Timeout    int  `json:"timeout_seconds,omitempty" validate:"required"`
+	// MigrateOnBoot applies the pending migrations when the server starts
+	MigrateOnBoot bool `json:"migrate_on_boot,omitempty"`
+	// ReplicaDSNs are the read replicas serving the queries, the primary serves them when there are none
+	ReplicaDSNs []string `json:"replica_dsns,omitempty"`
+	// WriteWindowSeconds is the time the queries of a user are served by the primary after their mutations, the
+	// windows are kept in redis so that every instance of the app sees them
+	WriteWindowSeconds int `json:"write_window_seconds" validate:"gte=0"`
+	// ReplicaCheckSeconds is the interval the replicas are pinged on, an unhealthy replica serves no queries
+	ReplicaCheckSeconds int `json:"replica_check_seconds" validate:"gt=0"`
+	// MaxOpenConns, MaxIdleConns and MaxLifetimeSeconds bound the pool of the primary and the pool of each replica,
+	// 0 keeps the defaults of database/sql
+	MaxOpenConns       int `json:"max_open_conns"       validate:"gte=0"`
+	MaxIdleConns       int `json:"max_idle_conns"       validate:"gte=0"`
+	MaxLifetimeSeconds int `json:"max_lifetime_seconds" validate:"gte=0"`
+	// SlowQueryMillis is the duration from which the queries are logged when LogQueries is set
+	SlowQueryMillis int `json:"slow_query_ms" validate:"gte=0"`
 }
 
 // Server holds data necessary for server configuration
 type Server struct {
 	Port         string `json:"port"                  validate:"required"`
-	Debug        bool   `json:"debug"                 validate:"required"`
+	Debug        bool   `json:"debug"`
 	ReadTimeout  int    `json:"read_timeout_seconds"  validate:"required"`
 	WriteTimeout int    `json:"write_timeout_seconds" validate:"required"`
+	// DrainSeconds is the time readiness fails before the server shuts down on SIGTERM
+	DrainSeconds    int `json:"drain_seconds"            validate:"gte=0"`
+	ShutdownTimeout int `json:"shutdown_timeout_seconds" validate:"gte=0"`
+	// TLSCertFile and TLSKeyFile terminate TLS in the server when both are set
+	TLSCertFile string `json:"tls_cert_file,omitempty" validate:"required_with=TLSKeyFile"`
+	TLSKeyFile  string `json:"tls_key_file,omitempty"  validate:"required_with=TLSCertFile"`
+	// H2C serves HTTP/2 without TLS, it is ignored when TLS is enabled
+	H2C bool `json:"h2c,omitempty"`
+	// RedirectPort is the port redirecting HTTP requests to HTTPS
+	RedirectPort string `json:"redirect_port,omitempty"`
 }
 
 // JWT holds data necessary for JWT configuration
@@ -84,3 +327,127 @@ type JWT struct {
 type Application struct {
 	MinPasswordStr int `json:"min_password_strength" validate:"required"`
 }
+
+// Admin holds configuration of the internal admin server exposing metrics
+type Admin struct {
+	Port string `json:"port,omitempty"`
+}
+
+// Tracing holds data necessary for tracing configuration
+type Tracing struct {
+	ServiceName string  `json:"service_name,omitempty"`
+	Exporter    string  `json:"exporter,omitempty"`
+	Endpoint    string  `json:"endpoint,omitempty"`
+	Insecure    bool    `json:"insecure,omitempty"`
+	AccessToken string  `json:"access_token,omitempty"`
+	SampleRatio float64 `json:"sample_ratio,omitempty" validate:"gte=0,lte=1"`
+}
+
+// Log holds configuration of the application logger
+type Log struct {
+	Level  string `json:"level,omitempty"  validate:"omitempty,oneof=debug info warn error dpanic panic fatal"`
+	Format string `json:"format,omitempty" validate:"omitempty,oneof=json console"`
+}
+
+// Health holds configuration of the readiness checks
+type Health struct {
+	CheckTimeoutSeconds int `json:"check_timeout_seconds,omitempty"`
+}
+
+// Secrets holds configuration of the provider of the database credentials and the jwt secret
+type Secrets struct {
+	Provider       string `json:"provider,omitempty"        validate:"omitempty,oneof=env file json"`
+	Dir            string `json:"dir,omitempty"`
+	JSONEnv        string `json:"json_env,omitempty"`
+	JSONFile       string `json:"json_file,omitempty"`
+	RefreshSeconds int    `json:"refresh_seconds,omitempty" validate:"gte=0"`
+}
+
+// BodyLog holds configuration of the request and response body logging
+type BodyLog struct {
+	Enabled      bool     `json:"enabled,omitempty"`
+	MaxBytes     int      `json:"max_bytes,omitempty"`
+	RedactFields []string `json:"redact_fields,omitempty"`
+}
+
+// Throttle holds the limit of requests per user of the throttled mutations
+type Throttle struct {
+	Limit         int `json:"limit,omitempty"          validate:"gte=0"`
+	WindowSeconds int `json:"window_seconds,omitempty" validate:"gte=0"`
+}
+
+// Cors holds the policy of the cross-origin requests
+type Cors struct {
+	AllowOrigins     []string `json:"allow_origins,omitempty"`
+	AllowMethods     []string `json:"allow_methods,omitempty"`
+	AllowHeaders     []string `json:"allow_headers,omitempty"`
+	AllowCredentials bool     `json:"allow_credentials,omitempty"`
+}
+
+// Security holds the values of the security headers, the playground gets its own Content-Security-Policy
+// since it loads its scripts and styles from a CDN
+type Security struct {
+	HSTSMaxAgeSeconds     int    `json:"hsts_max_age_seconds"     validate:"gte=0"`
+	ContentSecurityPolicy string `json:"csp,omitempty"`
+	PlaygroundCSP         string `json:"playground_csp,omitempty"`
+	ReferrerPolicy        string `json:"referrer_policy,omitempty"`
+	PermissionsPolicy     string `json:"permissions_policy,omitempty"`
+}
+
+// Websocket holds the origins allowed to open a subscription, in addition to the origin of the server
+type Websocket struct {
+	AllowOrigins []string `json:"allow_origins,omitempty"`
+}
+
+// Limits holds the size limits of the requests and the deadlines of the GraphQL operations
+type Limits struct {
+	BodyBytes int64 `json:"body_bytes" validate:"gt=0"`
+	// MaxUploadBytes bounds the multipart requests, which are not bound by BodyBytes
+	MaxUploadBytes int64 `json:"max_upload_bytes" validate:"gt=0"`
+	// MaxMemoryBytes is the part of an upload kept in memory, the rest is written to temporary files
+	MaxMemoryBytes          int64 `json:"max_memory_bytes" validate:"gt=0"`
+	OperationTimeoutSeconds int   `json:"operation_timeout_seconds" validate:"gte=0"`
+	// OperationTimeoutOverrides are the timeouts of the root fields that need another one, as field=seconds
+	OperationTimeoutOverrides []string `json:"operation_timeouts,omitempty"`
+}
+
+// OperationTimeouts returns the timeout of each root field of OperationTimeoutOverrides
+func (l *Limits) OperationTimeouts() (map[string]time.Duration, error) {
+	timeouts := make(map[string]time.Duration, len(l.OperationTimeoutOverrides))
+	for _, override := range l.OperationTimeoutOverrides {
+		parts := strings.SplitN(override, "=", 2)
+		seconds := -1
+		if len(parts) == 2 {
+			var err error
+			if seconds, err = strconv.Atoi(strings.TrimSpace(parts[1])); err != nil {
+				seconds = -1
+			}
+		}
+		if seconds < 0 || strings.TrimSpace(parts[0]) == "" {
+			return nil, fmt.Errorf(
+				"limits.operation_timeouts (LIMITS_OPERATION_TIMEOUTS) must be field=seconds, got %s", override)
+		}
+		timeouts[strings.TrimSpace(parts[0])] = time.Duration(seconds) * time.Second
+	}
+	return timeouts, nil
+}
+
+// FeatureFlags holds configuration of the in-memory feature flag store
+type FeatureFlags struct {
+	RefreshSeconds int `json:"refresh_seconds,omitempty" validate:"gt=0"`
+}
+
+// Audit holds configuration of the audit log
+type Audit struct {
+	// RetentionDays is the age after which the audit events are pruned, 0 keeps them forever
+	RetentionDays int `json:"retention_days" validate:"gte=0"`
+}
+
+func contains(s []string, e string) bool {
+	for _, a := range s {
+		if a == e {
+			return true
+		}
+	}
+	return false
+}
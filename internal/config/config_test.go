@@ -2,14 +2,13 @@ package config_test
 
 import (
 	"fmt"
-	"os"
 	"testing"
+	"time"
 
 	"go-template/internal/config"
 	"go-template/pkg/utl/convert"
 	"go-template/testutls"
 
-	. "github.com/agiledragon/gomonkey/v2"
 	"github.com/stretchr/testify/assert"
 )
 
@@ -18,9 +17,9 @@ const SuccessCase = "Success"
 func TestLoad(t *testing.T) {
 	cases := []struct {
 		name     string
+		env      map[string]string
 		wantData *config.Configuration
 		wantErr  bool
-		errKey   string
 		error    string
 	}{
 		{
@@ -29,34 +28,55 @@ func TestLoad(t *testing.T) {
 			wantData: testutls.MockConfig(),
 		},
 		{
-			name:    "Failure__NO_SERVER_PORT",
+			name:    "Failure__MISSING_KEYS",
+			env:     map[string]string{"SERVER_PORT": "", "DB_TIMEOUT_SECONDS": "", "APP_MIN_PASSWORD_STR": ""},
 			wantErr: true,
-			errKey:  "SERVER_PORT",
-			error:   "error loading port from .env ",
+			error: "invalid configuration: server.port (SERVER_PORT) is required; " +
+				"database.timeout_seconds (DB_TIMEOUT_SECONDS) is required; " +
+				"application.min_password_strength (APP_MIN_PASSWORD_STR) is required",
 		},
 		{
-			name:    "Failure__NO_DB_TIMEOUT_SECONDS",
+			name:    "Failure__INVALID_TRACING_SAMPLE_RATIO",
+			env:     map[string]string{"TRACING_SAMPLE_RATIO": "1.5"},
 			wantErr: true,
-			errKey:  "DB_TIMEOUT_SECONDS",
-			error:   "error loading db timeout from .env ",
+			error:   "invalid configuration: tracing.sample_ratio (TRACING_SAMPLE_RATIO) must satisfy lte=1, got 1.5",
 		},
 		{
-			name:    "Failure__NO_JWT_MIN_SECRET_LENGTH",
+			name:    "Failure__INVALID_NUMBER_AND_LOG_FORMAT",
+			env:     map[string]string{"SERVER_READ_TIMEOUT": "ten", "LOG_FORMAT": "xml"},
 			wantErr: true,
-			errKey:  "JWT_MIN_SECRET_LENGTH",
-			error:   "error loading jwt min secret length from .env ",
+			error: "invalid configuration: cannot parse 'server.read_timeout_seconds' as int: " +
+				"strconv.ParseInt: parsing \"ten\": invalid syntax; " +
+				"server.read_timeout_seconds (SERVER_READ_TIMEOUT) is required; " +
+				"log.format (LOG_FORMAT) must satisfy oneof=json console, got xml",
 		},
 		{
-			name:    "Failure__NO_APP_MIN_PASSWORD_STR",
+			name:    "Failure__CORS_CREDENTIALS_WITH_ANY_ORIGIN",
+			env:     map[string]string{"CORS_ALLOW_CREDENTIALS": "true"},
 			wantErr: true,
-			errKey:  "APP_MIN_PASSWORD_STR",
-			error:   "error loading application password string from .env ",
+			error: "invalid configuration: cors.allow_credentials (CORS_ALLOW_CREDENTIALS) " +
+				"can not be set when every origin is allowed",
 		},
 		{
-			name:    "Failure__NO_SERVER_READ_TIMEOUT",
+			name:    "Failure__INVALID_OPERATION_TIMEOUTS",
+			env:     map[string]string{"LIMITS_OPERATION_TIMEOUTS": "createUser=30,users"},
 			wantErr: true,
-			errKey:  "SERVER_READ_TIMEOUT",
-			error:   "error loading server timeout from .env ",
+			error: "invalid configuration: limits.operation_timeouts (LIMITS_OPERATION_TIMEOUTS) " +
+				"must be field=seconds, got users",
+		},
+		{
+			name:    "Failure__TLS_KEY_WITHOUT_CERT",
+			env:     map[string]string{"SERVER_TLS_KEY_FILE": "server.key"},
+			wantErr: true,
+			error: "invalid configuration: server.tls_cert_file (SERVER_TLS_CERT_FILE) " +
+				"must satisfy required_with=TLSKeyFile, got ",
+		},
+		{
+			name:    "Failure__MISSING_CONFIG_FILE",
+			env:     map[string]string{"CONFIG_FILE": "testdata/missing.yaml"},
+			wantErr: true,
+			error: "error reading config file testdata/missing.yaml: " +
+				"open testdata/missing.yaml: no such file or directory",
 		},
 	}
 	for _, tt := range cases {
@@ -66,24 +86,13 @@ func TestLoad(t *testing.T) {
 			if err != nil {
 				fmt.Print("error loading .env file")
 			}
-			_, _, err = testutls.SetupMockDB(t)
-			if err != nil {
-				fmt.Print("error loading .env file")
-			}
-
-			if tt.wantErr {
-				patches := ApplyFunc(os.Getenv, func(key string) string {
-					if key == tt.errKey {
-						return ""
-					}
-					return key
-				})
-				defer patches.Reset()
+			for key, value := range tt.env {
+				t.Setenv(key, value)
 			}
 
 			config, err := config.Load()
 			if tt.wantData != nil {
-				assert.Equal(t, config, tt.wantData)
+				assert.Equal(t, tt.wantData, config)
 			}
 
 			isError := err != nil
@@ -95,3 +104,164 @@ func TestLoad(t *testing.T) {
 		})
 	}
 }
+
+func TestLoadFile(t *testing.T) {
+	cases := []struct {
+		name     string
+		path     string
+		env      map[string]string
+		wantData func(cfg *config.Configuration)
+		wantErr  bool
+	}{
+		{
+			name: "Success_FileOnly",
+			path: "testdata/config.testdata.yaml",
+			wantData: func(cfg *config.Configuration) {
+				cfg.Server = &config.Server{
+					Port: ":8080", Debug: true, ReadTimeout: 15, WriteTimeout: 20, ShutdownTimeout: 10,
+				}
+				cfg.DB = &config.Database{
+					LogQueries: true, Timeout: 20, WriteWindowSeconds: 5, ReplicaCheckSeconds: 10,
+					MaxOpenConns: 25, MaxIdleConns: 5, MaxLifetimeSeconds: 1800, SlowQueryMillis: 200,
+				}
+				cfg.JWT = &config.JWT{
+					MinSecretLength:  128,
+					DurationMinutes:  10,
+					RefreshDuration:  10,
+					MaxRefresh:       144,
+					SigningAlgorithm: "HS384",
+				}
+				cfg.App = &config.Application{MinPasswordStr: 3}
+			},
+		},
+		{
+			name: "Success_EnvOverridesFile",
+			path: "testdata/config.testdata.yaml",
+			env:  map[string]string{"SERVER_PORT": "9001", "JWT_SIGNING_ALGORITHM": "HS512"},
+			wantData: func(cfg *config.Configuration) {
+				cfg.Server = &config.Server{
+					Port: ":9001", Debug: true, ReadTimeout: 15, WriteTimeout: 20, ShutdownTimeout: 10,
+				}
+				cfg.DB = &config.Database{
+					LogQueries: true, Timeout: 20, WriteWindowSeconds: 5, ReplicaCheckSeconds: 10,
+					MaxOpenConns: 25, MaxIdleConns: 5, MaxLifetimeSeconds: 1800, SlowQueryMillis: 200,
+				}
+				cfg.JWT = &config.JWT{
+					MinSecretLength:  128,
+					DurationMinutes:  10,
+					RefreshDuration:  10,
+					MaxRefresh:       144,
+					SigningAlgorithm: "HS512",
+				}
+				cfg.App = &config.Application{MinPasswordStr: 3}
+			},
+		},
+		{
+			name:    "Failure_InvalidFile",
+			path:    "testdata/config.invalid.yaml",
+			wantErr: true,
+		},
+	}
+	for _, tt := range cases {
+		t.Run(tt.name, func(t *testing.T) {
+			// only the file and the defaults are left once every bound variable is emptied
+			for _, key := range []string{
+				"SERVER_PORT", "SERVER_DEBUG", "SERVER_READ_TIMEOUT", "SERVER_WRITE_TIMEOUT", "SERVER_DRAIN_SECONDS",
+				"SERVER_SHUTDOWN_TIMEOUT", "SERVER_TLS_CERT_FILE", "SERVER_TLS_KEY_FILE", "SERVER_H2C",
+				"SERVER_REDIRECT_PORT", "DB_LOG_QUERIES",
+				"DB_TIMEOUT_SECONDS", "JWT_MIN_SECRET_LENGTH", "JWT_DURATION_MINUTES", "JWT_REFRESH_DURATION",
+				"JWT_MAX_REFRESH", "JWT_SIGNING_ALGORITHM", "APP_MIN_PASSWORD_STR", "ADMIN_PORT", "SERVICE_NAME",
+				"TRACING_EXPORTER", "OTEL_EXPORTER_OTLP_ENDPOINT", "INSECURE_MODE", "SIGNOZ_ACCESS_TOKEN",
+				"TRACING_SAMPLE_RATIO", "LOG_LEVEL", "LOG_FORMAT", "BODY_LOG_ENABLED", "BODY_LOG_MAX_BYTES",
+				"BODY_LOG_REDACT_FIELDS", "HEALTH_CHECK_TIMEOUT_SECONDS", "SECRETS_PROVIDER", "SECRETS_DIR",
+				"SECRETS_JSON_ENV", "SECRETS_JSON_FILE", "SECRETS_REFRESH_SECONDS", "THROTTLE_LIMIT",
+				"THROTTLE_WINDOW_SECONDS", "CORS_ALLOW_ORIGINS", "FEATURE_FLAGS_REFRESH_SECONDS",
+				"WEBSOCKET_ALLOW_ORIGINS", "CORS_ALLOW_METHODS", "CORS_ALLOW_HEADERS", "CORS_ALLOW_CREDENTIALS",
+				"SECURITY_HSTS_MAX_AGE", "SECURITY_CSP", "SECURITY_PLAYGROUND_CSP", "SECURITY_REFERRER_POLICY",
+				"SECURITY_PERMISSIONS_POLICY", "LIMITS_BODY_BYTES", "LIMITS_MAX_UPLOAD_BYTES", "LIMITS_MAX_MEMORY_BYTES",
+				"LIMITS_OPERATION_TIMEOUT", "LIMITS_OPERATION_TIMEOUTS", "DB_MIGRATE_ON_BOOT",
+				"AUDIT_RETENTION_DAYS", "DB_REPLICA_DSNS", "DB_WRITE_WINDOW_SECONDS", "DB_REPLICA_CHECK_SECONDS",
+				"DB_MAX_OPEN_CONNS", "DB_MAX_IDLE_CONNS", "DB_CONN_MAX_LIFETIME_SECONDS", "DB_SLOW_QUERY_MS",
+			} {
+				t.Setenv(key, "")
+			}
+			for key, value := range tt.env {
+				t.Setenv(key, value)
+			}
+
+			cfg, err := config.LoadFile(tt.path)
+			assert.Equal(t, tt.wantErr, err != nil)
+			if tt.wantData != nil {
+				want := &config.Configuration{
+					Admin:    &config.Admin{},
+					Tracing:  &config.Tracing{SampleRatio: 1},
+					Log:      &config.Log{Level: "info", Format: "json"},
+					BodyLog:  &config.BodyLog{MaxBytes: 4096},
+					Health:   &config.Health{CheckTimeoutSeconds: 2},
+					Secrets:  &config.Secrets{Provider: "env"},
+					Throttle: &config.Throttle{Limit: 5, WindowSeconds: 10},
+					Cors: &config.Cors{
+						AllowOrigins: []string{"*"},
+						AllowMethods: []string{"HEAD", "POST", "GET", "PATCH", "DELETE", "PUT"},
+						AllowHeaders: []string{"Authorization", "Content-Type", "X-Request-ID"},
+					},
+					Security: &config.Security{
+						HSTSMaxAgeSeconds:     5184000,
+						ContentSecurityPolicy: testutls.MockConfig().Security.ContentSecurityPolicy,
+						PlaygroundCSP:         testutls.MockConfig().Security.PlaygroundCSP,
+						ReferrerPolicy:        "no-referrer",
+						PermissionsPolicy:     testutls.MockConfig().Security.PermissionsPolicy,
+					},
+					Websocket: &config.Websocket{},
+					Limits: &config.Limits{
+						BodyBytes:               1 << 20,
+						MaxUploadBytes:          32 << 20,
+						MaxMemoryBytes:          8 << 20,
+						OperationTimeoutSeconds: 10,
+					},
+					Flags: &config.FeatureFlags{RefreshSeconds: 30},
+					Audit: &config.Audit{RetentionDays: 90},
+				}
+				tt.wantData(want)
+				assert.Equal(t, want, cfg)
+			}
+		})
+	}
+}
+
+func TestLimitsOperationTimeouts(t *testing.T) {
+	cases := map[string]struct {
+		overrides []string
+		want      map[string]time.Duration
+		err       string
+	}{
+		"Success": {
+			overrides: []string{"createUser=30", " users = 5 "},
+			want:      map[string]time.Duration{"createUser": 30 * time.Second, "users": 5 * time.Second},
+		},
+		"Failure_MissingField": {
+			overrides: []string{"=5"},
+			err:       "limits.operation_timeouts (LIMITS_OPERATION_TIMEOUTS) must be field=seconds, got =5",
+		},
+		"Failure_NotANumber": {
+			overrides: []string{"createUser=abc"},
+			err:       "limits.operation_timeouts (LIMITS_OPERATION_TIMEOUTS) must be field=seconds, got createUser=abc",
+		},
+		"Failure_Duration": {
+			overrides: []string{"createUser=10s"},
+			err:       "limits.operation_timeouts (LIMITS_OPERATION_TIMEOUTS) must be field=seconds, got createUser=10s",
+		},
+	}
+	for name, tt := range cases {
+		t.Run(name, func(t *testing.T) {
+			limits := &config.Limits{OperationTimeoutOverrides: tt.overrides}
+			timeouts, err := limits.OperationTimeouts()
+			if tt.err != "" {
+				assert.EqualError(t, err, tt.err)
+				return
+			}
+			assert.Nil(t, err)
+			assert.Equal(t, tt.want, timeouts)
+		})
+	}
+}
@@ -1,12 +1,11 @@
 package config
 
 import (
-	"encoding/json"
 	"fmt"
 	"go-template/pkg/utl/convert"
 	"log"
 	"os"
-	"strconv"
+	"strings"
 
 	"github.com/joho/godotenv"
 )
@@ -54,12 +53,27 @@ func FileName() string {
 	return envFileName
 }
 
+var (
+	// processEnv holds the variables set before the .env files were loaded, they take precedence over the files
+	processEnv map[string]string
+	// envFiles are the .env files loaded by LoadEnv in the order they were loaded
+	envFiles []string
+)
+
 func LoadEnvWithFilePrefix(fileprefix *string) error {
 	prefix := ""
 	if fileprefix != nil {
 		prefix = *fileprefix
 	}
-	err := godotenv.Load(fmt.Sprintf("%s.env.base", prefix))
+	if processEnv == nil {
+		processEnv = map[string]string{}
+		for _, kv := range os.Environ() {
+			parts := strings.SplitN(kv, "=", 2)
+			processEnv[parts[0]] = parts[1]
+		}
+	}
+	envFiles = []string{fmt.Sprintf("%s.env.base", prefix)}
+	err := godotenv.Load(envFiles[0])
 	if err != nil {
 		return err
 	}
@@ -72,7 +86,8 @@ func LoadEnvWithFilePrefix(fileprefix *string) error {
 
 	envVarInjection := GetBool("ENV_INJECTION")
 	if !envVarInjection || envName == "local" {
-		err = godotenv.Load(fmt.Sprintf("%s.env.%s", prefix, envName))
+		envFiles = append(envFiles, fmt.Sprintf("%s.env.%s", prefix, envName))
+		err = godotenv.Load(envFiles[1])
 
 		if err != nil {
 			fmt.Printf(".env.%s\n", envName)
@@ -81,33 +96,40 @@ func LoadEnvWithFilePrefix(fileprefix *string) error {
 		}
 	}
 
-	dbCredsInjected := GetBool("COPILOT_DB_CREDS_VIA_SECRETS_MANAGER")
-
-	if dbCredsInjected {
-		type copilotSecrets struct {
-			Username string `json:"username"`
-			Host     string `json:"host"`
-			DBName   string `json:"dbname"`
-			Password string `json:"password"`
-			Port     int    `json:"port"`
-		}
-		secrets := &copilotSecrets{}
+	return nil
+}
+func LoadEnv() error {
+	return LoadEnvWithFilePrefix(nil)
+}
 
-		err := json.Unmarshal([]byte(os.Getenv("DB_SECRET")), secrets)
+// reloadedEnv returns a lookup of the .env files read again from disk. Like with LoadEnv the first file
+// setting a variable wins and the variables of the process override the files.
+func reloadedEnv() (func(string) (string, bool), error) {
+	if processEnv == nil {
+		return os.LookupEnv, nil
+	}
+	values := map[string]string{}
+	for _, file := range envFiles {
+		fileValues, err := godotenv.Read(file)
 		if err != nil {
-			return err
+			return nil, err
+		}
+		for k, v := range fileValues {
+			if _, ok := values[k]; !ok {
+				values[k] = v
+			}
 		}
-
-		os.Setenv("PSQL_DBNAME", secrets.DBName)
-		os.Setenv("PSQL_HOST", secrets.Host)
-		os.Setenv("PSQL_PASS", secrets.Password)
-		os.Setenv("PSQL_PORT", strconv.Itoa(secrets.Port))
-		os.Setenv("PSQL_USER", secrets.Username)
-
 	}
-
-	return nil
+	for k, v := range processEnv {
+		values[k] = v
+	}
+	return func(key string) (string, bool) {
+		value, ok := values[key]
+		return value, ok
+	}, nil
 }
-func LoadEnv() error {
-	return LoadEnvWithFilePrefix(nil)
+
+// EnvFiles returns the .env files loaded by LoadEnv
+func EnvFiles() []string {
+	return envFiles
 }
@@ -161,16 +161,10 @@ func TestFileName(t *testing.T) {
 
 func TestLoadEnv(t *testing.T) {
 	type args struct {
-		env      string
-		err      string
-		tapped   bool
-		dbSecret string
+		env    string
+		err    string
+		tapped bool
 	}
-	username := "go_template_role"
-	host := "localhost"
-	dbname := "go_template"
-	password := "go_template_role456"
-	port := "5432"
 	tests := []struct {
 		name    string
 		wantErr bool
@@ -191,29 +185,12 @@ func TestLoadEnv(t *testing.T) {
 			wantErr: true,
 			args:    args{env: "local", tapped: false},
 		},
-		{
-			name:    "dbCredsInjected True",
-			wantErr: true,
-			args:    args{env: "", tapped: false},
-		},
-
 		{
 			name:    "Successfully load develop env",
 			wantErr: false,
 			args: args{
 				env:    "production",
 				tapped: false,
-				dbSecret: fmt.Sprintf(`{"username":"%s",`+
-					`"host":"%s","dbname":"%s","password":"%s",`+
-					`"port":%s}`, username, host, dbname, password, port),
-			},
-		},
-		{
-			name:    "dbCredsInjected True",
-			wantErr: false,
-			args: args{env: "", tapped: false, dbSecret: fmt.Sprintf(`{"username":"%s",`+
-				`"host":"%s","dbname":"%s","password":"%s",`+
-				`"port":%s}`, username, host, dbname, password, port),
 			},
 		},
 		{
@@ -243,29 +220,13 @@ func TestLoadEnv(t *testing.T) {
 
 		})
 		os.Setenv("ENVIRONMENT_NAME", tt.args.env)
-		if tt.args.dbSecret != "" {
-			os.Setenv("DB_SECRET", tt.args.dbSecret)
-		}
 		t.Run(tt.name, func(t *testing.T) {
-			if tt.name == "dbCredsInjected True" {
-				ApplyFunc(GetBool, func(key string) bool {
-					return true
-				})
-			}
-
 			tapped := tt.args.tapped
 
 			if err := LoadEnv(); (err != nil) != tt.wantErr {
 				t.Errorf("LoadEnv() error = %v, wantErr %v", err, tt.wantErr)
 			}
 			assert.Equal(t, tapped, !tt.args.tapped)
-			if tt.args.dbSecret != "" {
-				assert.Equal(t, os.Getenv("PSQL_USER"), username)
-				assert.Equal(t, os.Getenv("PSQL_HOST"), host)
-				assert.Equal(t, os.Getenv("PSQL_DBNAME"), dbname)
-				assert.Equal(t, os.Getenv("PSQL_PASS"), password)
-				assert.Equal(t, os.Getenv("PSQL_PORT"), port)
-			}
 		})
 	}
 }
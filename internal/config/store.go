@@ -0,0 +1,163 @@
+package config
+
+import (
+	"context"
+	"fmt"
+	"os"
+	"os/signal"
+	"reflect"
+	"strings"
+	"sync"
+	"sync/atomic"
+	"syscall"
+	"time"
+
+	"go-template/pkg/utl/zaplog"
+
+	"go.uber.org/zap"
+)
+
+// hotKeys are the configuration keys applied on reload, a change of any other key needs a restart
+var hotKeys = map[string]bool{
+	"application.min_password_strength": true,
+	"jwt.duration_minutes":              true,
+	"throttle.limit":                    true,
+	"throttle.window_seconds":           true,
+	"log.level":                         true,
+	"cors.allow_origins":                true,
+	"websocket.allow_origins":           true,
+	"audit.retention_days":              true,
+}
+
+// Store holds the current configuration and swaps it when the configuration is reloaded
+type Store struct {
+	current     atomic.Value
+	load        func() (*Configuration, error)
+	mu          sync.Mutex
+	subscribers []func(*Configuration)
+}
+
+// NewStore returns a store holding cfg, load is called to read the configuration again on reload
+func NewStore(cfg *Configuration, load func() (*Configuration, error)) *Store {
+	s := &Store{load: load}
+	s.current.Store(cfg)
+	return s
+}
+
+// Get returns the current configuration, it must not be modified
+func (s *Store) Get() *Configuration {
+	return s.current.Load().(*Configuration)
+}
+
+// Subscribe registers fn to be called with the new configuration after every reload
+func (s *Store) Subscribe(fn func(*Configuration)) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	s.subscribers = append(s.subscribers, fn)
+}
+
+// Reload reads and validates the configuration and swaps in the changed hot keys. The changed keys needing a
+// restart are returned, they keep their current value. The current configuration is kept on error.
+func (s *Store) Reload() (restart []string, err error) {
+	next, err := s.load()
+	if err != nil {
+		return nil, err
+	}
+
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	cfg, restart := merge(s.Get(), next)
+	s.current.Store(cfg)
+	for _, fn := range s.subscribers {
+		fn(cfg)
+	}
+	return restart, nil
+}
+
+// Watch reloads the configuration on SIGHUP and whenever one of files is modified, files are polled on
+// interval. It returns once ctx is done.
+func (s *Store) Watch(ctx context.Context, interval time.Duration, files ...string) {
+	hup := make(chan os.Signal, 1)
+	signal.Notify(hup, syscall.SIGHUP)
+	defer signal.Stop(hup)
+
+	ticker := time.NewTicker(interval)
+	defer ticker.Stop()
+	modified := modTimes(files)
+	for {
+		select {
+		case <-ctx.Done():
+			return
+		case <-hup:
+			s.reload(ctx, "SIGHUP")
+		case <-ticker.C:
+			latest := modTimes(files)
+			if !reflect.DeepEqual(latest, modified) {
+				modified = latest
+				s.reload(ctx, "file change")
+			}
+		}
+	}
+}
+
+func (s *Store) reload(ctx context.Context, trigger string) {
+	restart, err := s.Reload()
+	if err != nil {
+		zaplog.Error(ctx, "configuration not reloaded", zap.String("trigger", trigger), zap.Error(err))
+		return
+	}
+	if len(restart) != 0 {
+		zaplog.Warn(ctx, "configuration changes ignored until restart",
+			zap.String("trigger", trigger), zap.Strings("keys", restart))
+	}
+	zaplog.Info(ctx, "configuration reloaded", zap.String("trigger", trigger))
+}
+
+// modTimes returns the modification time of every file, files that cannot be read are left out
+func modTimes(files []string) map[string]time.Time {
+	times := map[string]time.Time{}
+	for _, file := range files {
+		if info, err := os.Stat(file); err == nil {
+			times[file] = info.ModTime()
+		}
+	}
+	return times
+}
+
+// merge returns a copy of current with the hot keys of next, along with the other keys that differ
+func merge(current, next *Configuration) (*Configuration, []string) {
+	merged := &Configuration{}
+	var restart []string
+	mergedValue := reflect.ValueOf(merged).Elem()
+	currentValue := reflect.ValueOf(current).Elem()
+	nextValue := reflect.ValueOf(next).Elem()
+	for i := 0; i < mergedValue.NumField(); i++ {
+		section := strings.SplitN(mergedValue.Type().Field(i).Tag.Get("json"), ",", 2)[0]
+		cur, nxt := currentValue.Field(i), nextValue.Field(i)
+		if cur.IsNil() || nxt.IsNil() {
+			mergedValue.Field(i).Set(cur)
+			if cur.IsNil() != nxt.IsNil() {
+				restart = append(restart, section)
+			}
+			continue
+		}
+		// sections are copied so that the previous configuration is left untouched
+		copied := reflect.New(cur.Elem().Type())
+		copied.Elem().Set(cur.Elem())
+		for j := 0; j < copied.Elem().NumField(); j++ {
+			key := fmt.Sprintf("%s.%s", section,
+				strings.SplitN(copied.Elem().Type().Field(j).Tag.Get("json"), ",", 2)[0])
+			nextField := nxt.Elem().Field(j)
+			if reflect.DeepEqual(copied.Elem().Field(j).Interface(), nextField.Interface()) {
+				continue
+			}
+			if hotKeys[key] {
+				copied.Elem().Field(j).Set(nextField)
+			} else {
+				restart = append(restart, key)
+			}
+		}
+		mergedValue.Field(i).Set(copied)
+	}
+	return merged, restart
+}
@@ -0,0 +1,120 @@
+package config_test
+
+import (
+	"context"
+	"fmt"
+	"os"
+	"path/filepath"
+	"testing"
+	"time"
+
+	"go-template/internal/config"
+	"go-template/testutls"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestStoreReload(t *testing.T) {
+	cases := []struct {
+		name        string
+		load        func() (*config.Configuration, error)
+		want        func(cfg *config.Configuration)
+		wantRestart []string
+		wantErr     bool
+	}{
+		{
+			name: "Success_HotKeys",
+			load: func() (*config.Configuration, error) {
+				cfg := testutls.MockConfig()
+				cfg.App.MinPasswordStr = 3
+				cfg.JWT.DurationMinutes = 15
+				cfg.Throttle.Limit = 50
+				cfg.Log.Level = "debug"
+				cfg.Cors.AllowOrigins = []string{"https://example.com"}
+				return cfg, nil
+			},
+			want: func(cfg *config.Configuration) {
+				cfg.App.MinPasswordStr = 3
+				cfg.JWT.DurationMinutes = 15
+				cfg.Throttle.Limit = 50
+				cfg.Log.Level = "debug"
+				cfg.Cors.AllowOrigins = []string{"https://example.com"}
+			},
+		},
+		{
+			name: "Success_ImmutableKeysIgnored",
+			load: func() (*config.Configuration, error) {
+				cfg := testutls.MockConfig()
+				cfg.Server.Port = ":9001"
+				cfg.DB.Timeout = 10
+				cfg.Throttle.WindowSeconds = 60
+				return cfg, nil
+			},
+			want: func(cfg *config.Configuration) {
+				cfg.Throttle.WindowSeconds = 60
+			},
+			wantRestart: []string{"server.port", "database.timeout_seconds"},
+		},
+		{
+			name: "Failure_Invalid",
+			load: func() (*config.Configuration, error) {
+				return nil, fmt.Errorf("invalid configuration: server.port (SERVER_PORT) is required")
+			},
+			want:    func(cfg *config.Configuration) {},
+			wantErr: true,
+		},
+	}
+	for _, tt := range cases {
+		t.Run(tt.name, func(t *testing.T) {
+			current := testutls.MockConfig()
+			store := config.NewStore(current, tt.load)
+			var notified *config.Configuration
+			store.Subscribe(func(cfg *config.Configuration) {
+				notified = cfg
+			})
+
+			restart, err := store.Reload()
+			assert.Equal(t, tt.wantErr, err != nil)
+			assert.ElementsMatch(t, tt.wantRestart, restart)
+
+			want := testutls.MockConfig()
+			tt.want(want)
+			assert.Equal(t, want, store.Get())
+			if !tt.wantErr {
+				assert.Equal(t, want, notified)
+			}
+			// the previous configuration is left untouched
+			assert.Equal(t, testutls.MockConfig(), current)
+		})
+	}
+}
+
+func TestStoreWatch(t *testing.T) {
+	file := filepath.Join(t.TempDir(), "config.yaml")
+	assert.Nil(t, os.WriteFile(file, []byte("throttle:\n  limit: 5\n"), 0o600))
+
+	reloaded := make(chan *config.Configuration, 1)
+	store := config.NewStore(testutls.MockConfig(), func() (*config.Configuration, error) {
+		cfg := testutls.MockConfig()
+		cfg.Throttle.Limit = 10
+		return cfg, nil
+	})
+	store.Subscribe(func(cfg *config.Configuration) {
+		reloaded <- cfg
+	})
+	ctx, cancel := context.WithCancel(context.Background())
+	defer cancel()
+	go store.Watch(ctx, 10*time.Millisecond, file)
+
+	// make sure the modification time changes on file systems with a coarse resolution
+	time.Sleep(50 * time.Millisecond)
+	assert.Nil(t, os.Chtimes(file, time.Now().Add(time.Minute), time.Now().Add(time.Minute)))
+
+	select {
+	case cfg := <-reloaded:
+		assert.Equal(t, 10, cfg.Throttle.Limit)
+		assert.Equal(t, 10, store.Get().Throttle.Limit)
+	case <-time.After(2 * time.Second):
+		t.Fatal("configuration was not reloaded")
+	}
+}
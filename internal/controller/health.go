@@ -0,0 +1,161 @@
+package controller
+
+import (
+	"context"
+	"database/sql"
+	"fmt"
+	"net/http"
+	"sync"
+	"sync/atomic"
+	"time"
+
+	"go-template/internal/migrations"
+
+	"github.com/labstack/echo/v4"
+	"github.com/volatiletech/sqlboiler/v4/boil"
+)
+
+const (
+	StatusOK          = "ok"
+	StatusUnavailable = "unavailable"
+	StatusDraining    = "draining"
+
+	// DefaultCheckTimeout is used when a readiness is created without a timeout
+	DefaultCheckTimeout = 2 * time.Second
+)
+
+// Check reports an error when a dependency of the service is not ready
+type Check func(ctx context.Context) error
+
+// CheckResult is the outcome of a single readiness check
+type CheckResult struct {
+	Status  string `json:"status"`
+	Latency string `json:"latency"`
+	Error   string `json:"error,omitempty"`
+}
+
+// ReadinessResponse is the body of the readiness endpoint
+type ReadinessResponse struct {
+	Status string                 `json:"status"`
+	Checks map[string]CheckResult `json:"checks"`
+}
+
+// Readiness runs the registered checks to tell whether the service can take traffic.
+// Once drained it reports not ready so that the load balancer stops routing requests before shutdown.
+type Readiness struct {
+	timeout  time.Duration
+	checks   map[string]Check
+	draining int32
+}
+
+// NewReadiness returns a readiness whose checks each get the given timeout
+func NewReadiness(timeout time.Duration) *Readiness {
+	if timeout <= 0 {
+		timeout = DefaultCheckTimeout
+	}
+	return &Readiness{timeout: timeout, checks: map[string]Check{}}
+}
+
+// Add registers a named check
+func (r *Readiness) Add(name string, check Check) *Readiness {
+	r.checks[name] = check
+	return r
+}
+
+// Drain marks the service as not ready, it is called when the server starts shutting down
+func (r *Readiness) Drain() {
+	atomic.StoreInt32(&r.draining, 1)
+}
+
+// Draining tells whether the service is shutting down
+func (r *Readiness) Draining() bool {
+	return atomic.LoadInt32(&r.draining) == 1
+}
+
+// Check runs every check concurrently and returns their breakdown
+func (r *Readiness) Check(ctx context.Context) ReadinessResponse {
+	res := ReadinessResponse{Status: StatusOK, Checks: map[string]CheckResult{}}
+	var mu sync.Mutex
+	var wg sync.WaitGroup
+	for name, check := range r.checks {
+		wg.Add(1)
+		go func(name string, check Check) {
+			defer wg.Done()
+			result := r.run(ctx, check)
+			mu.Lock()
+			defer mu.Unlock()
+			res.Checks[name] = result
+			if result.Status != StatusOK {
+				res.Status = StatusUnavailable
+			}
+		}(name, check)
+	}
+	wg.Wait()
+	if r.Draining() {
+		res.Status = StatusDraining
+	}
+	return res
+}
+
+func (r *Readiness) run(ctx context.Context, check Check) CheckResult {
+	ctx, cancel := context.WithTimeout(ctx, r.timeout)
+	defer cancel()
+	start := time.Now()
+	done := make(chan error, 1)
+	go func() {
+		done <- check(ctx)
+	}()
+
+	var err error
+	select {
+	case err = <-done:
+	case <-ctx.Done():
+		// checks that do not honour the context are abandoned once the timeout is reached
+		err = ctx.Err()
+	}
+	result := CheckResult{Status: StatusOK, Latency: time.Since(start).String()}
+	if err != nil {
+		result.Status = StatusUnavailable
+		result.Error = err.Error()
+	}
+	return result
+}
+
+// Handler serves the readiness of the service, it answers 503 when a check fails or when draining
+func (r *Readiness) Handler(c echo.Context) error {
+	res := r.Check(c.Request().Context())
+	if res.Status != StatusOK {
+		return c.JSON(http.StatusServiceUnavailable, res)
+	}
+	return c.JSON(http.StatusOK, res)
+}
+
+// LivenessHandler answers as long as the process is able to serve requests
+func LivenessHandler(c echo.Context) error {
+	return c.JSON(http.StatusOK, map[string]string{"status": StatusOK})
+}
+
+// PostgresCheck pings the database used by the models
+func PostgresCheck(ctx context.Context) error {
+	db, ok := boil.GetContextDB().(*sql.DB)
+	if !ok {
+		return fmt.Errorf("database is not initialised")
+	}
+	return db.PingContext(ctx)
+}
+
+// MigrationsCheck fails when migrations are waiting to be applied
+func MigrationsCheck(ctx context.Context) error {
+	db, ok := boil.GetContextDB().(*sql.DB)
+	if !ok {
+		return fmt.Errorf("database is not initialised")
+	}
+	pending, err := migrations.Pending(db)
+	if err != nil {
+		return err
+	}
+	if pending > 0 {
+		return fmt.Errorf("%d migrations pending", pending)
+	}
+	return nil
+}
@@ -0,0 +1,131 @@
+package controller_test
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+
+	"go-template/internal/controller"
+
+	"github.com/DATA-DOG/go-sqlmock"
+	"github.com/labstack/echo/v4"
+	"github.com/stretchr/testify/assert"
+	"github.com/volatiletech/sqlboiler/v4/boil"
+)
+
+func serve(handler echo.HandlerFunc) *httptest.ResponseRecorder {
+	e := echo.New()
+	res := httptest.NewRecorder()
+	c := e.NewContext(httptest.NewRequest(http.MethodGet, "/readyz", nil), res)
+	_ = handler(c)
+	return res
+}
+
+func TestReadiness(t *testing.T) {
+	cases := map[string]struct {
+		checks map[string]controller.Check
+		drain  bool
+		code   int
+		status string
+		errors map[string]string
+	}{
+		"Success": {
+			checks: map[string]controller.Check{
+				"postgres": func(ctx context.Context) error { return nil },
+				"redis":    func(ctx context.Context) error { return nil },
+			},
+			code:   http.StatusOK,
+			status: controller.StatusOK,
+		},
+		"Success_NoChecks": {
+			code:   http.StatusOK,
+			status: controller.StatusOK,
+		},
+		"Failure_CheckFailed": {
+			checks: map[string]controller.Check{
+				"postgres": func(ctx context.Context) error { return nil },
+				"redis":    func(ctx context.Context) error { return fmt.Errorf("connection refused") },
+			},
+			code:   http.StatusServiceUnavailable,
+			status: controller.StatusUnavailable,
+			errors: map[string]string{"redis": "connection refused"},
+		},
+		"Failure_Timeout": {
+			checks: map[string]controller.Check{
+				"migrations": func(ctx context.Context) error {
+					time.Sleep(time.Second)
+					return nil
+				},
+			},
+			code:   http.StatusServiceUnavailable,
+			status: controller.StatusUnavailable,
+			errors: map[string]string{"migrations": context.DeadlineExceeded.Error()},
+		},
+		"Failure_Draining": {
+			checks: map[string]controller.Check{
+				"postgres": func(ctx context.Context) error { return nil },
+			},
+			drain:  true,
+			code:   http.StatusServiceUnavailable,
+			status: controller.StatusDraining,
+		},
+	}
+	for name, tt := range cases {
+		t.Run(name, func(t *testing.T) {
+			readiness := controller.NewReadiness(50 * time.Millisecond)
+			for name, check := range tt.checks {
+				readiness.Add(name, check)
+			}
+			if tt.drain {
+				readiness.Drain()
+			}
+			res := serve(readiness.Handler)
+
+			assert.Equal(t, tt.code, res.Code)
+			var body controller.ReadinessResponse
+			assert.Nil(t, json.Unmarshal(res.Body.Bytes(), &body))
+			assert.Equal(t, tt.status, body.Status)
+			assert.Equal(t, len(tt.checks), len(body.Checks))
+			for name, result := range body.Checks {
+				assert.Equal(t, tt.errors[name], result.Error)
+			}
+		})
+	}
+}
+
+func TestLivenessHandler(t *testing.T) {
+	res := serve(controller.LivenessHandler)
+	assert.Equal(t, http.StatusOK, res.Code)
+	assert.JSONEq(t, `{"status":"ok"}`, res.Body.String())
+}
+
+func TestPostgresCheck(t *testing.T) {
+	cases := map[string]struct {
+		err     error
+		wantErr bool
+	}{
+		"Success": {},
+		"Failure_PingFailed": {
+			err:     fmt.Errorf("connection refused"),
+			wantErr: true,
+		},
+	}
+	for name, tt := range cases {
+		t.Run(name, func(t *testing.T) {
+			db, mock, err := sqlmock.New(sqlmock.MonitorPingsOption(true))
+			if err != nil {
+				t.Fatal(err)
+			}
+			defer db.Close()
+			boil.SetDB(db)
+			mock.ExpectPing().WillReturnError(tt.err)
+
+			err = controller.PostgresCheck(context.Background())
+			assert.Equal(t, tt.wantErr, err != nil)
+		})
+	}
+}
@@ -0,0 +1,150 @@
+// Package featureflags evaluates the feature flags of the feature_flags table, which are kept in memory and
+// refreshed on an interval
+package featureflags
+
+import (
+	"context"
+	"fmt"
+	"hash/fnv"
+	"sort"
+	"sync"
+	"time"
+
+	"go-template/daos"
+	"go-template/pkg/utl/zaplog"
+
+	"go.uber.org/zap"
+)
+
+const (
+	// ThrottleBypass disables the rate limit of the throttled mutations
+	ThrottleBypass = "throttle_bypass"
+	// SQLDebug logs every query executed by sqlboiler
+	SQLDebug = "sql_debug"
+)
+
+// Subject is who a flag is evaluated for, the zero value is an anonymous subject
+type Subject struct {
+	UserID int
+	Role   string
+}
+
+// Evaluate returns whether flag is on for subject. A disabled flag is off for everyone. Users listed in the
+// flag always get it, otherwise the subject must have one of the roles of the flag, if any, and fall into the
+// rollout percentage. Users are assigned to a stable bucket per flag, anonymous subjects only get fully rolled
+// out flags.
+func Evaluate(flag daos.FeatureFlag, subject Subject) bool {
+	if !flag.Enabled {
+		return false
+	}
+	for _, id := range flag.UserIDs {
+		if subject.UserID != 0 && id == subject.UserID {
+			return true
+		}
+	}
+	if len(flag.Roles) != 0 && !contains(flag.Roles, subject.Role) {
+		return false
+	}
+	if flag.RolloutPercentage >= 100 {
+		return true
+	}
+	if subject.UserID == 0 {
+		return false
+	}
+	return bucket(flag.Name, subject.UserID) < flag.RolloutPercentage
+}
+
+// bucket returns a number from 0 to 99 derived from the flag name and the user
+func bucket(name string, userID int) int {
+	h := fnv.New32a()
+	fmt.Fprintf(h, "%s:%d", name, userID)
+	return int(h.Sum32() % 100)
+}
+
+func contains(s []string, e string) bool {
+	for _, a := range s {
+		if a == e {
+			return true
+		}
+	}
+	return false
+}
+
+// Store holds the feature flags in memory, flags missing from the store are off
+type Store struct {
+	mu        sync.RWMutex
+	flags     map[string]daos.FeatureFlag
+	load      func(ctx context.Context) ([]daos.FeatureFlag, error)
+	onRefresh []func(s *Store)
+}
+
+// NewStore returns an empty store loading the flags with load, daos.FindAllFeatureFlags when nil
+func NewStore(load func(ctx context.Context) ([]daos.FeatureFlag, error)) *Store {
+	if load == nil {
+		load = daos.FindAllFeatureFlags
+	}
+	return &Store{flags: map[string]daos.FeatureFlag{}, load: load}
+}
+
+// OnRefresh registers fn to be called after every successful refresh
+func (s *Store) OnRefresh(fn func(s *Store)) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	s.onRefresh = append(s.onRefresh, fn)
+}
+
+// Refresh loads the flags, the flags in memory are kept on error
+func (s *Store) Refresh(ctx context.Context) error {
+	flags, err := s.load(ctx)
+	if err != nil {
+		return err
+	}
+	byName := make(map[string]daos.FeatureFlag, len(flags))
+	for _, flag := range flags {
+		byName[flag.Name] = flag
+	}
+	s.mu.Lock()
+	s.flags = byName
+	callbacks := s.onRefresh
+	s.mu.Unlock()
+	for _, fn := range callbacks {
+		fn(s)
+	}
+	return nil
+}
+
+// Run refreshes the flags on interval until ctx is done
+func (s *Store) Run(ctx context.Context, interval time.Duration) {
+	ticker := time.NewTicker(interval)
+	defer ticker.Stop()
+	for {
+		select {
+		case <-ctx.Done():
+			return
+		case <-ticker.C:
+			if err := s.Refresh(ctx); err != nil {
+				zaplog.Error(ctx, "feature flags not refreshed", zap.Error(err))
+			}
+		}
+	}
+}
+
+// Enabled returns whether the flag name is on for subject
+func (s *Store) Enabled(name string, subject Subject) bool {
+	s.mu.RLock()
+	defer s.mu.RUnlock()
+	flag, ok := s.flags[name]
+	return ok && Evaluate(flag, subject)
+}
+
+// All returns every flag ordered by name
+func (s *Store) All() []daos.FeatureFlag {
+	s.mu.RLock()
+	defer s.mu.RUnlock()
+	flags := make([]daos.FeatureFlag, 0, len(s.flags))
+	for _, flag := range s.flags {
+		flags = append(flags, flag)
+	}
+	sort.Slice(flags, func(i, j int) bool { return flags[i].Name < flags[j].Name })
+	return flags
+}
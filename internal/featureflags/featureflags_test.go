@@ -0,0 +1,112 @@
+package featureflags_test
+
+import (
+	"context"
+	"fmt"
+	"testing"
+
+	"go-template/daos"
+	"go-template/internal/featureflags"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestEvaluate(t *testing.T) {
+	cases := []struct {
+		name    string
+		flag    daos.FeatureFlag
+		subject featureflags.Subject
+		want    bool
+	}{
+		{
+			name:    "Disabled",
+			flag:    daos.FeatureFlag{Name: "f", RolloutPercentage: 100, UserIDs: []int{1}},
+			subject: featureflags.Subject{UserID: 1},
+			want:    false,
+		},
+		{
+			name: "Enabled",
+			flag: daos.FeatureFlag{Name: "f", Enabled: true, RolloutPercentage: 100},
+			want: true,
+		},
+		{
+			name:    "TargetedUser",
+			flag:    daos.FeatureFlag{Name: "f", Enabled: true, Roles: []string{"SUPER_ADMIN"}, UserIDs: []int{7}},
+			subject: featureflags.Subject{UserID: 7, Role: "USER"},
+			want:    true,
+		},
+		{
+			name:    "TargetedRole",
+			flag:    daos.FeatureFlag{Name: "f", Enabled: true, RolloutPercentage: 100, Roles: []string{"SUPER_ADMIN"}},
+			subject: featureflags.Subject{UserID: 1, Role: "SUPER_ADMIN"},
+			want:    true,
+		},
+		{
+			name:    "OtherRole",
+			flag:    daos.FeatureFlag{Name: "f", Enabled: true, RolloutPercentage: 100, Roles: []string{"SUPER_ADMIN"}},
+			subject: featureflags.Subject{UserID: 1, Role: "USER"},
+			want:    false,
+		},
+		{
+			name:    "NoRollout",
+			flag:    daos.FeatureFlag{Name: "f", Enabled: true, RolloutPercentage: 0},
+			subject: featureflags.Subject{UserID: 1},
+			want:    false,
+		},
+		{
+			name: "PartialRolloutAnonymous",
+			flag: daos.FeatureFlag{Name: "f", Enabled: true, RolloutPercentage: 99},
+			want: false,
+		},
+	}
+	for _, tt := range cases {
+		t.Run(tt.name, func(t *testing.T) {
+			assert.Equal(t, tt.want, featureflags.Evaluate(tt.flag, tt.subject))
+		})
+	}
+}
+
+func TestEvaluate_Rollout(t *testing.T) {
+	flag := daos.FeatureFlag{Name: "f", Enabled: true, RolloutPercentage: 30}
+	enabled := 0
+	for id := 1; id <= 1000; id++ {
+		subject := featureflags.Subject{UserID: id}
+		got := featureflags.Evaluate(flag, subject)
+		// users keep their bucket between evaluations
+		assert.Equal(t, got, featureflags.Evaluate(flag, subject))
+		if got {
+			enabled++
+		}
+	}
+	assert.InDelta(t, 300, enabled, 60)
+}
+
+func TestStore(t *testing.T) {
+	var loadErr error
+	flags := []daos.FeatureFlag{
+		{Name: "b", Enabled: true, RolloutPercentage: 100},
+		{Name: "a", Enabled: false, RolloutPercentage: 100},
+	}
+	store := featureflags.NewStore(func(ctx context.Context) ([]daos.FeatureFlag, error) {
+		return flags, loadErr
+	})
+	refreshed := 0
+	store.OnRefresh(func(s *featureflags.Store) {
+		refreshed++
+	})
+
+	assert.False(t, store.Enabled("b", featureflags.Subject{}))
+	assert.Nil(t, store.Refresh(context.Background()))
+	assert.True(t, store.Enabled("b", featureflags.Subject{}))
+	assert.False(t, store.Enabled("a", featureflags.Subject{}))
+	assert.False(t, store.Enabled("missing", featureflags.Subject{}))
+	assert.Equal(t, []daos.FeatureFlag{flags[1], flags[0]}, store.All())
+	assert.Equal(t, 1, refreshed)
+
+	// the flags in memory are kept when they cannot be loaded
+	loadErr = fmt.Errorf("connection refused")
+	flags = nil
+	assert.NotNil(t, store.Refresh(context.Background()))
+	assert.True(t, store.Enabled("b", featureflags.Subject{}))
+	assert.Equal(t, 1, refreshed)
+}
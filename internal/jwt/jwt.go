@@ -4,6 +4,7 @@ import (
 	"context"
 	"fmt"
 	"strings"
+	"sync/atomic"
 	"time"
 
 	"go-template/models"
@@ -29,10 +30,11 @@ func New(algo, secret string, ttlMinutes, minSecretLength int) (Service, error)
 		return Service{}, fmt.Errorf("invalid jwt signing method: %s", algo)
 	}
 
+	ttl := int64(time.Duration(ttlMinutes) * time.Minute)
 	return Service{
 		key:  []byte(secret),
 		algo: signingMethod,
-		ttl:  time.Duration(ttlMinutes) * time.Minute,
+		ttl:  &ttl,
 	}, nil
 }
 
@@ -41,13 +43,27 @@ type Service struct {
 	// Secret key used for signing.
 	key []byte
 
-	// Duration for which the jwt token is valid.
-	ttl time.Duration
+	// Duration for which the jwt token is valid, shared by the copies of the service and accessed atomically.
+	ttl *int64
 
 	// JWT signing algorithm
 	algo jwt.SigningMethod
 }
 
+// SetTTL changes the duration for which the generated tokens are valid
+func (s Service) SetTTL(ttlMinutes int) {
+	if s.ttl != nil {
+		atomic.StoreInt64(s.ttl, int64(time.Duration(ttlMinutes)*time.Minute))
+	}
+}
+
+func (s Service) getTTL() time.Duration {
+	if s.ttl == nil {
+		return 0
+	}
+	return time.Duration(atomic.LoadInt64(s.ttl))
+}
+
 // ParseToken parses token from Authorization header
 func (s Service) ParseToken(authHeader string) (*jwt.Token, error) {
 	parts := strings.SplitN(authHeader, " ", 2)
@@ -75,7 +91,7 @@ func (s Service) GenerateToken(u *models.User) (string, error) {
 		"id":   u.ID,
 		"u":    u.Username,
 		"e":    u.Email,
-		"exp":  time.Now().Add(s.ttl).Unix(),
+		"exp":  time.Now().Add(s.getTTL()).Unix(),
 		"role": role.Name,
 	}).SignedString(s.key)
 }
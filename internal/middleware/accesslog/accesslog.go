@@ -0,0 +1,54 @@
+package accesslog
+
+import (
+	"net/http"
+	"time"
+
+	"go-template/pkg/utl/zaplog"
+
+	"github.com/labstack/echo/v4"
+	"go.uber.org/zap"
+)
+
+// Middleware writes an access log entry for every request through the structured logger.
+// Server errors are logged at error level, client errors at warn level and everything else at info level.
+func Middleware() echo.MiddlewareFunc {
+	return func(next echo.HandlerFunc) echo.HandlerFunc {
+		return func(c echo.Context) error {
+			start := time.Now()
+			err := next(c)
+			if err != nil {
+				// let the error handler write the response so that the logged status is the one sent
+				c.Error(err)
+			}
+
+			req := c.Request()
+			res := c.Response()
+			fields := []zap.Field{
+				zap.String("method", req.Method),
+				zap.String("uri", req.RequestURI),
+				zap.String("route", c.Path()),
+				zap.Int("status", res.Status),
+				zap.Duration("latency", time.Since(start)),
+				zap.String("bytes_in", req.Header.Get(echo.HeaderContentLength)),
+				zap.Int64("bytes_out", res.Size),
+				zap.String("remote_ip", c.RealIP()),
+				zap.String("user_agent", req.UserAgent()),
+			}
+			if err != nil {
+				fields = append(fields, zap.Error(err))
+			}
+
+			ctx := req.Context()
+			switch {
+			case res.Status >= http.StatusInternalServerError:
+				zaplog.Error(ctx, "request", fields...)
+			case res.Status >= http.StatusBadRequest:
+				zaplog.Warn(ctx, "request", fields...)
+			default:
+				zaplog.Info(ctx, "request", fields...)
+			}
+			return nil
+		}
+	}
+}
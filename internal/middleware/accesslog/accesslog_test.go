@@ -0,0 +1,72 @@
+package accesslog_test
+
+import (
+	"context"
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"go-template/internal/middleware/accesslog"
+	"go-template/pkg/utl/zaplog"
+
+	"github.com/labstack/echo/v4"
+	"github.com/stretchr/testify/assert"
+	"go.uber.org/zap"
+	"go.uber.org/zap/zapcore"
+	"go.uber.org/zap/zaptest/observer"
+)
+
+func TestMiddleware(t *testing.T) {
+	cases := map[string]struct {
+		handler echo.HandlerFunc
+		status  int64
+		level   zapcore.Level
+	}{
+		"Success": {
+			handler: func(c echo.Context) error {
+				return c.String(http.StatusOK, "ok")
+			},
+			status: http.StatusOK,
+			level:  zap.InfoLevel,
+		},
+		"Failure_ClientError": {
+			handler: func(c echo.Context) error {
+				return echo.ErrNotFound
+			},
+			status: http.StatusNotFound,
+			level:  zap.WarnLevel,
+		},
+		"Failure_ServerError": {
+			handler: func(c echo.Context) error {
+				return fmt.Errorf("some error")
+			},
+			status: http.StatusInternalServerError,
+			level:  zap.ErrorLevel,
+		},
+	}
+	for name, tt := range cases {
+		t.Run(name, func(t *testing.T) {
+			core, logs := observer.New(zap.DebugLevel)
+			old := zaplog.Logger
+			zaplog.SetLogger(zap.New(core).Sugar())
+			defer zaplog.SetLogger(old)
+
+			e := echo.New()
+			e.Use(accesslog.Middleware())
+			e.GET("/test", tt.handler)
+			req := httptest.NewRequest(http.MethodGet, "/test", nil)
+			req = req.WithContext(context.WithValue(req.Context(), zaplog.RequestIdCtxKey, "rid"))
+			res := httptest.NewRecorder()
+			e.ServeHTTP(res, req)
+
+			assert.Equal(t, int(tt.status), res.Code)
+			assert.Equal(t, 1, logs.Len())
+			entry := logs.All()[0]
+			assert.Equal(t, tt.level, entry.Level)
+			assert.Equal(t, tt.status, entry.ContextMap()["status"])
+			assert.Equal(t, "/test", entry.ContextMap()["route"])
+			assert.Equal(t, "rid", entry.ContextMap()["request_id"])
+		})
+	}
+}
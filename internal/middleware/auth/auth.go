@@ -2,13 +2,17 @@ package auth
 
 import (
 	"context"
+	"fmt"
 	"reflect"
+	"time"
 
 	"go-template/daos"
 	"go-template/models"
 	resultwrapper "go-template/pkg/utl/resultwrapper"
+	"go-template/pkg/utl/zaplog"
 
 	graphql2 "github.com/99designs/gqlgen/graphql"
+	"github.com/99designs/gqlgen/graphql/handler/transport"
 	jwt "github.com/dgrijalva/jwt-go"
 	"github.com/labstack/echo/v4"
 	"github.com/vektah/gqlparser/v2/ast"
@@ -68,16 +72,54 @@ func GqlMiddleware() echo.MiddlewareFunc {
 	}
 }
 
+// WebsocketInit authenticates a websocket connection with the token of the connection_init payload. The user
+// is set in the context of the connection, which ends when the token expires.
+func WebsocketInit(tokenParser TokenParser) transport.WebsocketInitFunc {
+	return func(ctx context.Context, initPayload transport.InitPayload) (context.Context, error) {
+		tokenStr := initPayload.Authorization()
+		if len(tokenStr) == 0 {
+			return nil, fmt.Errorf("Authorization header is missing")
+		}
+		token, err := tokenParser.ParseToken(tokenStr)
+		if err != nil || !token.Valid {
+			return nil, fmt.Errorf("Invalid authorization token")
+		}
+		claims := token.Claims.(jwt.MapClaims)
+		email, _ := claims["e"].(string)
+		user, err := daos.FindUserByEmail(email, ctx)
+		if err != nil {
+			return nil, fmt.Errorf("No user found for this email address")
+		}
+
+		// the operations of the connection are authorized by GraphQLMiddleware with this token
+		ctx = context.WithValue(ctx, authorization, tokenStr)
+		ctx = context.WithValue(ctx, UserCtxKey, user)
+		ctx = zaplog.WithUserID(ctx, user.ID)
+		if exp, ok := claims["exp"].(float64); ok {
+			var cancel context.CancelFunc
+			ctx, cancel = context.WithDeadline(
+				transport.AppendCloseReason(ctx, "token expired"),
+				time.Unix(int64(exp), 0),
+			)
+			go func() {
+				<-ctx.Done()
+				cancel()
+			}()
+		}
+		return ctx, nil
+	}
+}
+
 // WhiteListedOperations...
 var WhiteListedOperations = map[string][]string{
-	"query":        {"__schema", "introspectionquery", "userNotification"},
-	"mutation":     {"login"},
-	"subscription": {"userNotification"},
+	"query":    {"__schema", "introspectionquery"},
+	"mutation": {"login"},
 }
 
 // AdminOperations...
 var AdminOperations = map[string][]string{
-	"query": {"users"},
+	"query":    {"users", "featureFlagDefinitions", "auditEvents"},
+	"mutation": {"upsertFeatureFlag", "deleteFeatureFlag"},
 }
 
 func contains(s []string, e string) bool {
@@ -143,6 +185,7 @@ func GraphQLMiddleware(
 	}
 
 	ctx = context.WithValue(ctx, UserCtxKey, user)
+	ctx = zaplog.WithUserID(ctx, user.ID)
 	return next(ctx)
 
 }
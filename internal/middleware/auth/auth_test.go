@@ -12,6 +12,7 @@ import (
 	"net/http/httptest"
 	"regexp"
 	"testing"
+	"time"
 
 	graphql "go-template/gqlmodels"
 	"go-template/internal/config"
@@ -299,3 +300,80 @@ func TestFromContext(t *testing.T) {
 	assert.Equal(t, user, u)
 	assert.Equal(t, user.ID, testutls.MockID)
 }
+
+func TestWebsocketInit(t *testing.T) {
+	expiringJwt := func(exp time.Time) *jwt.Token {
+		token := testutls.MockJwt("USER")
+		token.Claims.(jwt.MapClaims)["exp"] = float64(exp.Unix())
+		return token
+	}
+	exp := time.Now().Add(time.Hour).Truncate(time.Second)
+	cases := map[string]struct {
+		payload     transport.InitPayload
+		tokenParser func(token string) (*jwt.Token, error)
+		dbQueries   []testutls.QueryData
+		err         string
+		deadline    time.Time
+	}{
+		SuccessCase: {
+			payload: transport.InitPayload{"authorization": "bearer 123"},
+			tokenParser: func(token string) (*jwt.Token, error) {
+				return expiringJwt(exp), nil
+			},
+			dbQueries: []testutls.QueryData{
+				{
+					Actions:    &[]driver.Value{testutls.MockEmail},
+					Query:      `SELECT "users".* FROM "users" WHERE (email=$1) LIMIT 1`,
+					DbResponse: sqlmock.NewRows([]string{"id", "email"}).AddRow(testutls.MockID, testutls.MockEmail),
+				},
+			},
+			deadline: exp,
+		},
+		"Failure__NoAuthorizationToken": {
+			payload: transport.InitPayload{},
+			err:     "Authorization header is missing",
+		},
+		"Failure__InvalidAuthorizationToken": {
+			payload: transport.InitPayload{"Authorization": "bearer 123"},
+			tokenParser: func(token string) (*jwt.Token, error) {
+				return nil, fmt.Errorf("token is invalid")
+			},
+			err: "Invalid authorization token",
+		},
+		"Failure__NoUserWithThatEmail": {
+			payload: transport.InitPayload{"authorization": "bearer 123"},
+			tokenParser: func(token string) (*jwt.Token, error) {
+				return testutls.MockJwt("USER"), nil
+			},
+			err: "No user found for this email address",
+		},
+	}
+
+	oldDB := boil.GetDB()
+	mock, db, _ := testutls.SetupMockDB(t)
+	defer func() {
+		boil.SetDB(oldDB)
+		db.Close()
+	}()
+	for name, tt := range cases {
+		t.Run(name, func(t *testing.T) {
+			for _, dbQuery := range tt.dbQueries {
+				mock.ExpectQuery(regexp.QuoteMeta(dbQuery.Query)).
+					WithArgs(*dbQuery.Actions...).
+					WillReturnRows(dbQuery.DbResponse)
+			}
+			parseTokenMock = tt.tokenParser
+
+			ctx, err := auth.WebsocketInit(tokenParserMock{})(context.Background(), tt.payload)
+			if tt.err != "" {
+				assert.EqualError(t, err, tt.err)
+				return
+			}
+			assert.Nil(t, err)
+			assert.Equal(t, testutls.MockID, auth.UserIDFromContext(ctx))
+			deadline, ok := ctx.Deadline()
+			assert.True(t, ok)
+			assert.Equal(t, tt.deadline, deadline)
+		})
+	}
+}
@@ -0,0 +1,247 @@
+package bodylog
+
+import (
+	"encoding/json"
+	"fmt"
+	"strings"
+
+	"go-template/pkg/utl/zaplog"
+
+	"github.com/labstack/echo/v4"
+	"github.com/labstack/echo/v4/middleware"
+	"github.com/vektah/gqlparser/v2/ast"
+	"github.com/vektah/gqlparser/v2/formatter"
+	"github.com/vektah/gqlparser/v2/parser"
+	"go.uber.org/zap"
+)
+
+// Redacted replaces the value of every masked field
+const Redacted = "[REDACTED]"
+
+// DefaultRedactFields are masked when no fields are configured
+var DefaultRedactFields = []string{"password", "oldPassword", "newPassword", "token", "refreshToken"}
+
+// Config represents body logging specific config
+type Config struct {
+	Enabled bool
+	// MaxBodyBytes is the largest body that is logged, larger bodies are omitted
+	MaxBodyBytes int
+	// RedactFields are the fields, variables and arguments whose values are masked
+	RedactFields []string
+}
+
+// Middleware logs the request and response bodies with the configured fields masked.
+// Websocket upgrades are skipped and nothing is installed when logging is disabled.
+func Middleware(cfg *Config) echo.MiddlewareFunc {
+	if cfg == nil || !cfg.Enabled {
+		return func(next echo.HandlerFunc) echo.HandlerFunc {
+			return next
+		}
+	}
+	r := NewRedactor(cfg.RedactFields)
+	return middleware.BodyDumpWithConfig(middleware.BodyDumpConfig{
+		Skipper: func(c echo.Context) bool {
+			return c.IsWebSocket()
+		},
+		Handler: func(c echo.Context, reqBody, resBody []byte) {
+			ctx := c.Request().Context()
+			zaplog.Info(ctx, "request body", zap.String("body", r.Format(reqBody, cfg.MaxBodyBytes)))
+			zaplog.Info(ctx, "response body", zap.String("body", r.Format(resBody, cfg.MaxBodyBytes)))
+		},
+	})
+}
+
+// Redactor masks configured fields in JSON and GraphQL payloads
+type Redactor struct {
+	fields map[string]bool
+}
+
+// NewRedactor returns a redactor masking the given fields, or DefaultRedactFields when none are given
+func NewRedactor(fields []string) *Redactor {
+	if len(fields) == 0 {
+		fields = DefaultRedactFields
+	}
+	r := &Redactor{fields: map[string]bool{}}
+	for _, f := range fields {
+		f = strings.TrimSpace(f)
+		if f == "" {
+			continue
+		}
+		r.fields[strings.ToLower(f)] = true
+	}
+	return r
+}
+
+// Format returns the redacted body ready to be logged. Bodies larger than maxBytes and bodies that are not JSON
+// are replaced by a placeholder so that nothing is written unredacted.
+func (r *Redactor) Format(body []byte, maxBytes int) string {
+	if len(body) == 0 {
+		return ""
+	}
+	if maxBytes > 0 && len(body) > maxBytes {
+		return fmt.Sprintf("[body of %d bytes omitted]", len(body))
+	}
+	redacted, err := r.Redact(body)
+	if err != nil {
+		return fmt.Sprintf("[non-JSON body of %d bytes omitted]", len(body))
+	}
+	return string(redacted)
+}
+
+// Redact masks the configured fields in a JSON body. The graphql document found under "query" has the values of
+// its configured arguments masked as well, along with the variables passed to them.
+func (r *Redactor) Redact(body []byte) ([]byte, error) {
+	var payload interface{}
+	if err := json.Unmarshal(body, &payload); err != nil {
+		return nil, err
+	}
+	return json.Marshal(r.walk(payload))
+}
+
+func (r *Redactor) walk(v interface{}) interface{} {
+	switch val := v.(type) {
+	case map[string]interface{}:
+		r.redactOperation(val)
+		for k, item := range val {
+			switch {
+			case r.fields[strings.ToLower(k)]:
+				val[k] = Redacted
+			case k == "query":
+			default:
+				val[k] = r.walk(item)
+			}
+		}
+		return val
+	case []interface{}:
+		for i, item := range val {
+			val[i] = r.walk(item)
+		}
+		return val
+	default:
+		return val
+	}
+}
+
+// redactOperation masks the query of a graphql operation and the variables passed to its configured arguments.
+// Every variable is masked when the query cannot be parsed, since it is then unknown what they are passed to.
+func (r *Redactor) redactOperation(operation map[string]interface{}) {
+	query, ok := operation["query"].(string)
+	if !ok {
+		return
+	}
+	redacted, bound, err := r.redactQuery(query)
+	if err != nil {
+		operation["query"] = unparsedQuery
+		if _, ok := operation["variables"]; ok {
+			operation["variables"] = Redacted
+		}
+		return
+	}
+	operation["query"] = redacted
+	if vars, ok := operation["variables"].(map[string]interface{}); ok {
+		for name := range bound {
+			if _, ok := vars[name]; ok {
+				vars[name] = Redacted
+			}
+		}
+	}
+}
+
+// unparsedQuery replaces the graphql documents that cannot be parsed, their arguments cannot be told apart
+const unparsedQuery = "[unparsed query omitted]"
+
+// RedactQuery masks the values passed to configured arguments of a graphql document, and to the configured fields
+// of its input objects. A document that cannot be parsed is replaced by a placeholder.
+func (r *Redactor) RedactQuery(query string) string {
+	redacted, _, err := r.redactQuery(query)
+	if err != nil {
+		return unparsedQuery
+	}
+	return redacted
+}
+
+// redactQuery returns the redacted document printed back, and the variables passed to configured arguments
+func (r *Redactor) redactQuery(query string) (string, map[string]bool, error) {
+	doc, err := parser.ParseQuery(&ast.Source{Input: query})
+	if err != nil {
+		return "", nil, err
+	}
+	bound := map[string]bool{}
+	for _, operation := range doc.Operations {
+		r.redactDirectives(operation.Directives, bound)
+		r.redactSelections(operation.SelectionSet, bound)
+	}
+	for _, fragment := range doc.Fragments {
+		r.redactDirectives(fragment.Directives, bound)
+		r.redactSelections(fragment.SelectionSet, bound)
+	}
+	// the default values of the variables passed to configured arguments are masked too
+	for _, operation := range doc.Operations {
+		for _, variable := range operation.VariableDefinitions {
+			if bound[variable.Variable] && variable.DefaultValue != nil {
+				mask(variable.DefaultValue, bound)
+			}
+		}
+	}
+
+	var b strings.Builder
+	formatter.NewFormatter(&b).FormatQueryDocument(doc)
+	return b.String(), bound, nil
+}
+
+func (r *Redactor) redactSelections(selections ast.SelectionSet, bound map[string]bool) {
+	for _, selection := range selections {
+		switch s := selection.(type) {
+		case *ast.Field:
+			for _, arg := range s.Arguments {
+				r.redactValue(arg.Name, arg.Value, bound)
+			}
+			r.redactDirectives(s.Directives, bound)
+			r.redactSelections(s.SelectionSet, bound)
+		case *ast.InlineFragment:
+			r.redactDirectives(s.Directives, bound)
+			r.redactSelections(s.SelectionSet, bound)
+		case *ast.FragmentSpread:
+			r.redactDirectives(s.Directives, bound)
+		}
+	}
+}
+
+func (r *Redactor) redactDirectives(directives ast.DirectiveList, bound map[string]bool) {
+	for _, directive := range directives {
+		for _, arg := range directive.Arguments {
+			r.redactValue(arg.Name, arg.Value, bound)
+		}
+	}
+}
+
+// redactValue masks the value of a configured argument or input object field, and looks for them in the fields
+// and items of the other values
+func (r *Redactor) redactValue(name string, value *ast.Value, bound map[string]bool) {
+	if value == nil {
+		return
+	}
+	if r.fields[strings.ToLower(name)] {
+		mask(value, bound)
+		return
+	}
+	for _, child := range value.Children {
+		r.redactValue(child.Name, child.Value, bound)
+	}
+}
+
+// mask replaces the literals of value by Redacted. The variables are left in the document and added to bound, it
+// is their values that are masked.
+func mask(value *ast.Value, bound map[string]bool) {
+	switch value.Kind {
+	case ast.Variable:
+		bound[value.Raw] = true
+	case ast.ListValue, ast.ObjectValue:
+		for _, child := range value.Children {
+			mask(child.Value, bound)
+		}
+	default:
+		value.Kind = ast.StringValue
+		value.Raw = Redacted
+	}
+}
@@ -0,0 +1,131 @@
+package bodylog_test
+
+import (
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+
+	"go-template/internal/middleware/bodylog"
+	"go-template/pkg/utl/zaplog"
+
+	"github.com/labstack/echo/v4"
+	"github.com/stretchr/testify/assert"
+	"go.uber.org/zap"
+	"go.uber.org/zap/zaptest/observer"
+)
+
+func TestRedactor_Format(t *testing.T) {
+	cases := map[string]struct {
+		body     string
+		maxBytes int
+		want     string
+	}{
+		"Success_Empty": {
+			body: "",
+			want: "",
+		},
+		"Success_Variables": {
+			body: `{"query":"mutation login($u: String!, $p: String!) { login(username: $u, password: $p) { token } }",` +
+				`"variables":{"u":"admin","p":"adminuser","password":"adminuser"}}`,
+			want: `{"query":"mutation login ($u: String!, $p: String!) {\n\tlogin(username: $u, password: $p) ` +
+				`{\n\t\ttoken\n\t}\n}\n",` +
+				`"variables":{"p":"[REDACTED]","password":"[REDACTED]","u":"admin"}}`,
+		},
+		"Success_InlineArguments": {
+			body: `{"query":"mutation { changePassword(oldPassword: \"old\\\"pass\", newPassword:\"\"\"new\"\"\") { ok } }"}`,
+			want: `{"query":"mutation {\n\tchangePassword(oldPassword: \"[REDACTED]\", newPassword: \"[REDACTED]\") ` +
+				`{\n\t\tok\n\t}\n}\n"}`,
+		},
+		"Success_InputObject": {
+			body: `{"query":"mutation($t: String = \"default\") { created: createUser(input: {password: \"secret\", ` +
+				`email: \"a@b.c\", token: $t}) { id } }","variables":{"t":"refresh"}}`,
+			want: `{"query":"mutation ($t: String = \"[REDACTED]\") {\n\tcreated: createUser(input: ` +
+				`{password:\"[REDACTED]\",email:\"a@b.c\",token:$t}) {\n\t\tid\n\t}\n}\n","variables":{"t":"[REDACTED]"}}`,
+		},
+		"Success_Fragment": {
+			body: `{"query":"mutation { ...Login } fragment Login on Mutation { ` +
+				`login(username: \"u\", password: \"p\") { token } }"}`,
+			want: `{"query":"mutation {\n\t... Login\n}\nfragment Login on Mutation {\n\tlogin(username: \"u\", ` +
+				`password: \"[REDACTED]\") {\n\t\ttoken\n\t}\n}\n"}`,
+		},
+		"Failure_UnparsedQuery": {
+			body: `{"query":"mutation { login(password: \"p\"","variables":{"p":"secret"}}`,
+			want: `{"query":"[unparsed query omitted]","variables":"[REDACTED]"}`,
+		},
+		"Success_Response": {
+			body: `{"data":{"login":{"token":"jwt","refreshToken":"refresh"}}}`,
+			want: `{"data":{"login":{"refreshToken":"[REDACTED]","token":"[REDACTED]"}}}`,
+		},
+		"Success_Batch": {
+			body: `[{"variables":{"input":{"password":"secret","email":"a@b.c"}}}]`,
+			want: `[{"variables":{"input":{"email":"a@b.c","password":"[REDACTED]"}}}]`,
+		},
+		"Failure_TooLarge": {
+			body:     `{"query":"{ me { id } }"}`,
+			maxBytes: 10,
+			want:     "[body of 25 bytes omitted]",
+		},
+		"Failure_NotJSON": {
+			body: "username=admin&password=secret",
+			want: "[non-JSON body of 30 bytes omitted]",
+		},
+	}
+	r := bodylog.NewRedactor(nil)
+	for name, tt := range cases {
+		t.Run(name, func(t *testing.T) {
+			assert.Equal(t, tt.want, r.Format([]byte(tt.body), tt.maxBytes))
+		})
+	}
+}
+
+func TestMiddleware(t *testing.T) {
+	cases := map[string]struct {
+		cfg       *bodylog.Config
+		websocket bool
+		logs      int
+	}{
+		"Success": {
+			cfg:  &bodylog.Config{Enabled: true, MaxBodyBytes: 1024},
+			logs: 2,
+		},
+		"Success_Disabled": {
+			cfg:  &bodylog.Config{Enabled: false},
+			logs: 0,
+		},
+		"Success_NoConfig": {
+			logs: 0,
+		},
+		"Success_WebsocketSkipped": {
+			cfg:       &bodylog.Config{Enabled: true},
+			websocket: true,
+			logs:      0,
+		},
+	}
+	for name, tt := range cases {
+		t.Run(name, func(t *testing.T) {
+			core, logs := observer.New(zap.DebugLevel)
+			old := zaplog.Logger
+			zaplog.SetLogger(zap.New(core).Sugar())
+			defer zaplog.SetLogger(old)
+
+			e := echo.New()
+			e.Use(bodylog.Middleware(tt.cfg))
+			e.POST("/graphql", func(c echo.Context) error {
+				return c.JSONBlob(http.StatusOK, []byte(`{"data":{"login":{"token":"jwt"}}}`))
+			})
+			req := httptest.NewRequest(http.MethodPost, "/graphql",
+				strings.NewReader(`{"variables":{"password":"secret"}}`))
+			if tt.websocket {
+				req.Header.Set(echo.HeaderUpgrade, "websocket")
+			}
+			e.ServeHTTP(httptest.NewRecorder(), req)
+
+			assert.Equal(t, tt.logs, logs.Len())
+			for _, entry := range logs.All() {
+				assert.NotContains(t, entry.ContextMap()["body"], "secret")
+				assert.NotContains(t, entry.ContextMap()["body"], "jwt")
+			}
+		})
+	}
+}
@@ -0,0 +1,43 @@
+// Package readreplica routes the reads of the GraphQL operations between the primary and the read replicas
+package readreplica
+
+import (
+	"context"
+
+	"go-template/daos"
+	"go-template/internal/middleware/auth"
+
+	graphql2 "github.com/99designs/gqlgen/graphql"
+	"github.com/vektah/gqlparser/v2/ast"
+)
+
+// GraphQLMiddleware serves the reads of the mutations from the primary and starts the write window of their user,
+// in which the reads of the user's queries are served by the primary as well. The other queries are served by
+// the replicas. It runs after the auth middleware, which puts the user in the context.
+func GraphQLMiddleware(
+	ctx context.Context,
+	replicas *daos.ReplicaSet,
+	next graphql2.OperationHandler) graphql2.ResponseHandler {
+
+	if replicas == nil {
+		return next(ctx)
+	}
+	userID := auth.UserIDFromContext(ctx)
+	operation := graphql2.GetOperationContext(ctx).Operation
+	if operation == nil || operation.Operation != ast.Mutation {
+		if userID != 0 && replicas.WroteRecently(ctx, userID) {
+			ctx = daos.WithPrimary(ctx)
+		}
+		return next(ctx)
+	}
+
+	responses := next(daos.WithPrimary(ctx))
+	return func(ctx context.Context) *graphql2.Response {
+		response := responses(ctx)
+		// the window starts once the writes are done
+		if userID != 0 {
+			replicas.MarkWrite(ctx, userID)
+		}
+		return response
+	}
+}
@@ -0,0 +1,85 @@
+package readreplica_test
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"go-template/daos"
+	"go-template/internal/middleware/auth"
+	"go-template/internal/middleware/readreplica"
+	"go-template/models"
+	"go-template/testutls"
+
+	graphql2 "github.com/99designs/gqlgen/graphql"
+	"github.com/stretchr/testify/assert"
+	"github.com/vektah/gqlparser/v2/ast"
+)
+
+func TestGraphQLMiddleware(t *testing.T) {
+	cases := map[string]struct {
+		operation   ast.Operation
+		userID      int
+		wroteBefore bool
+		wantPrimary bool
+		wantWrote   bool
+	}{
+		"Query": {
+			operation: ast.Query,
+			userID:    1,
+		},
+		"QueryInWriteWindow": {
+			operation:   ast.Query,
+			userID:      1,
+			wroteBefore: true,
+			wantPrimary: true,
+			wantWrote:   true,
+		},
+		"Mutation": {
+			operation:   ast.Mutation,
+			userID:      1,
+			wantPrimary: true,
+			wantWrote:   true,
+		},
+		"AnonymousMutation": {
+			operation:   ast.Mutation,
+			wantPrimary: true,
+		},
+	}
+	for name, tt := range cases {
+		t.Run(name, func(t *testing.T) {
+			replicas := daos.NewReplicaSet(nil, time.Minute, daos.NewMemoryWriteWindows(testutls.FakeClock{Time: time.Now()}))
+			if tt.wroteBefore {
+				replicas.MarkWrite(context.Background(), tt.userID)
+			}
+			ctx := graphql2.WithOperationContext(context.Background(), &graphql2.OperationContext{
+				Operation: &ast.OperationDefinition{Operation: tt.operation},
+			})
+			if tt.userID != 0 {
+				ctx = context.WithValue(ctx, auth.UserCtxKey, &models.User{ID: tt.userID})
+			}
+			responses := readreplica.GraphQLMiddleware(ctx, replicas, func(ctx context.Context) graphql2.ResponseHandler {
+				assert.Equal(t, tt.wantPrimary, daos.UsesPrimary(ctx))
+				return func(context.Context) *graphql2.Response {
+					return &graphql2.Response{}
+				}
+			})
+			assert.NotNil(t, responses(ctx))
+			assert.Equal(t, tt.wantWrote, tt.userID != 0 && replicas.WroteRecently(context.Background(), tt.userID))
+		})
+	}
+}
+
+func TestGraphQLMiddlewareWithoutReplicas(t *testing.T) {
+	ctx := graphql2.WithOperationContext(context.Background(), &graphql2.OperationContext{
+		Operation: &ast.OperationDefinition{Operation: ast.Mutation},
+	})
+	responses := readreplica.GraphQLMiddleware(ctx, nil, func(ctx context.Context) graphql2.ResponseHandler {
+		// every read goes to the primary anyway
+		assert.False(t, daos.UsesPrimary(ctx))
+		return func(context.Context) *graphql2.Response {
+			return &graphql2.Response{}
+		}
+	})
+	assert.NotNil(t, responses(ctx))
+}
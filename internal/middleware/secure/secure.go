@@ -2,46 +2,112 @@ package secure
 
 import (
 	"net/http"
+	"strconv"
 
 	"github.com/labstack/echo/v4"
 	"github.com/labstack/echo/v4/middleware"
 )
 
+// HeadersConfig holds the values of the security headers
+type HeadersConfig struct {
+	// HSTSMaxAgeSeconds is the max-age of Strict-Transport-Security, the header is not set when 0
+	HSTSMaxAgeSeconds     int
+	ContentSecurityPolicy string
+	// RouteContentSecurityPolicies replaces ContentSecurityPolicy on the routes it lists, such as the
+	// playground which loads its scripts from a CDN
+	RouteContentSecurityPolicies map[string]string
+	ReferrerPolicy               string
+	PermissionsPolicy            string
+}
+
 // Headers adds general security headers for basic security measures
-func Headers() echo.MiddlewareFunc {
+func Headers(cfg *HeadersConfig) echo.MiddlewareFunc {
+	if cfg == nil {
+		cfg = &HeadersConfig{}
+	}
+	hsts := ""
+	if cfg.HSTSMaxAgeSeconds > 0 {
+		hsts = "max-age=" + strconv.Itoa(cfg.HSTSMaxAgeSeconds) + "; includeSubDomains"
+	}
 	return func(next echo.HandlerFunc) echo.HandlerFunc {
 		return func(c echo.Context) error {
+			header := c.Response().Header()
 			// Protects from MimeType Sniffing
-			c.Response().Header().Set("X-Content-Type-Options", "nosniff")
+			header.Set("X-Content-Type-Options", "nosniff")
 			// Prevents browser from prefetching DNS
-			c.Response().Header().Set("X-DNS-Prefetch-Control", "off")
+			header.Set("X-DNS-Prefetch-Control", "off")
 			// Denies website content to be served in an iframe
-			c.Response().Header().Set("X-Frame-Options", "DENY")
-			c.Response().Header().Set("Strict-Transport-Security", "max-age=5184000; includeSubDomains")
+			header.Set("X-Frame-Options", "DENY")
+			if hsts != "" {
+				header.Set("Strict-Transport-Security", hsts)
+			}
 			// Prevents Internet Explorer from executing downloads in site's context
-			c.Response().Header().Set("X-Download-Options", "noopen")
-			// Minimal XSS protection
-			c.Response().Header().Set("X-XSS-Protection", "1; mode=block")
+			header.Set("X-Download-Options", "noopen")
+			csp, ok := cfg.RouteContentSecurityPolicies[c.Path()]
+			if !ok {
+				csp = cfg.ContentSecurityPolicy
+			}
+			setIfNotEmpty(header, "Content-Security-Policy", csp)
+			setIfNotEmpty(header, "Referrer-Policy", cfg.ReferrerPolicy)
+			setIfNotEmpty(header, "Permissions-Policy", cfg.PermissionsPolicy)
 			return next(c)
 		}
 	}
 }
 
-// CORS adds Cross-Origin Resource Sharing support
-func CORS() echo.MiddlewareFunc {
-	return middleware.CORSWithConfig(middleware.CORSConfig{
-		AllowOrigins: []string{"*"},
-		MaxAge:       86400,
-		AllowMethods: []string{
+func setIfNotEmpty(header http.Header, key string, value string) {
+	if value != "" {
+		header.Set(key, value)
+	}
+}
+
+// CORSConfig holds the Cross-Origin Resource Sharing policy
+type CORSConfig struct {
+	// AllowOrigins is called on every request so that the origins can change at runtime, every origin is
+	// allowed when nil
+	AllowOrigins     func() []string
+	AllowMethods     []string
+	AllowHeaders     []string
+	AllowCredentials bool
+}
+
+// CORS adds Cross-Origin Resource Sharing support following cfg, every origin, method and header is allowed
+// without credentials when cfg is nil
+func CORS(cfg *CORSConfig) echo.MiddlewareFunc {
+	if cfg == nil {
+		cfg = &CORSConfig{}
+	}
+	allowMethods := cfg.AllowMethods
+	if len(allowMethods) == 0 {
+		allowMethods = []string{
 			http.MethodHead,
 			http.MethodPost,
 			http.MethodGet,
 			http.MethodPatch,
 			http.MethodDelete,
 			http.MethodPut,
+		}
+	}
+	allowHeaders := cfg.AllowHeaders
+	if len(allowHeaders) == 0 {
+		allowHeaders = []string{"*"}
+	}
+	return middleware.CORSWithConfig(middleware.CORSConfig{
+		AllowOriginFunc: func(origin string) (bool, error) {
+			if cfg.AllowOrigins == nil {
+				return true, nil
+			}
+			for _, allowed := range cfg.AllowOrigins() {
+				if allowed == "*" || allowed == origin {
+					return true, nil
+				}
+			}
+			return false, nil
 		},
-		AllowHeaders:     []string{"*"},
+		MaxAge:           86400,
+		AllowMethods:     allowMethods,
+		AllowHeaders:     allowHeaders,
 		ExposeHeaders:    []string{"Content-Length"},
-		AllowCredentials: true,
+		AllowCredentials: cfg.AllowCredentials,
 	})
 }
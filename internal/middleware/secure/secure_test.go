@@ -17,6 +17,7 @@ func echoHandler(mw ...echo.MiddlewareFunc) *echo.Echo {
 		e.Use(v)
 	}
 	e.GET("/hello", hwHandler)
+	e.GET("/playground", hwHandler)
 	return e
 }
 
@@ -25,7 +26,13 @@ func hwHandler(c echo.Context) error {
 }
 
 func TestSecureHeaders(t *testing.T) {
-	ts := httptest.NewServer(echoHandler(secure.Headers()))
+	ts := httptest.NewServer(echoHandler(secure.Headers(&secure.HeadersConfig{
+		HSTSMaxAgeSeconds:            5184000,
+		ContentSecurityPolicy:        "default-src 'none'",
+		RouteContentSecurityPolicies: map[string]string{"/playground": "default-src 'self'"},
+		ReferrerPolicy:               "no-referrer",
+		PermissionsPolicy:            "camera=()",
+	})))
 	defer ts.Close()
 	resp, err := http.Get(ts.URL + "/hello")
 	if err != nil {
@@ -36,11 +43,35 @@ func TestSecureHeaders(t *testing.T) {
 	assert.Equal(t, "DENY", resp.Header.Get("X-Frame-Options"))
 	assert.Equal(t, "max-age=5184000; includeSubDomains", resp.Header.Get("Strict-Transport-Security"))
 	assert.Equal(t, "noopen", resp.Header.Get("X-Download-Options"))
-	assert.Equal(t, "1; mode=block", resp.Header.Get("X-XSS-Protection"))
+	assert.Equal(t, "", resp.Header.Get("X-XSS-Protection"))
+	assert.Equal(t, "default-src 'none'", resp.Header.Get("Content-Security-Policy"))
+	assert.Equal(t, "no-referrer", resp.Header.Get("Referrer-Policy"))
+	assert.Equal(t, "camera=()", resp.Header.Get("Permissions-Policy"))
+
+	resp, err = http.Get(ts.URL + "/playground")
+	if err != nil {
+		t.Fatal("Did not expect http.Get to fail")
+	}
+	assert.Equal(t, "default-src 'self'", resp.Header.Get("Content-Security-Policy"))
+}
+
+func TestSecureHeaders_Defaults(t *testing.T) {
+	ts := httptest.NewServer(echoHandler(secure.Headers(nil)))
+	defer ts.Close()
+	resp, err := http.Get(ts.URL + "/hello")
+	if err != nil {
+		t.Fatal("Did not expect http.Get to fail")
+	}
+	assert.Equal(t, "nosniff", resp.Header.Get("X-Content-Type-Options"))
+	for _, header := range []string{
+		"Strict-Transport-Security", "Content-Security-Policy", "Referrer-Policy", "Permissions-Policy",
+	} {
+		assert.Equal(t, "", resp.Header.Get(header), header)
+	}
 }
 
 func TestCORS(t *testing.T) {
-	ts := httptest.NewServer(echoHandler(secure.CORS()))
+	ts := httptest.NewServer(echoHandler(secure.CORS(nil)))
 	defer ts.Close()
 	var cl http.Client
 	req, _ := http.NewRequest("OPTIONS", ts.URL+"/hello", nil)
@@ -49,6 +80,52 @@ func TestCORS(t *testing.T) {
 	assert.Equal(t, "86400", resp.Header.Get("Access-Control-Max-Age"))
 	assert.Equal(t, "HEAD,POST,GET,PATCH,DELETE,PUT", resp.Header.Get("Access-Control-Allow-Methods"))
 	assert.Equal(t, "*", resp.Header.Get("Access-Control-Allow-Headers"))
-	assert.Equal(t, "true", resp.Header.Get("Access-Control-Allow-Credentials"))
+	assert.Equal(t, "", resp.Header.Get("Access-Control-Allow-Credentials"))
 	assert.Equal(t, "localhost", resp.Header.Get("Access-Control-Allow-Origin"))
 }
+
+func TestCORS_Config(t *testing.T) {
+	ts := httptest.NewServer(echoHandler(secure.CORS(&secure.CORSConfig{
+		AllowOrigins:     func() []string { return []string{"https://a.example.com"} },
+		AllowMethods:     []string{http.MethodGet, http.MethodPost},
+		AllowHeaders:     []string{"Authorization", "Content-Type"},
+		AllowCredentials: true,
+	})))
+	defer ts.Close()
+	req, _ := http.NewRequest("OPTIONS", ts.URL+"/hello", nil)
+	req.Header.Add("Origin", "https://a.example.com")
+	resp, err := http.DefaultClient.Do(req)
+	assert.Nil(t, err)
+	assert.Equal(t, "GET,POST", resp.Header.Get("Access-Control-Allow-Methods"))
+	assert.Equal(t, "Authorization,Content-Type", resp.Header.Get("Access-Control-Allow-Headers"))
+	assert.Equal(t, "true", resp.Header.Get("Access-Control-Allow-Credentials"))
+	assert.Equal(t, "https://a.example.com", resp.Header.Get("Access-Control-Allow-Origin"))
+}
+
+func TestCORS_AllowOrigins(t *testing.T) {
+	origins := []string{"https://a.example.com"}
+	ts := httptest.NewServer(echoHandler(secure.CORS(&secure.CORSConfig{
+		AllowOrigins: func() []string { return origins },
+	})))
+	defer ts.Close()
+	tests := []struct {
+		name    string
+		origins []string
+		origin  string
+		want    string
+	}{
+		{name: "Allowed", origins: []string{"https://a.example.com"}, origin: "https://a.example.com", want: "https://a.example.com"},
+		{name: "NotAllowed", origins: []string{"https://a.example.com"}, origin: "https://b.example.com", want: ""},
+		{name: "Changed", origins: []string{"https://b.example.com"}, origin: "https://b.example.com", want: "https://b.example.com"},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			origins = tt.origins
+			req, _ := http.NewRequest("OPTIONS", ts.URL+"/hello", nil)
+			req.Header.Add("Origin", tt.origin)
+			resp, err := http.DefaultClient.Do(req)
+			assert.Nil(t, err)
+			assert.Equal(t, tt.want, resp.Header.Get("Access-Control-Allow-Origin"))
+		})
+	}
+}
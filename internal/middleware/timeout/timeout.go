@@ -0,0 +1,61 @@
+// Package timeout bounds the time of the GraphQL operations so that slow resolvers, and the queries they run,
+// are cancelled rather than piling up
+package timeout
+
+import (
+	"context"
+	"time"
+
+	graphql2 "github.com/99designs/gqlgen/graphql"
+	"github.com/vektah/gqlparser/v2/ast"
+)
+
+// Config holds the deadlines of the operations
+type Config struct {
+	// Default bounds every query and mutation, operations are not bounded when it is 0
+	Default time.Duration
+	// Fields overrides Default for the operations selecting the given root fields, e.g. createUser
+	Fields map[string]time.Duration
+}
+
+// For returns the timeout of operation, the longest of its root fields
+func (c *Config) For(operation *ast.OperationDefinition) time.Duration {
+	if operation == nil || operation.Operation == ast.Subscription {
+		return 0
+	}
+	timeout := time.Duration(0)
+	for _, selection := range operation.SelectionSet {
+		field, ok := selection.(*ast.Field)
+		if !ok {
+			continue
+		}
+		fieldTimeout, ok := c.Fields[field.Name]
+		if !ok {
+			fieldTimeout = c.Default
+		}
+		if fieldTimeout > timeout {
+			timeout = fieldTimeout
+		}
+	}
+	return timeout
+}
+
+// GraphQLMiddleware runs the operation with the deadline of its timeout, subscriptions are not bounded
+func GraphQLMiddleware(
+	ctx context.Context,
+	cfg *Config,
+	next graphql2.OperationHandler) graphql2.ResponseHandler {
+
+	timeout := cfg.For(graphql2.GetOperationContext(ctx).Operation)
+	if timeout <= 0 {
+		return next(ctx)
+	}
+	ctx, cancel := context.WithTimeout(ctx, timeout)
+	responses := next(ctx)
+	// the response handler is called once with a context derived from ctx for queries and mutations, the
+	// deadline is released once the response is ready
+	return func(ctx context.Context) *graphql2.Response {
+		defer cancel()
+		return responses(ctx)
+	}
+}
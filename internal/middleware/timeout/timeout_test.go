@@ -0,0 +1,94 @@
+package timeout_test
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"go-template/internal/middleware/timeout"
+
+	graphql2 "github.com/99designs/gqlgen/graphql"
+	"github.com/stretchr/testify/assert"
+	"github.com/vektah/gqlparser/v2/ast"
+)
+
+func operation(operation ast.Operation, fields ...string) *ast.OperationDefinition {
+	definition := &ast.OperationDefinition{Operation: operation}
+	for _, field := range fields {
+		definition.SelectionSet = append(definition.SelectionSet, &ast.Field{Name: field})
+	}
+	return definition
+}
+
+func TestConfigFor(t *testing.T) {
+	cfg := &timeout.Config{
+		Default: 10 * time.Second,
+		Fields:  map[string]time.Duration{"createUser": 30 * time.Second, "me": time.Second},
+	}
+	cases := map[string]struct {
+		operation *ast.OperationDefinition
+		want      time.Duration
+	}{
+		"Default": {
+			operation: operation(ast.Query, "users"),
+			want:      10 * time.Second,
+		},
+		"Override": {
+			operation: operation(ast.Mutation, "createUser"),
+			want:      30 * time.Second,
+		},
+		"LongestField": {
+			operation: operation(ast.Query, "me", "users"),
+			want:      10 * time.Second,
+		},
+		"Subscription": {
+			operation: operation(ast.Subscription, "userNotification"),
+			want:      0,
+		},
+	}
+	for name, tt := range cases {
+		t.Run(name, func(t *testing.T) {
+			assert.Equal(t, tt.want, cfg.For(tt.operation))
+		})
+	}
+}
+
+func TestGraphQLMiddleware(t *testing.T) {
+	cfg := &timeout.Config{Default: time.Minute}
+	cases := map[string]struct {
+		operation    *ast.OperationDefinition
+		wantDeadline bool
+	}{
+		"Query": {
+			operation:    operation(ast.Query, "users"),
+			wantDeadline: true,
+		},
+		"Subscription": {
+			operation:    operation(ast.Subscription, "userNotification"),
+			wantDeadline: false,
+		},
+	}
+	for name, tt := range cases {
+		t.Run(name, func(t *testing.T) {
+			ctx := graphql2.WithOperationContext(context.Background(), &graphql2.OperationContext{
+				Operation: tt.operation,
+			})
+			var resolverCtx context.Context
+			responses := timeout.GraphQLMiddleware(ctx, cfg, func(ctx context.Context) graphql2.ResponseHandler {
+				// the executor calls the response handler with a context derived from this one
+				return func(context.Context) *graphql2.Response {
+					resolverCtx = ctx
+					_, ok := ctx.Deadline()
+					assert.Equal(t, tt.wantDeadline, ok)
+					assert.Nil(t, ctx.Err())
+					return &graphql2.Response{}
+				}
+			})
+			assert.NotNil(t, responses(ctx))
+			if tt.wantDeadline {
+				// the deadline is released once the response is ready
+				assert.Error(t, resolverCtx.Err())
+			}
+		})
+	}
+}
@@ -0,0 +1,18 @@
+-- +migrate Up
+CREATE TABLE public.feature_flags (
+				id SERIAL UNIQUE PRIMARY KEY,
+				name TEXT UNIQUE NOT NULL,
+				description TEXT,
+				enabled BOOLEAN NOT NULL DEFAULT false,
+				rollout_percentage INT NOT NULL DEFAULT 100 CHECK (rollout_percentage BETWEEN 0 AND 100),
+				roles TEXT[] NOT NULL DEFAULT '{}',
+				user_ids INT[] NOT NULL DEFAULT '{}',
+				created_at TIMESTAMP WITH TIME ZONE,
+				updated_at TIMESTAMP WITH TIME ZONE
+			);
+INSERT INTO public.feature_flags (name, description, created_at, updated_at) VALUES
+	('throttle_bypass', 'Disables the rate limit of the throttled mutations', now(), now()),
+	('sql_debug', 'Logs every SQL query executed by sqlboiler', now(), now());
+
+-- +migrate Down
+DROP TABLE feature_flags;
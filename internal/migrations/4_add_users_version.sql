@@ -0,0 +1,5 @@
+-- +migrate Up
+ALTER TABLE public.users ADD COLUMN version INT NOT NULL DEFAULT 1;
+
+-- +migrate Down
+ALTER TABLE public.users DROP COLUMN version;
@@ -0,0 +1,18 @@
+-- +migrate Up
+CREATE TABLE public.audit_events (
+				id BIGSERIAL UNIQUE PRIMARY KEY,
+				action TEXT NOT NULL,
+				actor_id INT,
+				target_type TEXT NOT NULL,
+				target_id TEXT,
+				ip TEXT,
+				request_id TEXT,
+				diff JSONB,
+				created_at TIMESTAMP WITH TIME ZONE NOT NULL DEFAULT now()
+			);
+CREATE INDEX audit_events_created_at_idx ON public.audit_events (created_at);
+CREATE INDEX audit_events_actor_id_idx ON public.audit_events (actor_id);
+CREATE INDEX audit_events_target_idx ON public.audit_events (target_type, target_id);
+
+-- +migrate Down
+DROP TABLE audit_events;
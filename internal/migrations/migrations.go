@@ -0,0 +1,60 @@
+// Package migrations holds the sql migrations of the service and the helpers to inspect them
+package migrations
+
+import (
+	"context"
+	"database/sql"
+	"embed"
+	"fmt"
+
+	migrate "github.com/rubenv/sql-migrate"
+)
+
+const (
+	// Dir is the directory the migrations are written to, relative to the working directory
+	Dir = "internal/migrations"
+	// Dialect is the sql-migrate dialect of the database
+	Dialect = "postgres"
+	// lockKey is the key of the advisory lock serializing the replicas migrating on boot
+	lockKey int64 = 7166213469713531211
+)
+
+// files are the migrations compiled into the binary, so that it doesn't need the directory at runtime
+//
+//go:embed *.sql
+var files embed.FS
+
+// Source returns the source of the migrations
+func Source() migrate.MigrationSource {
+	return &migrate.EmbedFileSystemMigrationSource{FileSystem: files, Root: "."}
+}
+
+// Pending returns the number of migrations that have not been applied to the database yet
+func Pending(db *sql.DB) (int, error) {
+	planned, _, err := migrate.PlanMigration(db, Dialect, Source(), migrate.Up, 0)
+	if err != nil {
+		return 0, err
+	}
+	return len(planned), nil
+}
+
+// Up applies the pending migrations while holding a Postgres advisory lock, the replicas starting together
+// wait for the first one to be done and find nothing left to apply
+func Up(ctx context.Context, db *sql.DB) (int, error) {
+	conn, err := db.Conn(ctx)
+	if err != nil {
+		return 0, err
+	}
+	defer conn.Close()
+	if _, err := conn.ExecContext(ctx, "SELECT pg_advisory_lock($1)", lockKey); err != nil {
+		return 0, fmt.Errorf("error acquiring the migrations lock: %w", err)
+	}
+	// the lock belongs to the session, it is released before the connection goes back to the pool
+	defer conn.ExecContext(context.Background(), "SELECT pg_advisory_unlock($1)", lockKey) //nolint:errcheck
+
+	n, err := migrate.Exec(db, Dialect, Source(), migrate.Up)
+	if err != nil {
+		return n, fmt.Errorf("error applying the migrations: %w", err)
+	}
+	return n, nil
+}
@@ -0,0 +1,79 @@
+package migrations_test
+
+import (
+	"context"
+	"errors"
+	"testing"
+	"time"
+
+	"go-template/internal/migrations"
+
+	"github.com/DATA-DOG/go-sqlmock"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestSource(t *testing.T) {
+	found, err := migrations.Source().FindMigrations()
+	assert.Nil(t, err)
+	if assert.NotEmpty(t, found) {
+		assert.Equal(t, "1_create_roles.sql", found[0].Id)
+		assert.NotEmpty(t, found[0].Up)
+	}
+}
+
+func TestUp(t *testing.T) {
+	cases := map[string]struct {
+		init func(mock sqlmock.Sqlmock)
+		want int
+		err  string
+	}{
+		"Failure_Lock": {
+			init: func(mock sqlmock.Sqlmock) {
+				mock.ExpectExec("SELECT pg_advisory_lock").WillReturnError(errors.New("canceling statement"))
+			},
+			err: "error acquiring the migrations lock: canceling statement",
+		},
+		"Success_NothingPending": {
+			init: func(mock sqlmock.Sqlmock) {
+				mock.ExpectExec("SELECT pg_advisory_lock").WillReturnResult(sqlmock.NewResult(0, 0))
+				mock.ExpectExec("create table if not exists \"gorp_migrations\"").
+					WillReturnResult(sqlmock.NewResult(0, 0))
+				rows := sqlmock.NewRows([]string{"id", "applied_at"})
+				found, _ := migrations.Source().FindMigrations()
+				for _, m := range found {
+					rows.AddRow(m.Id, time.Now())
+				}
+				mock.ExpectQuery("SELECT \\* FROM \"gorp_migrations\"").WillReturnRows(rows)
+				mock.ExpectExec("SELECT pg_advisory_unlock").WillReturnResult(sqlmock.NewResult(0, 0))
+			},
+		},
+		"Failure_Exec": {
+			init: func(mock sqlmock.Sqlmock) {
+				mock.ExpectExec("SELECT pg_advisory_lock").WillReturnResult(sqlmock.NewResult(0, 0))
+				mock.ExpectExec("create table if not exists \"gorp_migrations\"").
+					WillReturnError(errors.New("permission denied"))
+				mock.ExpectExec("SELECT pg_advisory_unlock").WillReturnResult(sqlmock.NewResult(0, 0))
+			},
+			err: "error applying the migrations: permission denied",
+		},
+	}
+	for name, tt := range cases {
+		t.Run(name, func(t *testing.T) {
+			db, mock, err := sqlmock.New()
+			if err != nil {
+				t.Fatal(err)
+			}
+			defer db.Close()
+			tt.init(mock)
+
+			n, err := migrations.Up(context.Background(), db)
+			if tt.err != "" {
+				assert.EqualError(t, err, tt.err)
+			} else {
+				assert.Nil(t, err)
+			}
+			assert.Equal(t, tt.want, n)
+			assert.Nil(t, mock.ExpectationsWereMet())
+		})
+	}
+}
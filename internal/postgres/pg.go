@@ -1,36 +1,205 @@
 package postgres
 
 import (
+	"context"
 	"database/sql"
+	"database/sql/driver"
 	"fmt"
+	"go-template/internal/secrets"
 	"go-template/pkg/utl/zaplog"
 	"go-template/testutls"
+	"net/url"
 	"os"
+	"regexp"
+	"strconv"
+	"strings"
+	"time"
+	"unicode"
 
+	"github.com/lib/pq"
 	otelsql "github.com/uptrace/opentelemetry-go-extra/otelsql"
 	semconv "go.opentelemetry.io/otel/semconv/v1.10.0"
-
-	// DB adapter
-	_ "github.com/lib/pq"
 )
 
-// Connect ...
+// redacted replaces the passwords of the logged DSNs
+const redacted = "xxxxx"
+
+// dsnPassword matches the password of a key/value DSN, quoted or not
+var dsnPassword = regexp.MustCompile(`password=('(\\.|[^'\\])*'|\S*)`)
+
+// credentialNames are the secrets making up the DSN of the primary, in the order of the DSN
+var credentialNames = []string{"PSQL_DBNAME", "PSQL_HOST", "PSQL_USER", "PSQL_PASS", "PSQL_PORT", "PSQL_SSLMODE"}
+
+// dsnKeys are the DSN keys of the credentials
+var dsnKeys = map[string]string{
+	"PSQL_DBNAME":  "dbname",
+	"PSQL_HOST":    "host",
+	"PSQL_USER":    "user",
+	"PSQL_PASS":    "password",
+	"PSQL_PORT":    "port",
+	"PSQL_SSLMODE": "sslmode",
+}
+
+// Config tunes the pools and their connections, the zero values keep the defaults of database/sql and postgres
+type Config struct {
+	MaxOpenConns    int
+	MaxIdleConns    int
+	ConnMaxLifetime time.Duration
+	// StatementTimeout is the statement_timeout of the connections, postgres cancels the statements running longer
+	StatementTimeout time.Duration
+	// SlowQueryThreshold logs the queries taking longer, no query is logged when it is 0
+	SlowQueryThreshold time.Duration
+}
+
+// Connect opens the database with the credentials of the provider selected by the SECRETS_* variables. The pool
+// is not tuned and its statements have no timeout, so that migrations and seeders can run for long.
 func Connect() (*sql.DB, error) {
-	dsn := GetDSN()
-	zaplog.Logger.Info("Connecting to DB\n", dsn)
-	if testutls.IsInTests() {
-		return sql.Open("postgres", dsn)
+	p, err := secrets.FromEnv()
+	if err != nil {
+		return nil, err
+	}
+	return Open(p, nil)
+}
+
+// Open returns a pool whose connections read their credentials from the provider when they are created,
+// so that a rotated password is used by new connections without restarting the service
+func Open(p secrets.Provider, cfg *Config) (*sql.DB, error) {
+	if cfg == nil {
+		cfg = &Config{}
+	}
+	c := &connector{secrets: p, cfg: cfg}
+	// fail early when the credentials cannot be read
+	dsn, err := c.dsn(context.Background())
+	if err != nil {
+		return nil, err
 	}
-	return otelsql.Open("postgres", dsn, otelsql.WithAttributes(semconv.DBSystemPostgreSQL))
+	zaplog.Logger.Info("Connecting to DB ", RedactDSN(dsn))
+	return openDB(c, cfg), nil
+}
+
+// OpenReplicas returns a pool per read replica DSN, the DSNs carry their own credentials
+func OpenReplicas(dsns []string, cfg *Config) ([]*sql.DB, error) {
+	if cfg == nil {
+		cfg = &Config{}
+	}
+	pools := make([]*sql.DB, 0, len(dsns))
+	for i, dsn := range dsns {
+		pc, err := replicaConnector(dsn, cfg)
+		if err != nil {
+			for _, pool := range pools {
+				_ = pool.Close()
+			}
+			return nil, fmt.Errorf("invalid DSN of read replica %d: %w", i, err)
+		}
+		zaplog.Logger.Info("Connecting to DB read replica ", RedactDSN(dsn))
+		pools = append(pools, openDB(pc, cfg))
+	}
+	return pools, nil
 }
 
 func GetDSN() string {
-	dsn := fmt.Sprintf("dbname=%s host=%s user=%s password=%s port=%s sslmode=%s",
-		os.Getenv("PSQL_DBNAME"),
-		os.Getenv("PSQL_HOST"),
-		os.Getenv("PSQL_USER"),
-		os.Getenv("PSQL_PASS"),
-		os.Getenv("PSQL_PORT"),
-		os.Getenv("PSQL_SSLMODE"))
-	return dsn
+	params := make([]string, 0, len(credentialNames))
+	for _, name := range credentialNames {
+		params = append(params, dsnParam(dsnKeys[name], os.Getenv(name)))
+	}
+	return strings.Join(params, " ")
+}
+
+// RedactDSN returns the key/value or URL DSN with its password hidden, so that it can be logged
+func RedactDSN(dsn string) string {
+	u, err := url.Parse(dsn)
+	if err != nil || (u.Scheme != "postgres" && u.Scheme != "postgresql") {
+		return dsnPassword.ReplaceAllString(dsn, "password="+redacted)
+	}
+	if query := u.Query(); query.Get("password") != "" {
+		query.Set("password", redacted)
+		u.RawQuery = query.Encode()
+	}
+	return u.Redacted()
+}
+
+// dsnParam returns the key/value DSN parameter, the value is quoted when it is empty or holds spaces, quotes or
+// backslashes
+func dsnParam(key string, value string) string {
+	if value != "" && !strings.ContainsAny(value, `'\`) && strings.IndexFunc(value, unicode.IsSpace) < 0 {
+		return key + "=" + value
+	}
+	return key + "='" + strings.NewReplacer(`\`, `\\`, `'`, `\'`).Replace(value) + "'"
+}
+
+// statementTimeoutParam returns the DSN parameter setting the statement_timeout of the connections, pq sends the
+// parameters it does not know to postgres
+func statementTimeoutParam(cfg *Config) string {
+	if cfg.StatementTimeout <= 0 {
+		return ""
+	}
+	return " " + dsnParam("statement_timeout", strconv.FormatInt(cfg.StatementTimeout.Milliseconds(), 10))
+}
+
+func replicaConnector(dsn string, cfg *Config) (driver.Connector, error) {
+	if strings.HasPrefix(dsn, "postgres://") || strings.HasPrefix(dsn, "postgresql://") {
+		var err error
+		if dsn, err = pq.ParseURL(dsn); err != nil {
+			return nil, err
+		}
+	}
+	pc, err := pq.NewConnector(dsn + statementTimeoutParam(cfg))
+	if err != nil {
+		return nil, err
+	}
+	return SlowQueryConnector(pc, cfg.SlowQueryThreshold), nil
+}
+
+// openDB returns the pool of c tuned by cfg
+func openDB(c driver.Connector, cfg *Config) *sql.DB {
+	var db *sql.DB
+	if testutls.IsInTests() {
+		db = sql.OpenDB(c)
+	} else {
+		db = otelsql.OpenDB(c, otelsql.WithAttributes(semconv.DBSystemPostgreSQL))
+	}
+	if cfg.MaxOpenConns > 0 {
+		db.SetMaxOpenConns(cfg.MaxOpenConns)
+	}
+	if cfg.MaxIdleConns > 0 {
+		db.SetMaxIdleConns(cfg.MaxIdleConns)
+	}
+	if cfg.ConnMaxLifetime > 0 {
+		db.SetConnMaxLifetime(cfg.ConnMaxLifetime)
+	}
+	return db
+}
+
+// connector builds the dsn from the secrets every time the pool opens a connection
+type connector struct {
+	secrets secrets.Provider
+	cfg     *Config
+}
+
+func (c *connector) dsn(ctx context.Context) (string, error) {
+	params := make([]string, 0, len(credentialNames))
+	for _, name := range credentialNames {
+		value, err := c.secrets.Get(ctx, name)
+		if err != nil {
+			return "", fmt.Errorf("error reading database credentials: %w", err)
+		}
+		params = append(params, dsnParam(dsnKeys[name], value))
+	}
+	return strings.Join(params, " ") + statementTimeoutParam(c.cfg), nil
+}
+
+func (c *connector) Connect(ctx context.Context) (driver.Conn, error) {
+	dsn, err := c.dsn(ctx)
+	if err != nil {
+		return nil, err
+	}
+	pc, err := pq.NewConnector(dsn)
+	if err != nil {
+		return nil, err
+	}
+	return SlowQueryConnector(pc, c.cfg.SlowQueryThreshold).Connect(ctx)
+}
+
+func (c *connector) Driver() driver.Driver {
+	return &pq.Driver{}
 }
@@ -0,0 +1,101 @@
+package postgres
+
+import (
+	"context"
+	"database/sql/driver"
+	"strings"
+	"time"
+
+	"go-template/pkg/utl/zaplog"
+
+	"go.uber.org/zap"
+)
+
+// SlowQueryConnector returns a connector whose connections log the queries and statements taking longer than
+// threshold, c is returned as is when threshold is 0. The arguments are not logged since they may hold secrets.
+func SlowQueryConnector(c driver.Connector, threshold time.Duration) driver.Connector {
+	if threshold <= 0 {
+		return c
+	}
+	return &slowQueryConnector{Connector: c, threshold: threshold}
+}
+
+type slowQueryConnector struct {
+	driver.Connector
+	threshold time.Duration
+}
+
+func (c *slowQueryConnector) Connect(ctx context.Context) (driver.Conn, error) {
+	conn, err := c.Connector.Connect(ctx)
+	if err != nil {
+		return nil, err
+	}
+	return &slowQueryConn{Conn: conn, threshold: c.threshold}, nil
+}
+
+// slowQueryConn times the queries run without preparing them, which is how sqlboiler and the DAOs run them
+type slowQueryConn struct {
+	driver.Conn
+	threshold time.Duration
+}
+
+func (c *slowQueryConn) QueryContext(
+	ctx context.Context,
+	query string,
+	args []driver.NamedValue,
+) (driver.Rows, error) {
+	queryer, ok := c.Conn.(driver.QueryerContext)
+	if !ok {
+		return nil, driver.ErrSkip
+	}
+	start := time.Now()
+	rows, err := queryer.QueryContext(ctx, query, args)
+	c.log(ctx, query, time.Since(start))
+	return rows, err
+}
+
+func (c *slowQueryConn) ExecContext(
+	ctx context.Context,
+	query string,
+	args []driver.NamedValue,
+) (driver.Result, error) {
+	execer, ok := c.Conn.(driver.ExecerContext)
+	if !ok {
+		return nil, driver.ErrSkip
+	}
+	start := time.Now()
+	result, err := execer.ExecContext(ctx, query, args)
+	c.log(ctx, query, time.Since(start))
+	return result, err
+}
+
+func (c *slowQueryConn) PrepareContext(ctx context.Context, query string) (driver.Stmt, error) {
+	if preparer, ok := c.Conn.(driver.ConnPrepareContext); ok {
+		return preparer.PrepareContext(ctx, query)
+	}
+	return c.Conn.Prepare(query)
+}
+
+func (c *slowQueryConn) BeginTx(ctx context.Context, opts driver.TxOptions) (driver.Tx, error) {
+	if beginner, ok := c.Conn.(driver.ConnBeginTx); ok {
+		return beginner.BeginTx(ctx, opts)
+	}
+	return c.Conn.Begin() //nolint:staticcheck
+}
+
+func (c *slowQueryConn) Ping(ctx context.Context) error {
+	if pinger, ok := c.Conn.(driver.Pinger); ok {
+		return pinger.Ping(ctx)
+	}
+	return nil
+}
+
+func (c *slowQueryConn) log(ctx context.Context, query string, duration time.Duration) {
+	if duration < c.threshold {
+		return
+	}
+	zaplog.Warn(ctx, "slow query",
+		zap.String("query", strings.Join(strings.Fields(query), " ")),
+		zap.Duration("duration", duration),
+		zap.Duration("threshold", c.threshold))
+}
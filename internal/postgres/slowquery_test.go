@@ -0,0 +1,84 @@
+package postgres_test
+
+import (
+	"context"
+	"database/sql"
+	"database/sql/driver"
+	"testing"
+	"time"
+
+	"go-template/internal/postgres"
+	"go-template/pkg/utl/zaplog"
+
+	"github.com/DATA-DOG/go-sqlmock"
+	"github.com/stretchr/testify/assert"
+	"go.uber.org/zap"
+	"go.uber.org/zap/zaptest/observer"
+)
+
+// dsnConnector opens the connections of a sqlmock DSN
+type dsnConnector struct {
+	dsn string
+	drv driver.Driver
+}
+
+func (c *dsnConnector) Connect(context.Context) (driver.Conn, error) {
+	return c.drv.Open(c.dsn)
+}
+
+func (c *dsnConnector) Driver() driver.Driver {
+	return c.drv
+}
+
+func TestSlowQueryConnector(t *testing.T) {
+	cases := map[string]struct {
+		threshold time.Duration
+		logs      int
+	}{
+		"Slow":     {threshold: time.Nanosecond, logs: 2},
+		"Fast":     {threshold: time.Hour, logs: 0},
+		"Disabled": {threshold: 0, logs: 0},
+	}
+	for name, tt := range cases {
+		t.Run(name, func(t *testing.T) {
+			core, logs := observer.New(zap.DebugLevel)
+			old := zaplog.Logger
+			zaplog.SetLogger(zap.New(core).Sugar())
+			defer zaplog.SetLogger(old)
+
+			mockDB, mock, err := sqlmock.NewWithDSN("slowquery_"+name, sqlmock.QueryMatcherOption(sqlmock.QueryMatcherEqual))
+			if err != nil {
+				t.Fatalf("an error '%s' was not expected when opening a stub database connection", err)
+			}
+			defer mockDB.Close()
+			db := sql.OpenDB(postgres.SlowQueryConnector(
+				&dsnConnector{dsn: "slowquery_" + name, drv: mockDB.Driver()},
+				tt.threshold,
+			))
+			defer db.Close()
+
+			mock.ExpectQuery("SELECT * FROM users WHERE password = $1").
+				WithArgs("secret").
+				WillReturnRows(sqlmock.NewRows([]string{"id"}).AddRow(1))
+			mock.ExpectExec("DELETE FROM users WHERE id = $1").
+				WithArgs(1).
+				WillReturnResult(sqlmock.NewResult(0, 1))
+
+			var id int
+			assert.Nil(t, db.QueryRow("SELECT *\n\tFROM users WHERE password = $1", "secret").Scan(&id))
+			_, err = db.Exec("DELETE FROM users WHERE id = $1", 1)
+			assert.Nil(t, err)
+			assert.Nil(t, mock.ExpectationsWereMet())
+
+			assert.Equal(t, tt.logs, logs.Len())
+			for _, entry := range logs.All() {
+				assert.Equal(t, "slow query", entry.Message)
+				assert.NotContains(t, entry.ContextMap(), "args")
+			}
+			if tt.logs > 0 {
+				// the whitespace of the query is collapsed and its arguments are not logged
+				assert.Equal(t, "SELECT * FROM users WHERE password = $1", logs.All()[0].ContextMap()["query"])
+			}
+		})
+	}
+}
@@ -0,0 +1,78 @@
+package secrets
+
+import (
+	"context"
+	"sync"
+	"time"
+
+	"go-template/pkg/utl/zaplog"
+
+	"go.uber.org/zap"
+)
+
+// Refreshing caches the secrets of a provider and reads them again periodically once Run is called
+type Refreshing struct {
+	provider Provider
+	interval time.Duration
+
+	mu     sync.RWMutex
+	values map[string]string
+}
+
+// NewRefreshing returns a provider caching the secrets of p and refreshing them every interval
+func NewRefreshing(p Provider, interval time.Duration) *Refreshing {
+	return &Refreshing{provider: p, interval: interval, values: map[string]string{}}
+}
+
+// Get returns the cached secret, it is read from the provider on first use
+func (r *Refreshing) Get(ctx context.Context, name string) (string, error) {
+	r.mu.RLock()
+	value, ok := r.values[name]
+	r.mu.RUnlock()
+	if ok {
+		return value, nil
+	}
+	value, err := r.provider.Get(ctx, name)
+	if err != nil {
+		return "", err
+	}
+	r.mu.Lock()
+	r.values[name] = value
+	r.mu.Unlock()
+	return value, nil
+}
+
+// Refresh reads every cached secret again. A secret that cannot be read keeps its previous value.
+func (r *Refreshing) Refresh(ctx context.Context) {
+	r.mu.RLock()
+	names := make([]string, 0, len(r.values))
+	for name := range r.values {
+		names = append(names, name)
+	}
+	r.mu.RUnlock()
+
+	for _, name := range names {
+		value, err := r.provider.Get(ctx, name)
+		if err != nil {
+			zaplog.Warn(ctx, "error refreshing secret", zap.String("secret", name), zap.Error(err))
+			continue
+		}
+		r.mu.Lock()
+		r.values[name] = value
+		r.mu.Unlock()
+	}
+}
+
+// Run refreshes the secrets every interval until the context is done
+func (r *Refreshing) Run(ctx context.Context) {
+	ticker := time.NewTicker(r.interval)
+	defer ticker.Stop()
+	for {
+		select {
+		case <-ctx.Done():
+			return
+		case <-ticker.C:
+			r.Refresh(ctx)
+		}
+	}
+}
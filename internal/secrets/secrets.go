@@ -0,0 +1,188 @@
+// Package secrets resolves credentials such as the database password and the jwt secret from the environment,
+// from mounted files or from a JSON blob, and keeps them fresh so that rotated values are picked up at runtime.
+package secrets
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"os"
+	"path/filepath"
+	"strings"
+	"time"
+)
+
+const (
+	ProviderEnv  = "env"
+	ProviderFile = "file"
+	ProviderJSON = "json"
+
+	// DefaultJSONEnv is the variable Copilot injects the database secret of Secrets Manager into
+	DefaultJSONEnv = "DB_SECRET"
+)
+
+// ErrNotFound is returned when a provider does not hold the requested secret
+var ErrNotFound = errors.New("secret not found")
+
+// Provider returns the current value of a named secret, e.g. PSQL_PASS or JWT_SECRET
+type Provider interface {
+	Get(ctx context.Context, name string) (string, error)
+}
+
+// Config represents secrets specific config
+type Config struct {
+	// Provider is one of env, file or json, env is used when empty
+	Provider string
+	// Dir holds one file per secret for the file provider
+	Dir string
+	// JSONEnv is the variable holding the blob of the json provider
+	JSONEnv string
+	// JSONFile is the file holding the blob of the json provider, it is used instead of JSONEnv when set
+	JSONFile string
+	// Refresh is the interval at which the secrets are read again, they are read on every use when zero
+	Refresh time.Duration
+}
+
+// New returns the provider selected in the config. Secrets missing from a file or json provider are read from
+// the environment so that only the sensitive values need to be mounted.
+func New(cfg *Config) (Provider, error) {
+	if cfg == nil {
+		cfg = &Config{}
+	}
+	var p Provider
+	switch cfg.Provider {
+	case "", ProviderEnv:
+		p = Env{}
+	case ProviderFile:
+		if cfg.Dir == "" {
+			return nil, fmt.Errorf("secrets directory is required by the file provider")
+		}
+		p = Chain{File{Dir: cfg.Dir}, Env{}}
+	case ProviderJSON:
+		blob := JSONBlob{Env: cfg.JSONEnv, File: cfg.JSONFile, Keys: CopilotKeys}
+		if blob.Env == "" && blob.File == "" {
+			blob.Env = DefaultJSONEnv
+		}
+		p = Chain{blob, Env{}}
+	default:
+		return nil, fmt.Errorf("invalid secrets provider %s", cfg.Provider)
+	}
+	if cfg.Refresh > 0 {
+		return NewRefreshing(p, cfg.Refresh), nil
+	}
+	return p, nil
+}
+
+// FromEnv returns the provider selected by the SECRETS_* variables, it is used by the command line tools
+// which do not load the whole configuration
+func FromEnv() (Provider, error) {
+	return New(&Config{
+		Provider: os.Getenv("SECRETS_PROVIDER"),
+		Dir:      os.Getenv("SECRETS_DIR"),
+		JSONEnv:  os.Getenv("SECRETS_JSON_ENV"),
+		JSONFile: os.Getenv("SECRETS_JSON_FILE"),
+	})
+}
+
+// Env reads the secrets from environment variables of the same name
+type Env struct{}
+
+func (Env) Get(ctx context.Context, name string) (string, error) {
+	value, ok := os.LookupEnv(name)
+	if !ok {
+		return "", fmt.Errorf("%s: %w", name, ErrNotFound)
+	}
+	return value, nil
+}
+
+// File reads the secrets from files named after them, as mounted by Docker and Kubernetes
+type File struct {
+	Dir string
+}
+
+func (f File) Get(ctx context.Context, name string) (string, error) {
+	b, err := os.ReadFile(filepath.Join(f.Dir, name))
+	if errors.Is(err, os.ErrNotExist) {
+		return "", fmt.Errorf("%s: %w", name, ErrNotFound)
+	}
+	if err != nil {
+		return "", err
+	}
+	return strings.TrimRight(string(b), "\r\n"), nil
+}
+
+// CopilotKeys maps the secrets to the keys of the database secret Copilot generates in Secrets Manager
+var CopilotKeys = map[string]string{
+	"PSQL_USER":   "username",
+	"PSQL_PASS":   "password",
+	"PSQL_HOST":   "host",
+	"PSQL_PORT":   "port",
+	"PSQL_DBNAME": "dbname",
+}
+
+// JSONBlob reads the secrets from a JSON object held in an environment variable or in a file.
+// A secret is looked up by its name and then by the key it is mapped to in Keys.
+type JSONBlob struct {
+	Env  string
+	File string
+	Keys map[string]string
+}
+
+func (j JSONBlob) Get(ctx context.Context, name string) (string, error) {
+	var raw []byte
+	if j.File != "" {
+		b, err := os.ReadFile(j.File)
+		if err != nil {
+			return "", err
+		}
+		raw = b
+	} else {
+		raw = []byte(os.Getenv(j.Env))
+	}
+	if len(raw) == 0 {
+		return "", fmt.Errorf("%s: %w", name, ErrNotFound)
+	}
+
+	var blob map[string]interface{}
+	if err := json.Unmarshal(raw, &blob); err != nil {
+		return "", fmt.Errorf("error parsing secrets blob: %w", err)
+	}
+	value, ok := blob[name]
+	if !ok {
+		value, ok = blob[j.Keys[name]]
+	}
+	if !ok || value == nil {
+		return "", fmt.Errorf("%s: %w", name, ErrNotFound)
+	}
+	if s, ok := value.(string); ok {
+		return s, nil
+	}
+	// numbers, e.g. the port, are decoded as float64 and printed without a fraction
+	return fmt.Sprint(value), nil
+}
+
+// Chain returns the secret from the first provider holding it
+type Chain []Provider
+
+func (c Chain) Get(ctx context.Context, name string) (string, error) {
+	for _, p := range c {
+		value, err := p.Get(ctx, name)
+		if errors.Is(err, ErrNotFound) {
+			continue
+		}
+		return value, err
+	}
+	return "", fmt.Errorf("%s: %w", name, ErrNotFound)
+}
+
+// Static holds fixed secrets, it is meant for tests
+type Static map[string]string
+
+func (s Static) Get(ctx context.Context, name string) (string, error) {
+	value, ok := s[name]
+	if !ok {
+		return "", fmt.Errorf("%s: %w", name, ErrNotFound)
+	}
+	return value, nil
+}
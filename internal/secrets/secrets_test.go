@@ -0,0 +1,175 @@
+package secrets_test
+
+import (
+	"context"
+	"errors"
+	"os"
+	"path/filepath"
+	"testing"
+	"time"
+
+	"go-template/internal/secrets"
+
+	"github.com/stretchr/testify/assert"
+)
+
+const dbSecret = `{"username":"go_template_role","host":"localhost","dbname":"go_template",` +
+	`"password":"go_template_role456","port":5432}`
+
+func TestNew(t *testing.T) {
+	cases := map[string]struct {
+		cfg     *secrets.Config
+		want    interface{}
+		wantErr bool
+	}{
+		"Success_Default": {
+			want: secrets.Env{},
+		},
+		"Success_File": {
+			cfg:  &secrets.Config{Provider: secrets.ProviderFile, Dir: "/run/secrets"},
+			want: secrets.Chain{secrets.File{Dir: "/run/secrets"}, secrets.Env{}},
+		},
+		"Success_JSON": {
+			cfg: &secrets.Config{Provider: secrets.ProviderJSON},
+			want: secrets.Chain{
+				secrets.JSONBlob{Env: secrets.DefaultJSONEnv, Keys: secrets.CopilotKeys},
+				secrets.Env{},
+			},
+		},
+		"Success_Refreshing": {
+			cfg:  &secrets.Config{Refresh: time.Minute},
+			want: secrets.NewRefreshing(secrets.Env{}, time.Minute),
+		},
+		"Failure_FileWithoutDir": {
+			cfg:     &secrets.Config{Provider: secrets.ProviderFile},
+			wantErr: true,
+		},
+		"Failure_UnknownProvider": {
+			cfg:     &secrets.Config{Provider: "vault"},
+			wantErr: true,
+		},
+	}
+	for name, tt := range cases {
+		t.Run(name, func(t *testing.T) {
+			got, err := secrets.New(tt.cfg)
+			assert.Equal(t, tt.wantErr, err != nil)
+			if !tt.wantErr {
+				assert.Equal(t, tt.want, got)
+			}
+		})
+	}
+}
+
+func TestProviders(t *testing.T) {
+	dir := t.TempDir()
+	assert.Nil(t, os.WriteFile(filepath.Join(dir, "PSQL_PASS"), []byte("from-file\n"), 0600))
+	blobFile := filepath.Join(dir, "db.json")
+	assert.Nil(t, os.WriteFile(blobFile, []byte(dbSecret), 0600))
+	t.Setenv("DB_SECRET", dbSecret)
+	t.Setenv("PSQL_SSLMODE", "disable")
+	t.Setenv("JWT_SECRET", "from-env")
+
+	cases := map[string]struct {
+		provider secrets.Provider
+		secret   string
+		want     string
+		notFound bool
+	}{
+		"Env": {
+			provider: secrets.Env{},
+			secret:   "JWT_SECRET",
+			want:     "from-env",
+		},
+		"Env_NotFound": {
+			provider: secrets.Env{},
+			secret:   "MISSING_SECRET",
+			notFound: true,
+		},
+		"File": {
+			provider: secrets.File{Dir: dir},
+			secret:   "PSQL_PASS",
+			want:     "from-file",
+		},
+		"File_NotFound": {
+			provider: secrets.File{Dir: dir},
+			secret:   "JWT_SECRET",
+			notFound: true,
+		},
+		"JSONBlob_Env": {
+			provider: secrets.JSONBlob{Env: "DB_SECRET", Keys: secrets.CopilotKeys},
+			secret:   "PSQL_PASS",
+			want:     "go_template_role456",
+		},
+		"JSONBlob_File_Number": {
+			provider: secrets.JSONBlob{File: blobFile, Keys: secrets.CopilotKeys},
+			secret:   "PSQL_PORT",
+			want:     "5432",
+		},
+		"JSONBlob_NotFound": {
+			provider: secrets.JSONBlob{Env: "DB_SECRET", Keys: secrets.CopilotKeys},
+			secret:   "PSQL_SSLMODE",
+			notFound: true,
+		},
+		"Chain_Fallback": {
+			provider: secrets.Chain{secrets.JSONBlob{Env: "DB_SECRET", Keys: secrets.CopilotKeys}, secrets.Env{}},
+			secret:   "PSQL_SSLMODE",
+			want:     "disable",
+		},
+		"Static": {
+			provider: secrets.Static{"JWT_SECRET": "static"},
+			secret:   "JWT_SECRET",
+			want:     "static",
+		},
+	}
+	for name, tt := range cases {
+		t.Run(name, func(t *testing.T) {
+			got, err := tt.provider.Get(context.Background(), tt.secret)
+			assert.Equal(t, tt.notFound, errors.Is(err, secrets.ErrNotFound))
+			if !tt.notFound {
+				assert.Nil(t, err)
+				assert.Equal(t, tt.want, got)
+			}
+		})
+	}
+}
+
+func TestJSONBlob_Invalid(t *testing.T) {
+	t.Setenv("DB_SECRET", "not json")
+	_, err := secrets.JSONBlob{Env: "DB_SECRET"}.Get(context.Background(), "PSQL_PASS")
+	assert.NotNil(t, err)
+	assert.False(t, errors.Is(err, secrets.ErrNotFound))
+}
+
+func TestRefreshing(t *testing.T) {
+	source := secrets.Static{"PSQL_PASS": "old"}
+	r := secrets.NewRefreshing(source, 10*time.Millisecond)
+	ctx, cancel := context.WithCancel(context.Background())
+	defer cancel()
+
+	got, err := r.Get(ctx, "PSQL_PASS")
+	assert.Nil(t, err)
+	assert.Equal(t, "old", got)
+
+	// the cached value is served until the next refresh
+	source["PSQL_PASS"] = "rotated"
+	got, _ = r.Get(ctx, "PSQL_PASS")
+	assert.Equal(t, "old", got)
+
+	done := make(chan struct{})
+	go func() {
+		r.Run(ctx)
+		close(done)
+	}()
+	assert.Eventually(t, func() bool {
+		got, _ := r.Get(ctx, "PSQL_PASS")
+		return got == "rotated"
+	}, time.Second, 5*time.Millisecond)
+	cancel()
+	<-done
+
+	// a secret that cannot be read keeps its previous value
+	delete(source, "PSQL_PASS")
+	r.Refresh(ctx)
+	got, _ = r.Get(ctx, "PSQL_PASS")
+	assert.Equal(t, "rotated", got)
+}
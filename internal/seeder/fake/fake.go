@@ -0,0 +1,223 @@
+// Package fake generates realistic roles and users for the load and demo environments. The data only depends
+// on the seed, so that two environments seeded with the same seed hold the same users.
+package fake
+
+import (
+	"context"
+	"database/sql"
+	"errors"
+	"fmt"
+	"io"
+	"math/rand"
+	"strings"
+	"time"
+
+	"go-template/pkg/utl/secure"
+
+	"github.com/lib/pq"
+)
+
+var (
+	firstNames = []string{
+		"Aarav", "Aisha", "Alex", "Amelia", "Ananya", "Ben", "Carlos", "Chloe", "Daniel", "Diya", "Elena", "Ethan",
+		"Fatima", "Grace", "Hiro", "Ishaan", "Jack", "Julia", "Kabir", "Lena", "Liam", "Maya", "Mohammed", "Nina",
+		"Noah", "Olivia", "Omar", "Priya", "Rohan", "Sara", "Sofia", "Tariq", "Vikram", "Yuki", "Zara", "Zoe",
+	}
+	lastNames = []string{
+		"Ahmed", "Brown", "Chen", "Costa", "Das", "Fernandes", "Garcia", "Gupta", "Hassan", "Iyer", "Johnson",
+		"Khan", "Kim", "Kumar", "Lopez", "Martin", "Mehta", "Meyer", "Nair", "Novak", "Patel", "Rao", "Rossi",
+		"Sato", "Shah", "Silva", "Singh", "Smith", "Tanaka", "Williams", "Wilson", "Yilmaz",
+	}
+	streets = []string{
+		"Main", "Park", "Oak", "Maple", "Cedar", "Lake", "Hill", "Station", "Church", "Market", "River", "Garden",
+	}
+	streetTypes = []string{"Street", "Road", "Avenue", "Lane", "Boulevard", "Drive"}
+	cities      = []struct{ city, postcode, country string }{
+		{"Pune", "411001", "India"},
+		{"Mumbai", "400001", "India"},
+		{"Bengaluru", "560001", "India"},
+		{"London", "EC1A 1BB", "United Kingdom"},
+		{"Berlin", "10115", "Germany"},
+		{"Austin", "73301", "United States"},
+		{"Toronto", "M5H 2N2", "Canada"},
+		{"Singapore", "018956", "Singapore"},
+	}
+	roleTitles = []string{
+		"ADMIN", "MANAGER", "SUPPORT", "ANALYST", "AUDITOR", "OPERATOR", "EDITOR", "VIEWER", "BILLING", "DEVELOPER",
+	}
+)
+
+// epoch ends the year the users are created in, a fixed date keeps the data deterministic
+var epoch = time.Date(2023, 1, 1, 0, 0, 0, 0, time.UTC)
+
+// Role is a generated role
+type Role struct {
+	AccessLevel int
+	Name        string
+}
+
+// User is a generated user, RoleIndex is the index of its role among the generated roles
+type User struct {
+	FirstName string
+	LastName  string
+	Username  string
+	Password  string
+	Email     string
+	Mobile    string
+	Address   string
+	Active    bool
+	RoleIndex int
+	CreatedAt time.Time
+}
+
+// Generator generates the roles and then the users of a seed
+type Generator struct {
+	seed int64
+	rand *rand.Rand
+	// Passwords are the plain passwords the users are given, one in turn
+	Passwords []string
+	hashes    []string
+}
+
+// New returns a generator of the seed whose users share a pool of passwords, they are hashed once since
+// bcrypt takes tens of milliseconds per hash
+func New(seed int64, passwords int) *Generator {
+	g := &Generator{seed: seed, rand: rand.New(rand.NewSource(seed))} //nolint:gosec
+	sec := secure.New(1, nil)
+	for i := 0; i < passwords; i++ {
+		password := fmt.Sprintf("Demo-%d-%06d!", seed, g.rand.Intn(1000000))
+		g.Passwords = append(g.Passwords, password)
+		g.hashes = append(g.hashes, sec.Hash(password))
+	}
+	return g
+}
+
+func (g *Generator) pick(values []string) string {
+	return values[g.rand.Intn(len(values))]
+}
+
+// Roles returns n roles, their names are unique for the seed
+func (g *Generator) Roles(n int) []Role {
+	roles := make([]Role, n)
+	for i := range roles {
+		roles[i] = Role{
+			AccessLevel: 100 + g.rand.Intn(201),
+			Name:        fmt.Sprintf("%s_%d_%d", g.pick(roleTitles), g.seed, i+1),
+		}
+	}
+	return roles
+}
+
+// User returns the i-th user among roles roles, the users must be generated in order. The username and
+// email are unique for the seed.
+func (g *Generator) User(i int, roles int) User {
+	first, last := g.pick(firstNames), g.pick(lastNames)
+	handle := strings.ToLower(fmt.Sprintf("%s.%s.%d.%d", first, last, g.seed, i))
+	city := cities[g.rand.Intn(len(cities))]
+	return User{
+		FirstName: first,
+		LastName:  last,
+		Username:  handle,
+		Password:  g.hashes[i%len(g.hashes)],
+		Email:     handle + "@example.com",
+		Mobile:    fmt.Sprintf("+91%d%09d", 6+g.rand.Intn(4), g.rand.Intn(1000000000)),
+		Address: fmt.Sprintf("%d %s %s, %s %s, %s", 1+g.rand.Intn(999), g.pick(streets), g.pick(streetTypes),
+			city.city, city.postcode, city.country),
+		// one user in ten is deactivated
+		Active:    g.rand.Intn(10) != 0,
+		RoleIndex: g.rand.Intn(roles),
+		CreatedAt: epoch.Add(-time.Duration(g.rand.Int63n(int64(365 * 24 * time.Hour)))),
+	}
+}
+
+// Options sets the volumes of the data generated by Seed
+type Options struct {
+	Seed      int64
+	Roles     int
+	Users     int
+	Passwords int
+	// BatchSize is the number of users copied in a single transaction
+	BatchSize int
+}
+
+var userColumns = []string{
+	"first_name", "last_name", "username", "password", "email", "mobile", "address", "active", "role_id",
+	"created_at", "updated_at",
+}
+
+// Seed inserts the roles and then copies the users in batches
+func Seed(ctx context.Context, db *sql.DB, opts Options, out io.Writer) error {
+	if opts.Roles < 1 || opts.Users < 0 || opts.Passwords < 1 || opts.BatchSize < 1 {
+		return errors.New("invalid options: at least a role, a password and a batch of one user are needed")
+	}
+	g := New(opts.Seed, opts.Passwords)
+
+	roleIDs, err := insertRoles(ctx, db, g.Roles(opts.Roles))
+	if err != nil {
+		return fmt.Errorf("error inserting the roles: %w", err)
+	}
+	fmt.Fprintf(out, "Inserted %d roles\n", len(roleIDs))
+
+	for start := 0; start < opts.Users; start += opts.BatchSize {
+		end := start + opts.BatchSize
+		if end > opts.Users {
+			end = opts.Users
+		}
+		if err := copyUsers(ctx, db, g, roleIDs, start, end); err != nil {
+			return fmt.Errorf("error copying the users %d to %d: %w", start, end, err)
+		}
+		fmt.Fprintf(out, "Copied %d/%d users\n", end, opts.Users)
+	}
+	fmt.Fprintf(out, "The users log in with the passwords %s\n", strings.Join(g.Passwords, ", "))
+	return nil
+}
+
+func insertRoles(ctx context.Context, db *sql.DB, roles []Role) ([]int, error) {
+	tx, err := db.BeginTx(ctx, nil)
+	if err != nil {
+		return nil, err
+	}
+	// a no-op once the transaction is committed
+	defer tx.Rollback() //nolint:errcheck
+
+	ids := make([]int, len(roles))
+	for i, role := range roles {
+		if err := tx.QueryRowContext(ctx, `INSERT INTO public.roles (access_level, name, created_at, updated_at)
+			VALUES ($1, $2, $3, $3) RETURNING id`, role.AccessLevel, role.Name, epoch).Scan(&ids[i]); err != nil {
+			return nil, err
+		}
+	}
+	return ids, tx.Commit()
+}
+
+// copyUsers copies the users start to end, excluded, with the COPY protocol in a single transaction
+func copyUsers(ctx context.Context, db *sql.DB, g *Generator, roleIDs []int, start int, end int) error {
+	tx, err := db.BeginTx(ctx, nil)
+	if err != nil {
+		return err
+	}
+	// a no-op once the transaction is committed
+	defer tx.Rollback() //nolint:errcheck
+
+	stmt, err := tx.PrepareContext(ctx, pq.CopyInSchema("public", "users", userColumns...))
+	if err != nil {
+		return err
+	}
+	for i := start; i < end; i++ {
+		u := g.User(i, len(roleIDs))
+		if _, err := stmt.ExecContext(ctx, u.FirstName, u.LastName, u.Username, u.Password, u.Email, u.Mobile,
+			u.Address, u.Active, roleIDs[u.RoleIndex], u.CreatedAt, u.CreatedAt); err != nil {
+			stmt.Close()
+			return err
+		}
+	}
+	// the rows are sent once the statement is executed without arguments
+	if _, err := stmt.ExecContext(ctx); err != nil {
+		stmt.Close()
+		return err
+	}
+	if err := stmt.Close(); err != nil {
+		return err
+	}
+	return tx.Commit()
+}
@@ -0,0 +1,125 @@
+package fake_test
+
+import (
+	"bytes"
+	"context"
+	"errors"
+	"regexp"
+	"testing"
+
+	"go-template/internal/seeder/fake"
+	"go-template/pkg/utl/secure"
+
+	"github.com/DATA-DOG/go-sqlmock"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestGenerator(t *testing.T) {
+	generate := func(seed int64) ([]fake.Role, []fake.User, *fake.Generator) {
+		g := fake.New(seed, 2)
+		roles := g.Roles(3)
+		var users []fake.User
+		for i := 0; i < 20; i++ {
+			users = append(users, g.User(i, len(roles)))
+		}
+		return roles, users, g
+	}
+	roles, users, g := generate(42)
+	otherRoles, otherUsers, other := generate(42)
+
+	// the hashes are salted, everything else is the same for the same seed
+	assert.Equal(t, g.Passwords, other.Passwords)
+	assert.Equal(t, roles, otherRoles)
+	sec := secure.New(1, nil)
+	for i := range users {
+		assert.True(t, sec.HashMatchesPassword(users[i].Password, g.Passwords[i%2]))
+		users[i].Password, otherUsers[i].Password = "", ""
+	}
+	assert.Equal(t, users, otherUsers)
+
+	_, differentUsers, _ := generate(43)
+	assert.NotEqual(t, users[0].Username, differentUsers[0].Username)
+
+	emails := map[string]bool{}
+	for _, u := range users {
+		assert.Regexp(t, `^[a-z]+\.[a-z]+\.42\.\d+@example\.com$`, u.Email)
+		assert.Regexp(t, `^\+91[6-9]\d{9}$`, u.Mobile)
+		assert.NotEmpty(t, u.Address)
+		assert.True(t, u.RoleIndex >= 0 && u.RoleIndex < len(roles))
+		assert.False(t, emails[u.Email])
+		emails[u.Email] = true
+	}
+}
+
+func TestSeed(t *testing.T) {
+	copyIn := regexp.QuoteMeta(`COPY "public"."users" ("first_name", "last_name", "username", "password", "email", ` +
+		`"mobile", "address", "active", "role_id", "created_at", "updated_at") FROM STDIN`)
+	roles := func(mock sqlmock.Sqlmock) {
+		mock.ExpectBegin()
+		mock.ExpectQuery("INSERT INTO public.roles").WillReturnRows(sqlmock.NewRows([]string{"id"}).AddRow(7))
+		mock.ExpectQuery("INSERT INTO public.roles").WillReturnRows(sqlmock.NewRows([]string{"id"}).AddRow(8))
+		mock.ExpectCommit()
+	}
+	cases := map[string]struct {
+		opts fake.Options
+		init func(mock sqlmock.Sqlmock)
+		want string
+		err  string
+	}{
+		"Failure_Options": {
+			opts: fake.Options{Roles: 0, Users: 1, Passwords: 1, BatchSize: 1},
+			init: func(mock sqlmock.Sqlmock) {},
+			err:  "invalid options: at least a role, a password and a batch of one user are needed",
+		},
+		"Success_Batches": {
+			opts: fake.Options{Seed: 1, Roles: 2, Users: 3, Passwords: 1, BatchSize: 2},
+			init: func(mock sqlmock.Sqlmock) {
+				roles(mock)
+				for _, batch := range []int{2, 1} {
+					mock.ExpectBegin()
+					prepare := mock.ExpectPrepare(copyIn)
+					for i := 0; i < batch; i++ {
+						prepare.ExpectExec().WithArgs(sqlmock.AnyArg(), sqlmock.AnyArg(), sqlmock.AnyArg(),
+							sqlmock.AnyArg(), sqlmock.AnyArg(), sqlmock.AnyArg(), sqlmock.AnyArg(), sqlmock.AnyArg(),
+							sqlmock.AnyArg(), sqlmock.AnyArg(), sqlmock.AnyArg()).
+							WillReturnResult(sqlmock.NewResult(0, 1))
+					}
+					prepare.ExpectExec().WithArgs().WillReturnResult(sqlmock.NewResult(0, 0))
+					prepare.WillBeClosed()
+					mock.ExpectCommit()
+				}
+			},
+			want: "Inserted 2 roles\nCopied 2/3 users\nCopied 3/3 users\n",
+		},
+		"Failure_Copy": {
+			opts: fake.Options{Seed: 1, Roles: 2, Users: 3, Passwords: 1, BatchSize: 5},
+			init: func(mock sqlmock.Sqlmock) {
+				roles(mock)
+				mock.ExpectBegin()
+				mock.ExpectPrepare(copyIn).ExpectExec().WillReturnError(errors.New("violates foreign key constraint"))
+				mock.ExpectRollback()
+			},
+			err: "error copying the users 0 to 3: violates foreign key constraint",
+		},
+	}
+	for name, tt := range cases {
+		t.Run(name, func(t *testing.T) {
+			db, mock, err := sqlmock.New()
+			if err != nil {
+				t.Fatal(err)
+			}
+			defer db.Close()
+			tt.init(mock)
+
+			out := &bytes.Buffer{}
+			err = fake.Seed(context.Background(), db, tt.opts, out)
+			if tt.err != "" {
+				assert.EqualError(t, err, tt.err)
+			} else {
+				assert.Nil(t, err)
+				assert.Contains(t, out.String(), tt.want)
+			}
+			assert.Nil(t, mock.ExpectationsWereMet())
+		})
+	}
+}
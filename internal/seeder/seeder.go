@@ -0,0 +1,195 @@
+// Package seeder runs the seeders registered with a Registry, in the order of their dependencies. Every seeder
+// runs in its own transaction and the version it seeded is recorded in the seed_versions table, so that running
+// the seeders again only runs the new ones and the ones whose version was bumped.
+package seeder
+
+import (
+	"context"
+	"database/sql"
+	"fmt"
+	"io"
+	"sort"
+	"strings"
+)
+
+const createVersionsTable = `CREATE TABLE IF NOT EXISTS public.seed_versions (
+	name TEXT PRIMARY KEY,
+	version INT NOT NULL,
+	seeded_at TIMESTAMP WITH TIME ZONE NOT NULL
+)`
+
+// Seeder fills the database with the rows a feature needs. Seed must leave the database as it found it when
+// the rows are already there, e.g. with ON CONFLICT clauses, since the rows may have been inserted otherwise.
+type Seeder struct {
+	Name string
+	// Version is bumped to run the seeder again where a previous version already ran
+	Version int
+	// DependsOn are the names of the seeders that run first
+	DependsOn []string
+	// Envs are the environments the seeder runs in, every one when empty
+	Envs []string
+	Seed func(ctx context.Context, tx *sql.Tx) error
+}
+
+// runsIn returns whether the seeder runs in the environment
+func (s *Seeder) runsIn(env string) bool {
+	if len(s.Envs) == 0 {
+		return true
+	}
+	for _, e := range s.Envs {
+		if e == env {
+			return true
+		}
+	}
+	return false
+}
+
+// Registry holds the seeders by name
+type Registry struct {
+	seeders map[string]*Seeder
+	names   []string
+}
+
+// NewRegistry returns an empty registry
+func NewRegistry() *Registry {
+	return &Registry{seeders: map[string]*Seeder{}}
+}
+
+// Register adds the seeder to the registry, its dependencies may be registered after it
+func (r *Registry) Register(s Seeder) error {
+	if s.Name == "" || s.Seed == nil {
+		return fmt.Errorf("seeder %q needs a name and a seed function", s.Name)
+	}
+	if _, ok := r.seeders[s.Name]; ok {
+		return fmt.Errorf("seeder %s is already registered", s.Name)
+	}
+	r.seeders[s.Name] = &s
+	r.names = append(r.names, s.Name)
+	return nil
+}
+
+// List returns the seeders in the order they run in
+func (r *Registry) List() ([]*Seeder, error) {
+	return r.Plan()
+}
+
+// Plan returns the seeders named and their dependencies, each after the seeders it depends on. Every seeder
+// is planned when no name is given.
+func (r *Registry) Plan(names ...string) ([]*Seeder, error) {
+	if len(names) == 0 {
+		names = append([]string{}, r.names...)
+		sort.Strings(names)
+	}
+	var (
+		planned  []*Seeder
+		done     = map[string]bool{}
+		visiting = map[string]bool{}
+		visit    func(name string, path []string) error
+	)
+	visit = func(name string, path []string) error {
+		if done[name] {
+			return nil
+		}
+		s, ok := r.seeders[name]
+		if !ok {
+			if len(path) > 0 {
+				return fmt.Errorf("seeder %s depends on unknown seeder %s", path[len(path)-1], name)
+			}
+			return fmt.Errorf("unknown seeder %s", name)
+		}
+		path = append(path, name)
+		if visiting[name] {
+			return fmt.Errorf("seeders depend on each other: %s", strings.Join(path, " -> "))
+		}
+		visiting[name] = true
+		deps := append([]string{}, s.DependsOn...)
+		sort.Strings(deps)
+		for _, dep := range deps {
+			if err := visit(dep, path); err != nil {
+				return err
+			}
+		}
+		visiting[name] = false
+		done[name] = true
+		planned = append(planned, s)
+		return nil
+	}
+	for _, name := range names {
+		if err := visit(name, nil); err != nil {
+			return nil, err
+		}
+	}
+	return planned, nil
+}
+
+// Versions returns the version seeded by every seeder that ran
+func Versions(ctx context.Context, db *sql.DB) (map[string]int, error) {
+	if _, err := db.ExecContext(ctx, createVersionsTable); err != nil {
+		return nil, fmt.Errorf("error creating the seed_versions table: %w", err)
+	}
+	rows, err := db.QueryContext(ctx, "SELECT name, version FROM public.seed_versions")
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+	versions := map[string]int{}
+	for rows.Next() {
+		var (
+			name    string
+			version int
+		)
+		if err := rows.Scan(&name, &version); err != nil {
+			return nil, err
+		}
+		versions[name] = version
+	}
+	return versions, rows.Err()
+}
+
+// Run runs the seeders named and their dependencies that belong to the environment and didn't seed their
+// current version yet, every one of them when no name is given
+func (r *Registry) Run(ctx context.Context, db *sql.DB, env string, out io.Writer, names ...string) error {
+	planned, err := r.Plan(names...)
+	if err != nil {
+		return err
+	}
+	versions, err := Versions(ctx, db)
+	if err != nil {
+		return err
+	}
+	for _, s := range planned {
+		seeded, ok := versions[s.Name]
+		switch {
+		case !s.runsIn(env):
+			fmt.Fprintf(out, "%s: skipped in %s\n", s.Name, env)
+		case ok && seeded >= s.Version:
+			fmt.Fprintf(out, "%s: version %d already seeded\n", s.Name, seeded)
+		default:
+			if err := seed(ctx, db, s); err != nil {
+				return fmt.Errorf("error seeding %s: %w", s.Name, err)
+			}
+			fmt.Fprintf(out, "%s: seeded version %d\n", s.Name, s.Version)
+		}
+	}
+	return nil
+}
+
+// seed runs the seeder and records its version in a single transaction
+func seed(ctx context.Context, db *sql.DB, s *Seeder) error {
+	tx, err := db.BeginTx(ctx, nil)
+	if err != nil {
+		return err
+	}
+	// a no-op once the transaction is committed
+	defer tx.Rollback() //nolint:errcheck
+
+	if err := s.Seed(ctx, tx); err != nil {
+		return err
+	}
+	if _, err := tx.ExecContext(ctx, `INSERT INTO public.seed_versions (name, version, seeded_at) VALUES ($1, $2, now())
+		ON CONFLICT (name) DO UPDATE SET version = EXCLUDED.version, seeded_at = EXCLUDED.seeded_at`,
+		s.Name, s.Version); err != nil {
+		return err
+	}
+	return tx.Commit()
+}
@@ -0,0 +1,178 @@
+package seeder_test
+
+import (
+	"bytes"
+	"context"
+	"database/sql"
+	"errors"
+	"testing"
+
+	"go-template/internal/seeder"
+
+	"github.com/DATA-DOG/go-sqlmock"
+	"github.com/stretchr/testify/assert"
+)
+
+func noop(ctx context.Context, tx *sql.Tx) error { return nil }
+
+func names(seeders []*seeder.Seeder) []string {
+	var names []string
+	for _, s := range seeders {
+		names = append(names, s.Name)
+	}
+	return names
+}
+
+func TestRegister(t *testing.T) {
+	r := seeder.NewRegistry()
+	assert.Nil(t, r.Register(seeder.Seeder{Name: "roles", Seed: noop}))
+	assert.EqualError(t, r.Register(seeder.Seeder{Name: "roles", Seed: noop}), "seeder roles is already registered")
+	assert.EqualError(t, r.Register(seeder.Seeder{Name: "users"}), "seeder \"users\" needs a name and a seed function")
+}
+
+func TestPlan(t *testing.T) {
+	cases := map[string]struct {
+		seeders []seeder.Seeder
+		names   []string
+		want    []string
+		err     string
+	}{
+		"Success_All": {
+			seeders: []seeder.Seeder{
+				{Name: "users", DependsOn: []string{"roles"}, Seed: noop},
+				{Name: "audit", DependsOn: []string{"users", "roles"}, Seed: noop},
+				{Name: "roles", Seed: noop},
+			},
+			want: []string{"roles", "users", "audit"},
+		},
+		"Success_Named": {
+			seeders: []seeder.Seeder{
+				{Name: "users", DependsOn: []string{"roles"}, Seed: noop},
+				{Name: "roles", Seed: noop},
+				{Name: "flags", Seed: noop},
+			},
+			names: []string{"users"},
+			want:  []string{"roles", "users"},
+		},
+		"Failure_Unknown": {
+			seeders: []seeder.Seeder{{Name: "roles", Seed: noop}},
+			names:   []string{"users"},
+			err:     "unknown seeder users",
+		},
+		"Failure_UnknownDependency": {
+			seeders: []seeder.Seeder{{Name: "users", DependsOn: []string{"roles"}, Seed: noop}},
+			err:     "seeder users depends on unknown seeder roles",
+		},
+		"Failure_Cycle": {
+			seeders: []seeder.Seeder{
+				{Name: "a", DependsOn: []string{"b"}, Seed: noop},
+				{Name: "b", DependsOn: []string{"a"}, Seed: noop},
+			},
+			err: "seeders depend on each other: a -> b -> a",
+		},
+	}
+	for name, tt := range cases {
+		t.Run(name, func(t *testing.T) {
+			r := seeder.NewRegistry()
+			for _, s := range tt.seeders {
+				assert.Nil(t, r.Register(s))
+			}
+			planned, err := r.Plan(tt.names...)
+			if tt.err != "" {
+				assert.EqualError(t, err, tt.err)
+				return
+			}
+			assert.Nil(t, err)
+			assert.Equal(t, tt.want, names(planned))
+		})
+	}
+}
+
+func TestRun(t *testing.T) {
+	versions := func(mock sqlmock.Sqlmock, rows *sqlmock.Rows) {
+		mock.ExpectExec("CREATE TABLE IF NOT EXISTS public.seed_versions").WillReturnResult(sqlmock.NewResult(0, 0))
+		mock.ExpectQuery("SELECT name, version FROM public.seed_versions").WillReturnRows(rows)
+	}
+	seedRoles := func(ctx context.Context, tx *sql.Tx) error {
+		_, err := tx.ExecContext(ctx, "INSERT INTO public.roles")
+		return err
+	}
+	cases := map[string]struct {
+		env  string
+		init func(mock sqlmock.Sqlmock)
+		want string
+		err  string
+	}{
+		"Success_Seeds": {
+			env: "local",
+			init: func(mock sqlmock.Sqlmock) {
+				versions(mock, sqlmock.NewRows([]string{"name", "version"}))
+				mock.ExpectBegin()
+				mock.ExpectExec("INSERT INTO public.roles").WillReturnResult(sqlmock.NewResult(1, 1))
+				mock.ExpectExec("INSERT INTO public.seed_versions").WithArgs("roles", 2).
+					WillReturnResult(sqlmock.NewResult(1, 1))
+				mock.ExpectCommit()
+				mock.ExpectBegin()
+				mock.ExpectExec("INSERT INTO public.seed_versions").WithArgs("flags", 1).
+					WillReturnResult(sqlmock.NewResult(1, 1))
+				mock.ExpectCommit()
+			},
+			want: "roles: seeded version 2\nflags: seeded version 1\n",
+		},
+		"Success_AlreadySeededAndOtherEnv": {
+			env: "production",
+			init: func(mock sqlmock.Sqlmock) {
+				versions(mock, sqlmock.NewRows([]string{"name", "version"}).AddRow("roles", 2))
+			},
+			want: "roles: version 2 already seeded\nflags: skipped in production\n",
+		},
+		"Success_VersionBumped": {
+			env: "production",
+			init: func(mock sqlmock.Sqlmock) {
+				versions(mock, sqlmock.NewRows([]string{"name", "version"}).AddRow("roles", 1))
+				mock.ExpectBegin()
+				mock.ExpectExec("INSERT INTO public.roles").WillReturnResult(sqlmock.NewResult(1, 1))
+				mock.ExpectExec("INSERT INTO public.seed_versions").WithArgs("roles", 2).
+					WillReturnResult(sqlmock.NewResult(1, 1))
+				mock.ExpectCommit()
+			},
+			want: "roles: seeded version 2\nflags: skipped in production\n",
+		},
+		"Failure_RolledBack": {
+			env: "production",
+			init: func(mock sqlmock.Sqlmock) {
+				versions(mock, sqlmock.NewRows([]string{"name", "version"}))
+				mock.ExpectBegin()
+				mock.ExpectExec("INSERT INTO public.roles").WillReturnError(errors.New("duplicate key"))
+				mock.ExpectRollback()
+			},
+			err: "error seeding roles: duplicate key",
+		},
+	}
+	for name, tt := range cases {
+		t.Run(name, func(t *testing.T) {
+			db, mock, err := sqlmock.New()
+			if err != nil {
+				t.Fatal(err)
+			}
+			defer db.Close()
+			tt.init(mock)
+
+			r := seeder.NewRegistry()
+			assert.Nil(t, r.Register(seeder.Seeder{Name: "roles", Version: 2, Seed: seedRoles}))
+			assert.Nil(t, r.Register(seeder.Seeder{
+				Name: "flags", Version: 1, DependsOn: []string{"roles"}, Envs: []string{"local"}, Seed: noop,
+			}))
+
+			out := &bytes.Buffer{}
+			err = r.Run(context.Background(), db, tt.env, out)
+			if tt.err != "" {
+				assert.EqualError(t, err, tt.err)
+			} else {
+				assert.Nil(t, err)
+				assert.Equal(t, tt.want, out.String())
+			}
+			assert.Nil(t, mock.ExpectationsWereMet())
+		})
+	}
+}
@@ -2,14 +2,19 @@ package server
 
 import (
 	"context"
+	"crypto/tls"
 	"log"
 	"net/http"
 	"os"
 	"os/signal"
+	"syscall"
 	"time"
 
 	controller "go-template/internal/controller"
+	"go-template/internal/middleware/accesslog"
+	"go-template/internal/middleware/bodylog"
 	"go-template/internal/middleware/secure"
+	"go-template/internal/service/metrics"
 	"go-template/internal/service/tracer"
 	"go-template/pkg/utl/zaplog"
 
@@ -18,6 +23,7 @@ import (
 	"github.com/labstack/echo/v4/middleware"
 	"github.com/labstack/gommon/random"
 	"go.opentelemetry.io/contrib/instrumentation/github.com/labstack/echo/otelecho"
+	"golang.org/x/net/http2"
 )
 
 type CustomContext struct {
@@ -26,12 +32,11 @@ type CustomContext struct {
 }
 
 // New instantates new Echo server
-func New() *echo.Echo {
+func New(cfg *Config) *echo.Echo {
 	e := echo.New()
 	e.Use(
 		otelecho.Middleware(os.Getenv("SERVICE_NAME")),
-		middleware.Logger(),
-		middleware.Recover(),
+		metrics.Middleware(),
 		func(next echo.HandlerFunc) echo.HandlerFunc {
 			return func(c echo.Context) error {
 				req := c.Request()
@@ -47,14 +52,19 @@ func New() *echo.Echo {
 				return next(cc)
 			}
 		},
-		middleware.BodyDump(func(c echo.Context, reqBody, resBody []byte) {
-			zaplog.Info(c.Request().Context(), string(reqBody))
-			zaplog.Info(c.Request().Context(), string(resBody))
-		}),
-		secure.Headers(),
-		secure.CORS(),
+		accesslog.Middleware(),
+		middleware.Recover(),
+		bodylog.Middleware(cfg.BodyLog),
+		secure.Headers(cfg.Headers),
+		secure.CORS(cfg.CORS),
 	)
+	readiness := cfg.Readiness
+	if readiness == nil {
+		readiness = controller.NewReadiness(0)
+	}
 	e.GET("/", controller.HealthCheckHandler)
+	e.GET("/healthz", controller.LivenessHandler)
+	e.GET("/readyz", readiness.Handler)
 	e.Validator = &CustomValidator{V: validator.New()}
 	custErr := &customErrHandler{e: e}
 	e.HTTPErrorHandler = custErr.handler
@@ -68,39 +78,152 @@ type Config struct {
 	ReadTimeoutSeconds  int
 	WriteTimeoutSeconds int
 	Debug               bool
+	// AdminPort is the port of the admin server, it is not started when empty
+	AdminPort    string
+	AdminHandler http.Handler
+	Tracer       *tracer.Config
+	BodyLog      *bodylog.Config
+	// Readiness is served at /readyz and drained when the server shuts down
+	Readiness *controller.Readiness
+	Headers   *secure.HeadersConfig
+	CORS      *secure.CORSConfig
+	// DrainSeconds is the time between readiness failing and the server shutting down
+	DrainSeconds           int
+	ShutdownTimeoutSeconds int
+	// Subscriptions are ended when the server shuts down
+	Subscriptions *Subscriptions
+	// Closers are closed in order once the server is shut down
+	Closers []Closer
+	// TLSCertFile and TLSKeyFile serve HTTPS and HTTP/2 when both are set
+	TLSCertFile string
+	TLSKeyFile  string
+	// H2C serves HTTP/2 without TLS, for the traffic inside the cluster
+	H2C bool
+	// RedirectPort is the port of the listener redirecting HTTP to HTTPS, it is only started with TLS
+	RedirectPort string
 }
 
+// defaultShutdownTimeout bounds the shutdown when no timeout is configured
+const defaultShutdownTimeout = 10 * time.Second
+
 // Start starts echo server
 func Start(e *echo.Echo, cfg *Config) {
-	tp := tracer.Init()
-	s := &http.Server{
-		Addr:         cfg.Port,
-		ReadTimeout:  time.Duration(cfg.ReadTimeoutSeconds) * time.Second,
-		WriteTimeout: time.Duration(cfg.WriteTimeoutSeconds) * time.Second,
+	tp, err := tracer.Init(cfg.Tracer)
+	if err != nil {
+		zaplog.Logger.Warn("Traces will not be exported: ", err)
 	}
 	e.Debug = cfg.Debug
+	// the servers of echo are used so that e.Shutdown waits for the requests in flight
+	s := e.Server
+	if cfg.TLSCertFile != "" && cfg.TLSKeyFile != "" {
+		certs, err := newCertReloader(cfg.TLSCertFile, cfg.TLSKeyFile)
+		if err != nil {
+			e.StdLogger.Fatal(err)
+		}
+		s = e.TLSServer
+		s.TLSConfig = &tls.Config{
+			MinVersion:     tls.VersionTLS12,
+			GetCertificate: certs.GetCertificate,
+			NextProtos:     []string{"h2", "http/1.1"},
+		}
+	}
+	s.Addr = cfg.Port
+	s.ReadTimeout = time.Duration(cfg.ReadTimeoutSeconds) * time.Second
+	s.WriteTimeout = time.Duration(cfg.WriteTimeoutSeconds) * time.Second
 
 	// Start server
 	go func() {
 		zaplog.Logger.Info("Warming up server... ")
-		if err := e.StartServer(s); err != nil {
+		var err error
+		if s.TLSConfig == nil && cfg.H2C {
+			err = e.StartH2CServer(s.Addr, &http2.Server{})
+		} else {
+			err = e.StartServer(s)
+		}
+		if err != nil {
 			e.Logger.Info("Shutting down the server")
 		}
 	}()
 
-	// Wait for interrupt signal to gracefully shutdown the server with
-	// a timeout of 10 seconds.
+	var redirect *http.Server
+	if cfg.RedirectPort != "" && s.TLSConfig != nil {
+		redirect = &http.Server{
+			Addr:         cfg.RedirectPort,
+			Handler:      redirectHandler(cfg.Port),
+			ReadTimeout:  s.ReadTimeout,
+			WriteTimeout: s.WriteTimeout,
+		}
+		go func() {
+			zaplog.Logger.Info("Redirecting to HTTPS from ", cfg.RedirectPort)
+			if err := redirect.ListenAndServe(); err != nil && err != http.ErrServerClosed {
+				zaplog.Logger.Error("redirect server stopped: ", err)
+			}
+		}()
+	}
+
+	var admin *http.Server
+	if cfg.AdminPort != "" && cfg.AdminHandler != nil {
+		admin = &http.Server{
+			Addr:         cfg.AdminPort,
+			Handler:      cfg.AdminHandler,
+			ReadTimeout:  s.ReadTimeout,
+			WriteTimeout: s.WriteTimeout,
+		}
+		go func() {
+			zaplog.Logger.Info("Starting admin server on ", cfg.AdminPort)
+			if err := admin.ListenAndServe(); err != nil && err != http.ErrServerClosed {
+				zaplog.Logger.Error("admin server stopped: ", err)
+			}
+		}()
+	}
+
+	// Wait for interrupt signal, or the SIGTERM sent by ECS, to gracefully shutdown the server
 	quit := make(chan os.Signal, 1)
-	signal.Notify(quit, os.Interrupt)
+	signal.Notify(quit, os.Interrupt, syscall.SIGTERM)
 	<-quit
-	ctx, cancel := context.WithTimeout(context.Background(), 10*time.Second)
+	signal.Stop(quit)
+
+	// readiness fails first so that the load balancer stops sending requests during the drain period
+	if cfg.Readiness != nil {
+		cfg.Readiness.Drain()
+	}
+	if cfg.DrainSeconds > 0 {
+		zaplog.Logger.Info("Draining for ", cfg.DrainSeconds, " seconds")
+		time.Sleep(time.Duration(cfg.DrainSeconds) * time.Second)
+	}
+
+	timeout := time.Duration(cfg.ShutdownTimeoutSeconds) * time.Second
+	if timeout <= 0 {
+		timeout = defaultShutdownTimeout
+	}
+	ctx, cancel := context.WithTimeout(context.Background(), timeout)
 	defer func() {
-		cancel()
-		if err := tp.Shutdown(context.Background()); err != nil {
+		defer cancel()
+		if admin != nil {
+			if err := admin.Close(); err != nil {
+				log.Printf("Error shutting down admin server: %v", err)
+			}
+		}
+		if redirect != nil {
+			if err := redirect.Close(); err != nil {
+				log.Printf("Error shutting down redirect server: %v", err)
+			}
+		}
+		// the pending spans are flushed before the resources they trace are closed
+		if err := tp.Shutdown(ctx); err != nil {
 			log.Printf("Error shutting down tracer provider: %v", err)
 		}
+		for _, closer := range cfg.Closers {
+			if err := closer.Close(ctx); err != nil {
+				log.Printf("Error closing %s: %v", closer.Name, err)
+			}
+		}
 	}()
+	if cfg.Subscriptions != nil {
+		cfg.Subscriptions.Close()
+	}
+	// the error is only logged, exiting here would skip the closers deferred above
 	if err := e.Shutdown(ctx); err != nil {
-		e.StdLogger.Fatal(err)
+		zaplog.Logger.Error("Error shutting down server: ", err)
 	}
 }
@@ -12,6 +12,7 @@ import (
 	"time"
 
 	"go-template/internal/server"
+	"go-template/internal/service/tracer"
 	"go-template/testutls"
 
 	. "github.com/agiledragon/gomonkey/v2"
@@ -21,7 +22,7 @@ import (
 
 // Improve tests
 func TestNew(t *testing.T) {
-	e := server.New()
+	e := server.New(&server.Config{})
 	if e == nil {
 		t.Errorf("Server should not be nil")
 	}
@@ -50,14 +51,16 @@ type args struct {
 
 func initValues(shutDownFailed bool, startServer func(e *echo.Echo, s *http.Server) error) args {
 	config := testutls.MockConfig()
+	cfg := &server.Config{
+		Port:                config.Server.Port,
+		ReadTimeoutSeconds:  config.Server.ReadTimeout,
+		WriteTimeoutSeconds: config.Server.WriteTimeout,
+		Debug:               config.Server.Debug,
+		Tracer:              &tracer.Config{Exporter: tracer.ExporterNone},
+	}
 	return args{
-		e: server.New(),
-		cfg: &server.Config{
-			Port:                config.Server.Port,
-			ReadTimeoutSeconds:  config.Server.ReadTimeout,
-			WriteTimeoutSeconds: config.Server.WriteTimeout,
-			Debug:               config.Server.Debug,
-		},
+		e:              server.New(cfg),
+		cfg:            cfg,
 		startServer:    startServer,
 		shutDownFailed: true,
 	}
@@ -92,15 +95,11 @@ func TestStart(t *testing.T) {
 			})
 
 			if tt.args.shutDownFailed {
+				// the failure is logged and Start returns
 				ApplyMethod(reflect.TypeOf(tt.args.e), "Shutdown", func(e *echo.Echo, ctx context.Context) (err error) {
+					tt.args.serverShutDownCalled = true
 					return fmt.Errorf("error shutting down")
 				})
-
-				ApplyMethod(
-					reflect.TypeOf(tt.args.e.StdLogger), "Fatal",
-					func(l *log.Logger, i ...interface{}) {
-						tt.args.serverShutDownCalled = true
-					})
 			}
 
 			go func() {
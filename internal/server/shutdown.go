@@ -0,0 +1,85 @@
+package server
+
+import (
+	"context"
+	"sync"
+	"sync/atomic"
+
+	"github.com/99designs/gqlgen/graphql/handler/transport"
+)
+
+// Closer releases a resource once the server stopped serving requests
+type Closer struct {
+	Name  string
+	Close func(ctx context.Context) error
+}
+
+// Subscriptions ends the websocket connections when the server shuts down, the connections are not tracked by
+// http.Server.Shutdown once upgraded
+type Subscriptions struct {
+	mu      sync.Mutex
+	closed  bool
+	next    int
+	cancels map[int]context.CancelFunc
+}
+
+// NewSubscriptions returns open Subscriptions
+func NewSubscriptions() *Subscriptions {
+	return &Subscriptions{cancels: map[int]context.CancelFunc{}}
+}
+
+// closeReasonKey resolves only the key of the close reason of gqlgen, which is not exported
+var closeReasonKey = transport.AppendCloseReason(context.Background(), "")
+
+const shutdownReason = "server shutting down"
+
+// shutdownContext reports the shutdown as the close reason once the connection was closed by Close, the close
+// reason of the parent, such as an expired token, is kept otherwise
+type shutdownContext struct {
+	context.Context
+	closed int32
+}
+
+func (c *shutdownContext) Value(key interface{}) interface{} {
+	if atomic.LoadInt32(&c.closed) == 1 && closeReasonKey.Value(key) != nil {
+		return shutdownReason
+	}
+	return c.Context.Value(key)
+}
+
+// Context returns the context of a websocket connection derived from ctx, it is cancelled by Close so that
+// the client gets a close frame
+func (s *Subscriptions) Context(ctx context.Context) context.Context {
+	ctx, cancel := context.WithCancel(ctx)
+	conn := &shutdownContext{Context: ctx}
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	if s.closed {
+		atomic.StoreInt32(&conn.closed, 1)
+		cancel()
+		return conn
+	}
+	id := s.next
+	s.next++
+	s.cancels[id] = func() {
+		atomic.StoreInt32(&conn.closed, 1)
+		cancel()
+	}
+	go func() {
+		<-ctx.Done()
+		s.mu.Lock()
+		delete(s.cancels, id)
+		s.mu.Unlock()
+	}()
+	return conn
+}
+
+// Close ends every websocket connection, and the connections opened afterwards
+func (s *Subscriptions) Close() {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	s.closed = true
+	for _, cancel := range s.cancels {
+		cancel()
+	}
+}
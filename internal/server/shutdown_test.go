@@ -0,0 +1,159 @@
+package server_test
+
+import (
+	"context"
+	"net/http"
+	"net/http/httptest"
+	"os"
+	"reflect"
+	"strings"
+	"syscall"
+	"testing"
+	"time"
+
+	graphql "go-template/gqlmodels"
+	"go-template/internal/server"
+	"go-template/internal/service/tracer"
+	"go-template/resolver"
+
+	"github.com/99designs/gqlgen/graphql/handler"
+	"github.com/99designs/gqlgen/graphql/handler/transport"
+	. "github.com/agiledragon/gomonkey/v2"
+	"github.com/gorilla/websocket"
+	"github.com/labstack/echo/v4"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestSubscriptions(t *testing.T) {
+	subscriptions := server.NewSubscriptions()
+	ctx := subscriptions.Context(context.Background())
+	other, cancel := context.WithCancel(context.Background())
+	subscriptions.Context(other)
+	cancel()
+
+	select {
+	case <-ctx.Done():
+		t.Fatal("context should not be cancelled before Close")
+	case <-time.After(50 * time.Millisecond):
+	}
+
+	subscriptions.Close()
+	select {
+	case <-ctx.Done():
+	case <-time.After(time.Second):
+		t.Fatal("context should be cancelled by Close")
+	}
+}
+
+func TestSubscriptions_CloseReason(t *testing.T) {
+	cases := map[string]struct {
+		close  func(subscriptions *server.Subscriptions, cancel context.CancelFunc)
+		reason string
+	}{
+		"Shutdown": {
+			close: func(subscriptions *server.Subscriptions, cancel context.CancelFunc) {
+				subscriptions.Close()
+			},
+			reason: "server shutting down",
+		},
+		"TokenExpired": {
+			close: func(subscriptions *server.Subscriptions, cancel context.CancelFunc) {
+				cancel()
+			},
+			reason: "token expired",
+		},
+	}
+	for name, tt := range cases {
+		t.Run(name, func(t *testing.T) {
+			subscriptions := server.NewSubscriptions()
+			var cancel context.CancelFunc
+			initialized := make(chan struct{})
+			graphqlHandler := handler.New(graphql.NewExecutableSchema(graphql.Config{
+				Resolvers: &resolver.Resolver{Observers: map[string]chan *graphql.User{}},
+			}))
+			graphqlHandler.AddTransport(transport.Websocket{
+				InitFunc: func(ctx context.Context, initPayload transport.InitPayload) (context.Context, error) {
+					ctx, cancel = context.WithCancel(transport.AppendCloseReason(ctx, "token expired"))
+					close(initialized)
+					return subscriptions.Context(ctx), nil
+				},
+			})
+			ts := httptest.NewServer(graphqlHandler)
+			defer ts.Close()
+
+			ws, _, err := websocket.DefaultDialer.Dial("ws"+strings.TrimPrefix(ts.URL, "http"), nil)
+			if err != nil {
+				t.Fatal(err)
+			}
+			defer ws.Close()
+			assert.Nil(t, ws.WriteMessage(websocket.TextMessage, []byte(`{"type":"connection_init"}`)))
+			<-initialized
+			tt.close(subscriptions, cancel)
+
+			for {
+				_, p, err := ws.ReadMessage()
+				if err != nil {
+					t.Fatal("connection closed without error")
+				}
+				if strings.Contains(string(p), "connection_error") {
+					assert.Contains(t, string(p), tt.reason)
+					return
+				}
+			}
+		})
+	}
+}
+
+func TestStart_SIGTERM(t *testing.T) {
+	cases := map[string]struct {
+		shutdownErr error
+	}{
+		"Success": {},
+		// the requests still in flight when the timeout expires do not keep the resources from being closed
+		"Failure_ShutdownTimeout": {shutdownErr: context.DeadlineExceeded},
+	}
+	for name, tt := range cases {
+		t.Run(name, func(t *testing.T) {
+			cfg := &server.Config{
+				Tracer:        &tracer.Config{Exporter: tracer.ExporterNone},
+				Subscriptions: server.NewSubscriptions(),
+			}
+			subscription := cfg.Subscriptions.Context(context.Background())
+			closed := []string{}
+			for _, name := range []string{"postgres", "redis"} {
+				name := name
+				cfg.Closers = append(cfg.Closers, server.Closer{Name: name, Close: func(ctx context.Context) error {
+					// the subscriptions end before the resources they use are closed
+					assert.Error(t, subscription.Err())
+					closed = append(closed, name)
+					return nil
+				}})
+			}
+			e := server.New(cfg)
+			patches := ApplyMethod(reflect.TypeOf(e), "StartServer", func(e *echo.Echo, s *http.Server) error {
+				return nil
+			})
+			defer patches.Reset()
+			if tt.shutdownErr != nil {
+				patches.ApplyMethod(reflect.TypeOf(e), "Shutdown", func(e *echo.Echo, ctx context.Context) error {
+					return tt.shutdownErr
+				})
+			}
+
+			go func() {
+				time.Sleep(200 * time.Millisecond)
+				proc, err := os.FindProcess(os.Getpid())
+				if err != nil {
+					t.Error(err)
+					return
+				}
+				if err := proc.Signal(syscall.SIGTERM); err != nil {
+					t.Error(err)
+				}
+			}()
+			server.Start(e, cfg)
+
+			assert.Equal(t, []string{"postgres", "redis"}, closed)
+		})
+	}
+}
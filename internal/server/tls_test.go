@@ -0,0 +1,220 @@
+package server
+
+import (
+	"crypto/ecdsa"
+	"crypto/elliptic"
+	"crypto/rand"
+	"crypto/tls"
+	"crypto/x509"
+	"crypto/x509/pkix"
+	"encoding/pem"
+	"math/big"
+	"net"
+	"net/http"
+	"net/http/httptest"
+	"os"
+	"os/signal"
+	"path/filepath"
+	"syscall"
+	"testing"
+	"time"
+
+	"go-template/internal/service/tracer"
+
+	"github.com/stretchr/testify/assert"
+	"golang.org/x/net/http2"
+)
+
+// writeCert writes a self-signed certificate for localhost with the given common name to certFile and keyFile
+func writeCert(t *testing.T, certFile string, keyFile string, commonName string) {
+	key, err := ecdsa.GenerateKey(elliptic.P256(), rand.Reader)
+	if err != nil {
+		t.Fatal(err)
+	}
+	template := &x509.Certificate{
+		SerialNumber: big.NewInt(time.Now().UnixNano()),
+		Subject:      pkix.Name{CommonName: commonName},
+		DNSNames:     []string{"localhost"},
+		IPAddresses:  []net.IP{net.ParseIP("127.0.0.1")},
+		NotBefore:    time.Now().Add(-time.Hour),
+		NotAfter:     time.Now().Add(time.Hour),
+	}
+	der, err := x509.CreateCertificate(rand.Reader, template, template, &key.PublicKey, key)
+	if err != nil {
+		t.Fatal(err)
+	}
+	keyDer, err := x509.MarshalECPrivateKey(key)
+	if err != nil {
+		t.Fatal(err)
+	}
+	assert.Nil(t, os.WriteFile(certFile, pem.EncodeToMemory(&pem.Block{Type: "CERTIFICATE", Bytes: der}), 0600))
+	assert.Nil(t, os.WriteFile(keyFile, pem.EncodeToMemory(&pem.Block{Type: "EC PRIVATE KEY", Bytes: keyDer}), 0600))
+}
+
+func commonName(t *testing.T, cert *tls.Certificate) string {
+	parsed, err := x509.ParseCertificate(cert.Certificate[0])
+	if err != nil {
+		t.Fatal(err)
+	}
+	return parsed.Subject.CommonName
+}
+
+func TestCertReloader(t *testing.T) {
+	interval := certCheckInterval
+	certCheckInterval = 0
+	defer func() { certCheckInterval = interval }()
+
+	dir := t.TempDir()
+	certFile, keyFile := filepath.Join(dir, "server.crt"), filepath.Join(dir, "server.key")
+	_, err := newCertReloader(certFile, keyFile)
+	assert.Error(t, err)
+
+	writeCert(t, certFile, keyFile, "first")
+	certs, err := newCertReloader(certFile, keyFile)
+	if err != nil {
+		t.Fatal(err)
+	}
+	cert, _ := certs.GetCertificate(nil)
+	assert.Equal(t, "first", commonName(t, cert))
+
+	// a half written pair keeps the previous certificate
+	later := time.Now().Add(time.Minute)
+	assert.Nil(t, os.WriteFile(certFile, []byte("not a certificate"), 0600))
+	assert.Nil(t, os.Chtimes(certFile, later, later))
+	cert, _ = certs.GetCertificate(nil)
+	assert.Equal(t, "first", commonName(t, cert))
+
+	writeCert(t, certFile, keyFile, "second")
+	later = later.Add(time.Minute)
+	assert.Nil(t, os.Chtimes(certFile, later, later))
+	cert, _ = certs.GetCertificate(nil)
+	assert.Equal(t, "second", commonName(t, cert))
+}
+
+func TestRedirectHandler(t *testing.T) {
+	cases := map[string]struct {
+		tlsAddr string
+		url     string
+		want    string
+	}{
+		"DefaultPort": {
+			tlsAddr: ":443",
+			url:     "http://example.com:8080/graphql?query=1",
+			want:    "https://example.com/graphql?query=1",
+		},
+		"OtherPort": {
+			tlsAddr: ":9443",
+			url:     "http://example.com/playground",
+			want:    "https://example.com:9443/playground",
+		},
+	}
+	for name, tt := range cases {
+		t.Run(name, func(t *testing.T) {
+			rec := httptest.NewRecorder()
+			redirectHandler(tt.tlsAddr).ServeHTTP(rec, httptest.NewRequest(http.MethodGet, tt.url, nil))
+			assert.Equal(t, http.StatusPermanentRedirect, rec.Code)
+			assert.Equal(t, tt.want, rec.Header().Get("Location"))
+		})
+	}
+}
+
+func freePort(t *testing.T) string {
+	l, err := net.Listen("tcp", "127.0.0.1:0")
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer l.Close()
+	return l.Addr().String()
+}
+
+// startServer starts the server and returns a function stopping it the way the orchestrator does
+func startServer(t *testing.T, cfg *Config) (stop func()) {
+	e := New(cfg)
+	done := make(chan struct{})
+	go func() {
+		Start(e, cfg)
+		close(done)
+	}()
+	return func() {
+		// Start waits for the signal once the listeners are started
+		time.Sleep(200 * time.Millisecond)
+		sigc := make(chan os.Signal, 1)
+		signal.Notify(sigc, syscall.SIGTERM)
+		defer signal.Stop(sigc)
+		proc, _ := os.FindProcess(os.Getpid())
+		assert.Nil(t, proc.Signal(syscall.SIGTERM))
+		<-done
+	}
+}
+
+func get(client *http.Client, url string) (res *http.Response, err error) {
+	for i := 0; i < 50; i++ {
+		if res, err = client.Get(url); err == nil {
+			res.Body.Close()
+			return res, nil
+		}
+		time.Sleep(20 * time.Millisecond)
+	}
+	return nil, err
+}
+
+func TestStart_TLS(t *testing.T) {
+	dir := t.TempDir()
+	certFile, keyFile := filepath.Join(dir, "server.crt"), filepath.Join(dir, "server.key")
+	writeCert(t, certFile, keyFile, "localhost")
+	cfg := &Config{
+		Port:         freePort(t),
+		RedirectPort: freePort(t),
+		TLSCertFile:  certFile,
+		TLSKeyFile:   keyFile,
+		Tracer:       &tracer.Config{Exporter: tracer.ExporterNone},
+	}
+	stop := startServer(t, cfg)
+	defer stop()
+
+	client := &http.Client{
+		Transport: &http.Transport{
+			TLSClientConfig:   &tls.Config{InsecureSkipVerify: true}, //nolint:gosec
+			ForceAttemptHTTP2: true,
+		},
+		CheckRedirect: func(req *http.Request, via []*http.Request) error {
+			return http.ErrUseLastResponse
+		},
+	}
+	res, err := get(client, "https://"+cfg.Port+"/healthz")
+	if err != nil {
+		t.Fatal(err)
+	}
+	assert.Equal(t, http.StatusOK, res.StatusCode)
+	assert.Equal(t, 2, res.ProtoMajor)
+
+	res, err = get(client, "http://"+cfg.RedirectPort+"/healthz")
+	if err != nil {
+		t.Fatal(err)
+	}
+	assert.Equal(t, http.StatusPermanentRedirect, res.StatusCode)
+}
+
+func TestStart_H2C(t *testing.T) {
+	cfg := &Config{
+		Port:   freePort(t),
+		H2C:    true,
+		Tracer: &tracer.Config{Exporter: tracer.ExporterNone},
+	}
+	stop := startServer(t, cfg)
+	defer stop()
+
+	// prior knowledge HTTP/2 over a plain connection, as the proxies of the cluster send it
+	client := &http.Client{Transport: &http2.Transport{
+		AllowHTTP: true,
+		DialTLS: func(network, addr string, cfg *tls.Config) (net.Conn, error) {
+			return net.Dial(network, addr)
+		},
+	}}
+	res, err := get(client, "http://"+cfg.Port+"/healthz")
+	if err != nil {
+		t.Fatal(err)
+	}
+	assert.Equal(t, http.StatusOK, res.StatusCode)
+	assert.Equal(t, 2, res.ProtoMajor)
+}
@@ -1,20 +1,25 @@
 package service
 
 import (
+	"context"
 	"crypto/sha1"
-	"os"
 
 	"go-template/internal/config"
 	"go-template/internal/jwt"
+	"go-template/internal/secrets"
 	"go-template/pkg/utl/secure"
 )
 
 // Secure returns new secure service
 func Secure(cfg *config.Configuration) *secure.Service {
-	return secure.New(cfg.App.MinPasswordStr, sha1.New())
+	return secure.New(cfg.App.MinPasswordStr, sha1.New)
 }
 
-// JWT returns new JWT service
-func JWT(cfg *config.Configuration) (jwt.Service, error) {
-	return jwt.New(cfg.JWT.SigningAlgorithm, os.Getenv("JWT_SECRET"), cfg.JWT.DurationMinutes, cfg.JWT.MinSecretLength)
+// JWT returns new JWT service signing with the JWT_SECRET of the secrets provider
+func JWT(cfg *config.Configuration, p secrets.Provider) (jwt.Service, error) {
+	secret, err := p.Get(context.Background(), "JWT_SECRET")
+	if err != nil {
+		return jwt.Service{}, err
+	}
+	return jwt.New(cfg.JWT.SigningAlgorithm, secret, cfg.JWT.DurationMinutes, cfg.JWT.MinSecretLength)
 }
@@ -2,14 +2,13 @@ package service_test
 
 import (
 	"log"
-	"os"
 	"testing"
 
 	"go-template/internal/config"
+	"go-template/internal/secrets"
 	"go-template/internal/service"
 	"go-template/testutls"
 
-	. "github.com/agiledragon/gomonkey/v2"
 	"github.com/stretchr/testify/assert"
 )
 
@@ -53,13 +52,9 @@ func TestJWT(t *testing.T) {
 			},
 		},
 	}
-	patches := ApplyFunc(os.Getenv, func(s string) string {
-		return testutls.MockJWTSecret
-	})
-	defer patches.Reset()
 	for _, tt := range tests {
 		t.Run(tt.name, func(t *testing.T) {
-			got, err := service.JWT(tt.args.cfg)
+			got, err := service.JWT(tt.args.cfg, secrets.Static{"JWT_SECRET": testutls.MockJWTSecret})
 			if err != nil {
 				log.Fatal(err)
 			}
@@ -0,0 +1,91 @@
+package metrics
+
+import (
+	"context"
+	"time"
+
+	"github.com/99designs/gqlgen/graphql"
+	"github.com/vektah/gqlparser/v2/ast"
+)
+
+// GraphQLExtension is a gqlgen handler extension recording operation and resolver metrics
+type GraphQLExtension struct{}
+
+var _ interface {
+	graphql.HandlerExtension
+	graphql.OperationInterceptor
+	graphql.ResponseInterceptor
+	graphql.FieldInterceptor
+} = GraphQLExtension{}
+
+// ExtensionName ...
+func (GraphQLExtension) ExtensionName() string {
+	return "Metrics"
+}
+
+// Validate ...
+func (GraphQLExtension) Validate(schema graphql.ExecutableSchema) error {
+	return nil
+}
+
+// InterceptOperation counts the operation and tracks subscriptions for as long as they are open
+func (GraphQLExtension) InterceptOperation(ctx context.Context, next graphql.OperationHandler) graphql.ResponseHandler {
+	name, opType := operationLabels(ctx)
+	GraphQLOperations.WithLabelValues(name, opType).Inc()
+	if opType == string(ast.Subscription) {
+		ActiveSubscriptions.Inc()
+		go func() {
+			<-ctx.Done()
+			ActiveSubscriptions.Dec()
+		}()
+	}
+	return next(ctx)
+}
+
+// InterceptResponse records the duration and errors of each response
+func (GraphQLExtension) InterceptResponse(ctx context.Context, next graphql.ResponseHandler) *graphql.Response {
+	res := next(ctx)
+	if res == nil {
+		return res
+	}
+	name, opType := operationLabels(ctx)
+	if len(res.Errors) > 0 {
+		GraphQLErrors.WithLabelValues(name, opType).Inc()
+	}
+	// subscriptions produce a response per event, their duration is not meaningful
+	if opType != string(ast.Subscription) && graphql.HasOperationContext(ctx) {
+		start := graphql.GetOperationContext(ctx).Stats.OperationStart
+		GraphQLDuration.WithLabelValues(name, opType).Observe(time.Since(start).Seconds())
+	}
+	return res
+}
+
+// InterceptField records the latency of fields backed by a resolver
+func (GraphQLExtension) InterceptField(ctx context.Context, next graphql.Resolver) (interface{}, error) {
+	fc := graphql.GetFieldContext(ctx)
+	if fc == nil || !fc.IsResolver {
+		return next(ctx)
+	}
+	start := time.Now()
+	res, err := next(ctx)
+	ResolverDuration.WithLabelValues(fc.Object, fc.Field.Name).Observe(time.Since(start).Seconds())
+	return res, err
+}
+
+func operationLabels(ctx context.Context) (name string, opType string) {
+	name, opType = "anonymous", "unknown"
+	if !graphql.HasOperationContext(ctx) {
+		return name, opType
+	}
+	oc := graphql.GetOperationContext(ctx)
+	if oc.OperationName != "" {
+		name = oc.OperationName
+	}
+	if oc.Operation != nil {
+		opType = string(oc.Operation.Operation)
+		if name == "anonymous" && oc.Operation.Name != "" {
+			name = oc.Operation.Name
+		}
+	}
+	return name, opType
+}
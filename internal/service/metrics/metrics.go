@@ -0,0 +1,131 @@
+package metrics
+
+import (
+	"database/sql"
+	"net/http"
+	"sync"
+
+	"github.com/prometheus/client_golang/prometheus"
+	"github.com/prometheus/client_golang/prometheus/collectors"
+	"github.com/prometheus/client_golang/prometheus/promauto"
+	"github.com/prometheus/client_golang/prometheus/promhttp"
+)
+
+const namespace = "go_template"
+
+// Registry holds every collector exposed on the metrics endpoint
+var Registry = prometheus.NewRegistry()
+
+var factory = promauto.With(Registry)
+
+var (
+	// HTTPRequests counts http requests by method, route and status code
+	HTTPRequests = factory.NewCounterVec(prometheus.CounterOpts{
+		Namespace: namespace,
+		Subsystem: "http",
+		Name:      "requests_total",
+		Help:      "Total number of HTTP requests.",
+	}, []string{"method", "route", "status"})
+
+	// HTTPDuration observes the latency of http requests by method and route
+	HTTPDuration = factory.NewHistogramVec(prometheus.HistogramOpts{
+		Namespace: namespace,
+		Subsystem: "http",
+		Name:      "request_duration_seconds",
+		Help:      "Latency of HTTP requests.",
+		Buckets:   prometheus.DefBuckets,
+	}, []string{"method", "route"})
+
+	// GraphQLOperations counts graphql operations by name and type
+	GraphQLOperations = factory.NewCounterVec(prometheus.CounterOpts{
+		Namespace: namespace,
+		Subsystem: "graphql",
+		Name:      "operations_total",
+		Help:      "Total number of GraphQL operations.",
+	}, []string{"operation", "type"})
+
+	// GraphQLErrors counts graphql responses that carried errors by operation name and type
+	GraphQLErrors = factory.NewCounterVec(prometheus.CounterOpts{
+		Namespace: namespace,
+		Subsystem: "graphql",
+		Name:      "errors_total",
+		Help:      "Total number of GraphQL responses containing errors.",
+	}, []string{"operation", "type"})
+
+	// GraphQLDuration observes the duration of graphql operations by name and type
+	GraphQLDuration = factory.NewHistogramVec(prometheus.HistogramOpts{
+		Namespace: namespace,
+		Subsystem: "graphql",
+		Name:      "operation_duration_seconds",
+		Help:      "Duration of GraphQL operations.",
+		Buckets:   prometheus.DefBuckets,
+	}, []string{"operation", "type"})
+
+	// ResolverDuration observes the latency of resolver fields by object and field
+	ResolverDuration = factory.NewHistogramVec(prometheus.HistogramOpts{
+		Namespace: namespace,
+		Subsystem: "graphql",
+		Name:      "resolver_duration_seconds",
+		Help:      "Latency of GraphQL field resolvers.",
+		Buckets:   []float64{.0005, .001, .005, .01, .025, .05, .1, .25, .5, 1, 2.5},
+	}, []string{"object", "field"})
+
+	// ActiveSubscriptions tracks the number of open graphql subscriptions
+	ActiveSubscriptions = factory.NewGauge(prometheus.GaugeOpts{
+		Namespace: namespace,
+		Subsystem: "graphql",
+		Name:      "active_subscriptions",
+		Help:      "Number of active GraphQL subscriptions.",
+	})
+
+	// CacheRequests counts redis cache lookups by entity and result (hit or miss)
+	CacheRequests = factory.NewCounterVec(prometheus.CounterOpts{
+		Namespace: namespace,
+		Subsystem: "cache",
+		Name:      "requests_total",
+		Help:      "Total number of cache lookups.",
+	}, []string{"entity", "result"})
+
+	// RateLimitRejections counts requests rejected by the throttler by query path
+	RateLimitRejections = factory.NewCounterVec(prometheus.CounterOpts{
+		Namespace: namespace,
+		Subsystem: "throttle",
+		Name:      "rejections_total",
+		Help:      "Total number of requests rejected by the rate limiter.",
+	}, []string{"path"})
+)
+
+const (
+	// CacheHit is the result label for a cache hit
+	CacheHit = "hit"
+	// CacheMiss is the result label for a cache miss
+	CacheMiss = "miss"
+)
+
+var (
+	dbCollectorMu sync.Mutex
+	dbCollector   prometheus.Collector
+)
+
+func init() {
+	Registry.MustRegister(
+		collectors.NewGoCollector(),
+		collectors.NewProcessCollector(collectors.ProcessCollectorOpts{}),
+	)
+}
+
+// RegisterDBStats exposes the sql.DBStats of the given pool, replacing any previously registered pool
+func RegisterDBStats(db *sql.DB) {
+	dbCollectorMu.Lock()
+	defer dbCollectorMu.Unlock()
+	if dbCollector != nil {
+		Registry.Unregister(dbCollector)
+	}
+	dbCollector = collectors.NewDBStatsCollector(db, "postgres")
+	Registry.MustRegister(dbCollector)
+}
+
+// Handler returns the http handler serving the metrics in the prometheus exposition format
+func Handler() http.Handler {
+	return promhttp.HandlerFor(Registry, promhttp.HandlerOpts{})
+}
@@ -0,0 +1,142 @@
+package metrics_test
+
+import (
+	"context"
+	"database/sql"
+	"fmt"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+
+	"go-template/internal/service/metrics"
+
+	"github.com/99designs/gqlgen/graphql"
+	"github.com/labstack/echo/v4"
+	"github.com/prometheus/client_golang/prometheus/testutil"
+	"github.com/stretchr/testify/assert"
+	"github.com/vektah/gqlparser/v2/ast"
+	"github.com/vektah/gqlparser/v2/gqlerror"
+)
+
+func TestMiddleware(t *testing.T) {
+	cases := map[string]struct {
+		route   string
+		handler echo.HandlerFunc
+		status  string
+	}{
+		"Success": {
+			route: "/ok",
+			handler: func(c echo.Context) error {
+				return c.String(http.StatusOK, "ok")
+			},
+			status: "200",
+		},
+		"Failure_HTTPError": {
+			route: "/forbidden",
+			handler: func(c echo.Context) error {
+				return echo.ErrForbidden
+			},
+			status: "403",
+		},
+		"Failure_Error": {
+			route: "/error",
+			handler: func(c echo.Context) error {
+				return fmt.Errorf("some error")
+			},
+			status: "500",
+		},
+	}
+	for name, tt := range cases {
+		t.Run(name, func(t *testing.T) {
+			e := echo.New()
+			e.Use(metrics.Middleware())
+			e.GET(tt.route, tt.handler)
+
+			before := testutil.ToFloat64(metrics.HTTPRequests.WithLabelValues(http.MethodGet, tt.route, tt.status))
+			req := httptest.NewRequest(http.MethodGet, tt.route, nil)
+			e.ServeHTTP(httptest.NewRecorder(), req)
+			after := testutil.ToFloat64(metrics.HTTPRequests.WithLabelValues(http.MethodGet, tt.route, tt.status))
+
+			assert.Equal(t, before+1, after)
+		})
+	}
+}
+
+func operationContext(name string, op ast.Operation) context.Context {
+	oc := &graphql.OperationContext{
+		OperationName: name,
+		Operation:     &ast.OperationDefinition{Operation: op},
+	}
+	return graphql.WithOperationContext(context.Background(), oc)
+}
+
+func TestGraphQLExtension(t *testing.T) {
+	ext := metrics.GraphQLExtension{}
+	cases := map[string]struct {
+		name     string
+		op       ast.Operation
+		response *graphql.Response
+		errors   float64
+	}{
+		"Success": {
+			name:     "me",
+			op:       ast.Query,
+			response: &graphql.Response{},
+		},
+		"Failure_ResponseWithErrors": {
+			name:     "login",
+			op:       ast.Mutation,
+			response: &graphql.Response{Errors: gqlerror.List{gqlerror.Errorf("error")}},
+			errors:   1,
+		},
+	}
+	for name, tt := range cases {
+		t.Run(name, func(t *testing.T) {
+			ctx := operationContext(tt.name, tt.op)
+			opType := string(tt.op)
+			ops := testutil.ToFloat64(metrics.GraphQLOperations.WithLabelValues(tt.name, opType))
+			errs := testutil.ToFloat64(metrics.GraphQLErrors.WithLabelValues(tt.name, opType))
+
+			handler := ext.InterceptOperation(ctx, func(ctx context.Context) graphql.ResponseHandler {
+				return func(ctx context.Context) *graphql.Response {
+					return tt.response
+				}
+			})
+			res := ext.InterceptResponse(ctx, handler)
+
+			assert.Equal(t, tt.response, res)
+			assert.Equal(t, ops+1, testutil.ToFloat64(metrics.GraphQLOperations.WithLabelValues(tt.name, opType)))
+			assert.Equal(t, errs+tt.errors, testutil.ToFloat64(metrics.GraphQLErrors.WithLabelValues(tt.name, opType)))
+		})
+	}
+}
+
+func TestGraphQLExtension_Subscription(t *testing.T) {
+	ext := metrics.GraphQLExtension{}
+	ctx, cancel := context.WithCancel(operationContext("userNotification", ast.Subscription))
+	before := testutil.ToFloat64(metrics.ActiveSubscriptions)
+
+	ext.InterceptOperation(ctx, func(ctx context.Context) graphql.ResponseHandler {
+		return nil
+	})
+	assert.Equal(t, before+1, testutil.ToFloat64(metrics.ActiveSubscriptions))
+
+	cancel()
+	assert.Eventually(t, func() bool {
+		return testutil.ToFloat64(metrics.ActiveSubscriptions) == before
+	}, time.Second, 10*time.Millisecond)
+}
+
+func TestHandler(t *testing.T) {
+	metrics.RegisterDBStats(&sql.DB{})
+	metrics.RegisterDBStats(&sql.DB{})
+
+	res := httptest.NewRecorder()
+	metrics.Handler().ServeHTTP(res, httptest.NewRequest(http.MethodGet, "/metrics", nil))
+	body, _ := io.ReadAll(res.Body)
+
+	assert.Equal(t, http.StatusOK, res.Code)
+	assert.Contains(t, string(body), "go_goroutines")
+}
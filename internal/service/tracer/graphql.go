@@ -0,0 +1,150 @@
+package tracer
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+
+	"github.com/99designs/gqlgen/complexity"
+	"github.com/99designs/gqlgen/graphql"
+	"go.opentelemetry.io/otel"
+	"go.opentelemetry.io/otel/attribute"
+	"go.opentelemetry.io/otel/codes"
+	"go.opentelemetry.io/otel/trace"
+)
+
+const tracerName = "go-template/graphql"
+
+// GraphQLExtension is a gqlgen handler extension creating a span per operation, named by the operation,
+// and a child span per resolver
+type GraphQLExtension struct {
+	schema graphql.ExecutableSchema
+}
+
+var _ interface {
+	graphql.HandlerExtension
+	graphql.OperationInterceptor
+	graphql.FieldInterceptor
+} = &GraphQLExtension{}
+
+// ExtensionName ...
+func (*GraphQLExtension) ExtensionName() string {
+	return "Tracing"
+}
+
+// Validate keeps a reference to the schema, it is needed to compute the complexity of operations
+func (t *GraphQLExtension) Validate(schema graphql.ExecutableSchema) error {
+	t.schema = schema
+	return nil
+}
+
+// InterceptOperation starts the operation span and ends it once the operation has responded. Subscription
+// spans are ended when the subscription is closed.
+func (t *GraphQLExtension) InterceptOperation(
+	ctx context.Context,
+	next graphql.OperationHandler,
+) graphql.ResponseHandler {
+	oc := graphql.GetOperationContext(ctx)
+	name, opType := oc.OperationName, "unknown"
+	if oc.Operation != nil {
+		opType = string(oc.Operation.Operation)
+		if name == "" {
+			name = oc.Operation.Name
+		}
+	}
+	if name == "" {
+		name = "anonymous"
+	}
+
+	ctx, span := otel.Tracer(tracerName).Start(ctx, fmt.Sprintf("%s %s", opType, name),
+		trace.WithSpanKind(trace.SpanKindServer),
+		trace.WithAttributes(
+			attribute.String("graphql.operation.name", name),
+			attribute.String("graphql.operation.type", opType),
+			attribute.String("graphql.variables.shape", VariablesShape(oc.Variables)),
+		),
+	)
+	if t.schema != nil && oc.Operation != nil {
+		span.SetAttributes(attribute.Int("graphql.complexity", complexity.Calculate(t.schema, oc.Operation, oc.Variables)))
+	}
+
+	responses := next(ctx)
+	return func(ctx context.Context) *graphql.Response {
+		res := responses(ctx)
+		if res == nil {
+			span.End()
+			return res
+		}
+		if len(res.Errors) > 0 {
+			span.SetAttributes(attribute.Int("graphql.errors.count", len(res.Errors)))
+			span.SetStatus(codes.Error, res.Errors.Error())
+			for _, err := range res.Errors {
+				span.RecordError(err)
+			}
+		}
+		if opType != "subscription" {
+			span.End()
+		}
+		return res
+	}
+}
+
+// InterceptField wraps fields backed by a resolver in a span
+func (t *GraphQLExtension) InterceptField(ctx context.Context, next graphql.Resolver) (interface{}, error) {
+	fc := graphql.GetFieldContext(ctx)
+	if fc == nil || !fc.IsResolver {
+		return next(ctx)
+	}
+
+	ctx, span := otel.Tracer(tracerName).Start(ctx, fmt.Sprintf("%s.%s", fc.Object, fc.Field.Name),
+		trace.WithAttributes(
+			attribute.String("graphql.field.path", fc.Path().String()),
+			attribute.String("graphql.field.object", fc.Object),
+			attribute.String("graphql.field.name", fc.Field.Name),
+		),
+	)
+	defer span.End()
+
+	res, err := next(ctx)
+	if err != nil {
+		span.RecordError(err)
+		span.SetStatus(codes.Error, err.Error())
+	}
+	return res, err
+}
+
+// VariablesShape describes the variables of an operation by their keys and types, leaving out the values
+func VariablesShape(vars map[string]interface{}) string {
+	if len(vars) == 0 {
+		return "{}"
+	}
+	b, err := json.Marshal(shape(vars))
+	if err != nil {
+		return "{}"
+	}
+	return string(b)
+}
+
+func shape(v interface{}) interface{} {
+	switch val := v.(type) {
+	case map[string]interface{}:
+		m := make(map[string]interface{}, len(val))
+		for k, item := range val {
+			m[k] = shape(item)
+		}
+		return m
+	case []interface{}:
+		if len(val) == 0 {
+			return []interface{}{}
+		}
+		return []interface{}{shape(val[0])}
+	case string:
+		return "string"
+	case bool:
+		return "boolean"
+	case nil:
+		return "null"
+	default:
+		return "number"
+	}
+}
@@ -2,12 +2,13 @@ package tracer
 
 import (
 	"context"
-	"log"
+	"fmt"
 	"os"
 
 	"go.opentelemetry.io/otel"
 	"go.opentelemetry.io/otel/exporters/otlp/otlptrace"
 	"go.opentelemetry.io/otel/exporters/otlp/otlptrace/otlptracegrpc"
+	"go.opentelemetry.io/otel/exporters/stdout/stdouttrace"
 	"go.opentelemetry.io/otel/propagation"
 	semconv "go.opentelemetry.io/otel/semconv/v1.10.0"
 	"google.golang.org/grpc/credentials"
@@ -17,47 +18,82 @@ import (
 	sdktrace "go.opentelemetry.io/otel/sdk/trace"
 )
 
-func Init() *sdktrace.TracerProvider {
-	var (
-		signozToken  = os.Getenv("SIGNOZ_ACCESS_TOKEN")
-		collectorURL = os.Getenv("OTEL_EXPORTER_OTLP_ENDPOINT")
-		insecure     = os.Getenv("INSECURE_MODE")
-	)
-	headers := map[string]string{
-		"signoz-access-token": signozToken,
-	}
+const (
+	// ExporterOTLP sends spans to an OTLP collector over grpc
+	ExporterOTLP = "otlp"
+	// ExporterStdout writes spans to stdout
+	ExporterStdout = "stdout"
+	// ExporterNone records spans without exporting them
+	ExporterNone = "none"
+)
 
-	secureOption := otlptracegrpc.WithTLSCredentials(
-		credentials.NewClientTLSFromCert(nil, ""),
-	) // config can be passed to configure TLS
-	if len(insecure) > 0 {
-		secureOption = otlptracegrpc.WithInsecure()
-	}
+// Config represents tracer specific config
+type Config struct {
+	ServiceName string
+	// Exporter is one of otlp, stdout or none. When empty otlp is used if an endpoint is configured
+	Exporter    string
+	Endpoint    string
+	Insecure    bool
+	Headers     map[string]string
+	SampleRatio float64
+}
 
-	exporter, err := otlptrace.New(
-		context.Background(),
-		otlptracegrpc.NewClient(
-			secureOption,
-			otlptracegrpc.WithEndpoint(collectorURL),
-			otlptracegrpc.WithHeaders(headers),
+// Init registers a global tracer provider. When the exporter cannot be built an error is returned
+// alongside a provider that records spans without exporting them, so that callers can keep running.
+func Init(cfg *Config) (*sdktrace.TracerProvider, error) {
+	opts := []sdktrace.TracerProviderOption{
+		// sample a ratio of new traces and follow the decision of the parent for propagated ones
+		sdktrace.WithSampler(sdktrace.ParentBased(sdktrace.TraceIDRatioBased(cfg.SampleRatio))),
+		sdktrace.WithResource(
+			resource.NewWithAttributes(semconv.SchemaURL, semconv.ServiceNameKey.String(cfg.ServiceName)),
 		),
-	)
-	if err != nil {
-		log.Fatal(err)
 	}
 
-	// For the demonstration, use sdktrace.AlwaysSample sampler to sample all traces.
-	// In a production application, use sdktrace.ProbabilitySampler with a desired probability.
-	traceProvider := sdktrace.NewTracerProvider(
-		sdktrace.WithSampler(sdktrace.AlwaysSample()),
-		sdktrace.WithSpanProcessor(sdktrace.NewBatchSpanProcessor(exporter)),
-		sdktrace.WithResource(
-			resource.NewWithAttributes(semconv.SchemaURL, semconv.ServiceNameKey.String(os.Getenv("SERVICE_NAME"))),
-		),
-	)
+	exporter, err := newExporter(cfg)
+	if err == nil && exporter != nil {
+		opts = append(opts, sdktrace.WithBatcher(exporter))
+	}
+	traceProvider := sdktrace.NewTracerProvider(opts...)
 
 	otel.SetTracerProvider(traceProvider)
 	otel.SetTextMapPropagator(propagation.NewCompositeTextMapPropagator(propagation.TraceContext{}, propagation.Baggage{}))
 
-	return traceProvider
+	return traceProvider, err
+}
+
+func newExporter(cfg *Config) (sdktrace.SpanExporter, error) {
+	exporter := cfg.Exporter
+	if exporter == "" {
+		exporter = ExporterNone
+		if cfg.Endpoint != "" {
+			exporter = ExporterOTLP
+		}
+	}
+
+	switch exporter {
+	case ExporterNone:
+		return nil, nil
+	case ExporterStdout:
+		return stdouttrace.New(stdouttrace.WithWriter(os.Stdout))
+	case ExporterOTLP:
+		if cfg.Endpoint == "" {
+			return nil, fmt.Errorf("tracing exporter %s requires an endpoint", exporter)
+		}
+		secureOption := otlptracegrpc.WithTLSCredentials(
+			credentials.NewClientTLSFromCert(nil, ""),
+		) // config can be passed to configure TLS
+		if cfg.Insecure {
+			secureOption = otlptracegrpc.WithInsecure()
+		}
+		return otlptrace.New(
+			context.Background(),
+			otlptracegrpc.NewClient(
+				secureOption,
+				otlptracegrpc.WithEndpoint(cfg.Endpoint),
+				otlptracegrpc.WithHeaders(cfg.Headers),
+			),
+		)
+	default:
+		return nil, fmt.Errorf("unknown tracing exporter: %s", exporter)
+	}
 }
@@ -0,0 +1,133 @@
+package tracer_test
+
+import (
+	"context"
+	"fmt"
+	"testing"
+
+	"go-template/internal/service/tracer"
+
+	"github.com/99designs/gqlgen/graphql"
+	"github.com/stretchr/testify/assert"
+	"github.com/vektah/gqlparser/v2/ast"
+	"github.com/vektah/gqlparser/v2/gqlerror"
+	"go.opentelemetry.io/otel"
+	"go.opentelemetry.io/otel/codes"
+	sdktrace "go.opentelemetry.io/otel/sdk/trace"
+	"go.opentelemetry.io/otel/sdk/trace/tracetest"
+)
+
+func TestInit(t *testing.T) {
+	cases := map[string]struct {
+		cfg     *tracer.Config
+		wantErr bool
+	}{
+		"Success_NoCollector": {
+			cfg: &tracer.Config{SampleRatio: 1},
+		},
+		"Success_Stdout": {
+			cfg: &tracer.Config{Exporter: tracer.ExporterStdout, SampleRatio: 0.5},
+		},
+		"Success_OTLP": {
+			cfg: &tracer.Config{Endpoint: "localhost:4317", Insecure: true, SampleRatio: 1},
+		},
+		"Failure_OTLPWithoutEndpoint": {
+			cfg:     &tracer.Config{Exporter: tracer.ExporterOTLP},
+			wantErr: true,
+		},
+		"Failure_UnknownExporter": {
+			cfg:     &tracer.Config{Exporter: "zipkin"},
+			wantErr: true,
+		},
+	}
+	for name, tt := range cases {
+		t.Run(name, func(t *testing.T) {
+			tp, err := tracer.Init(tt.cfg)
+			assert.Equal(t, tt.wantErr, err != nil)
+			// a provider is always returned so that the server can start
+			assert.NotNil(t, tp)
+		})
+	}
+}
+
+func TestVariablesShape(t *testing.T) {
+	cases := map[string]struct {
+		vars map[string]interface{}
+		want string
+	}{
+		"Success_Empty": {
+			want: "{}",
+		},
+		"Success_Nested": {
+			vars: map[string]interface{}{
+				"password": "secret",
+				"input": map[string]interface{}{
+					"active": true,
+					"roleId": 1,
+					"tags":   []interface{}{"a", "b"},
+					"mobile": nil,
+				},
+			},
+			want: `{"input":{"active":"boolean","mobile":"null","roleId":"number","tags":["string"]},"password":"string"}`,
+		},
+	}
+	for name, tt := range cases {
+		t.Run(name, func(t *testing.T) {
+			assert.Equal(t, tt.want, tracer.VariablesShape(tt.vars))
+		})
+	}
+}
+
+func TestGraphQLExtension(t *testing.T) {
+	recorder := tracetest.NewSpanRecorder()
+	otel.SetTracerProvider(sdktrace.NewTracerProvider(sdktrace.WithSpanProcessor(recorder)))
+
+	cases := map[string]struct {
+		name     string
+		response *graphql.Response
+		fieldErr error
+		status   codes.Code
+	}{
+		"Success": {
+			name:     "me",
+			response: &graphql.Response{},
+			status:   codes.Unset,
+		},
+		"Failure_ResolverError": {
+			name:     "login",
+			response: &graphql.Response{Errors: gqlerror.List{gqlerror.Errorf("invalid credentials")}},
+			fieldErr: fmt.Errorf("invalid credentials"),
+			status:   codes.Error,
+		},
+	}
+	for name, tt := range cases {
+		t.Run(name, func(t *testing.T) {
+			ext := &tracer.GraphQLExtension{}
+			ctx := graphql.WithOperationContext(context.Background(), &graphql.OperationContext{
+				OperationName: tt.name,
+				Operation:     &ast.OperationDefinition{Operation: ast.Query},
+			})
+
+			handler := ext.InterceptOperation(ctx, func(ctx context.Context) graphql.ResponseHandler {
+				fctx := graphql.WithFieldContext(ctx, &graphql.FieldContext{
+					Object:     "Query",
+					Field:      graphql.CollectedField{Field: &ast.Field{Name: tt.name, Alias: tt.name}},
+					IsResolver: true,
+				})
+				_, err := ext.InterceptField(fctx, func(ctx context.Context) (interface{}, error) {
+					return nil, tt.fieldErr
+				})
+				assert.Equal(t, tt.fieldErr, err)
+				return graphql.OneShot(tt.response)
+			})
+			assert.Equal(t, tt.response, handler(ctx))
+
+			spans := recorder.Ended()
+			field, operation := spans[len(spans)-2], spans[len(spans)-1]
+			assert.Equal(t, "Query."+tt.name, field.Name())
+			assert.Equal(t, "query "+tt.name, operation.Name())
+			assert.Equal(t, operation.SpanContext().SpanID(), field.Parent().SpanID())
+			assert.Equal(t, tt.status, operation.Status().Code)
+		})
+	}
+}
@@ -40,6 +40,7 @@ type User struct {
 	CreatedAt          null.Time   `boil:"created_at" json:"created_at,omitempty" toml:"created_at" yaml:"created_at,omitempty"`
 	UpdatedAt          null.Time   `boil:"updated_at" json:"updated_at,omitempty" toml:"updated_at" yaml:"updated_at,omitempty"`
 	DeletedAt          null.Time   `boil:"deleted_at" json:"deleted_at,omitempty" toml:"deleted_at" yaml:"deleted_at,omitempty"`
+	Version            int         `boil:"version" json:"version" toml:"version" yaml:"version"`
 
 	R *userR `boil:"-" json:"-" toml:"-" yaml:"-"`
 	L userL  `boil:"-" json:"-" toml:"-" yaml:"-"`
@@ -62,6 +63,7 @@ var UserColumns = struct {
 	CreatedAt          string
 	UpdatedAt          string
 	DeletedAt          string
+	Version            string
 }{
 	ID:                 "id",
 	FirstName:          "first_name",
@@ -79,6 +81,7 @@ var UserColumns = struct {
 	CreatedAt:          "created_at",
 	UpdatedAt:          "updated_at",
 	DeletedAt:          "deleted_at",
+	Version:            "version",
 }
 
 var UserTableColumns = struct {
@@ -98,6 +101,7 @@ var UserTableColumns = struct {
 	CreatedAt          string
 	UpdatedAt          string
 	DeletedAt          string
+	Version            string
 }{
 	ID:                 "users.id",
 	FirstName:          "users.first_name",
@@ -115,6 +119,7 @@ var UserTableColumns = struct {
 	CreatedAt:          "users.created_at",
 	UpdatedAt:          "users.updated_at",
 	DeletedAt:          "users.deleted_at",
+	Version:            "users.version",
 }
 
 // Generated where
@@ -208,6 +213,7 @@ var UserWhere = struct {
 	CreatedAt          whereHelpernull_Time
 	UpdatedAt          whereHelpernull_Time
 	DeletedAt          whereHelpernull_Time
+	Version            whereHelperint
 }{
 	ID:                 whereHelperint{field: "\"users\".\"id\""},
 	FirstName:          whereHelpernull_String{field: "\"users\".\"first_name\""},
@@ -225,6 +231,7 @@ var UserWhere = struct {
 	CreatedAt:          whereHelpernull_Time{field: "\"users\".\"created_at\""},
 	UpdatedAt:          whereHelpernull_Time{field: "\"users\".\"updated_at\""},
 	DeletedAt:          whereHelpernull_Time{field: "\"users\".\"deleted_at\""},
+	Version:            whereHelperint{field: "\"users\".\"version\""},
 }
 
 // UserRels is where relationship names are stored.
@@ -255,9 +262,9 @@ func (r *userR) GetRole() *Role {
 type userL struct{}
 
 var (
-	userAllColumns            = []string{"id", "first_name", "last_name", "username", "password", "email", "mobile", "address", "active", "last_login", "last_password_change", "token", "role_id", "created_at", "updated_at", "deleted_at"}
+	userAllColumns            = []string{"id", "first_name", "last_name", "username", "password", "email", "mobile", "address", "active", "last_login", "last_password_change", "token", "role_id", "created_at", "updated_at", "deleted_at", "version"}
 	userColumnsWithoutDefault = []string{}
-	userColumnsWithDefault    = []string{"id", "first_name", "last_name", "username", "password", "email", "mobile", "address", "active", "last_login", "last_password_change", "token", "role_id", "created_at", "updated_at", "deleted_at"}
+	userColumnsWithDefault    = []string{"id", "first_name", "last_name", "username", "password", "email", "mobile", "address", "active", "last_login", "last_password_change", "token", "role_id", "created_at", "updated_at", "deleted_at", "version"}
 	userPrimaryKeyColumns     = []string{"id"}
 	userGeneratedColumns      = []string{}
 )
@@ -898,7 +905,7 @@ func (o *User) Upsert(ctx context.Context, exec boil.ContextExecutor, updateOnCo
 	}
 	if len(cache.retMapping) != 0 {
 		err = exec.QueryRowContext(ctx, cache.query, vals...).Scan(returns...)
-		if err == sql.ErrNoRows {
+		if errors.Is(err, sql.ErrNoRows) {
 			err = nil // Postgres doesn't return anything when there's no update
 		}
 	} else {
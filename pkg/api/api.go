@@ -4,16 +4,35 @@ package api
 import (
 	"context"
 	"net/http"
+	"net/url"
 	"os"
+	"strconv"
+	"strings"
 	"time"
 
+	"go-template/daos"
 	graphql "go-template/gqlmodels"
+	"go-template/internal/audit"
 	"go-template/internal/config"
-	"go-template/internal/jwt"
+	"go-template/internal/controller"
+	"go-template/internal/featureflags"
 	authMw "go-template/internal/middleware/auth"
+	"go-template/internal/middleware/bodylog"
+	"go-template/internal/middleware/readreplica"
+	"go-template/internal/middleware/secure"
+	"go-template/internal/middleware/timeout"
+	"go-template/internal/migrations"
 	"go-template/internal/postgres"
+	"go-template/internal/secrets"
 	"go-template/internal/server"
+	"go-template/internal/service"
+	"go-template/internal/service/metrics"
+	"go-template/internal/service/tracer"
+	"go-template/pkg/utl/clock"
+	"go-template/pkg/utl/mailer"
+	"go-template/pkg/utl/rediscache"
 	throttle "go-template/pkg/utl/throttle"
+	"go-template/pkg/utl/zaplog"
 	"go-template/resolver"
 
 	graphql2 "github.com/99designs/gqlgen/graphql"
@@ -24,30 +43,192 @@ import (
 	"github.com/99designs/gqlgen/graphql/playground"
 	"github.com/gorilla/websocket"
 	"github.com/labstack/echo/v4"
+	"github.com/labstack/echo/v4/middleware"
 	_ "github.com/lib/pq" // here
+	"go.uber.org/zap"
 
 	"github.com/volatiletech/sqlboiler/v4/boil"
 )
 
+// configPollInterval is the interval the configuration files are checked for changes on
+const configPollInterval = 5 * time.Second
+
+// auditPruneInterval is the interval the audit events older than the retention are deleted on
+const auditPruneInterval = time.Hour
+
+// playgroundPath serves the GraphQL playground, which gets its own Content-Security-Policy
+const playgroundPath = "/playground"
+
 // Start starts the API service
 func Start(cfg *config.Configuration) (*echo.Echo, error) {
-	db, err := postgres.Connect()
+	if err := zaplog.Configure(cfg.Log.Level, cfg.Log.Format); err != nil {
+		return nil, err
+	}
+
+	secretsProvider, err := secrets.New(&secrets.Config{
+		Provider: cfg.Secrets.Provider,
+		Dir:      cfg.Secrets.Dir,
+		JSONEnv:  cfg.Secrets.JSONEnv,
+		JSONFile: cfg.Secrets.JSONFile,
+		Refresh:  time.Duration(cfg.Secrets.RefreshSeconds) * time.Second,
+	})
+	if err != nil {
+		return nil, err
+	}
+	if refreshing, ok := secretsProvider.(*secrets.Refreshing); ok {
+		ctx, cancel := context.WithCancel(context.Background())
+		defer cancel()
+		go refreshing.Run(ctx)
+	}
+
+	dbCfg := &postgres.Config{
+		MaxOpenConns:     cfg.DB.MaxOpenConns,
+		MaxIdleConns:     cfg.DB.MaxIdleConns,
+		ConnMaxLifetime:  time.Duration(cfg.DB.MaxLifetimeSeconds) * time.Second,
+		StatementTimeout: time.Duration(cfg.DB.Timeout) * time.Second,
+	}
+	if cfg.DB.LogQueries {
+		dbCfg.SlowQueryThreshold = time.Duration(cfg.DB.SlowQueryMillis) * time.Millisecond
+	}
+	db, err := postgres.Open(secretsProvider, dbCfg)
 	if err != nil {
 		return nil, err
 	}
 
 	boil.SetDB(db)
+	metrics.RegisterDBStats(db)
+
+	// the queries are served by the read replicas, when there are any
+	var replicas *daos.ReplicaSet
+	if len(cfg.DB.ReplicaDSNs) > 0 {
+		pools, err := postgres.OpenReplicas(cfg.DB.ReplicaDSNs, dbCfg)
+		if err != nil {
+			return nil, err
+		}
+		// the write windows are kept in redis, the next query of a user may be served by another instance
+		window := time.Duration(cfg.DB.WriteWindowSeconds) * time.Second
+		replicas = daos.NewReplicaSet(pools, window, rediscache.NewWriteWindows())
+		daos.SetReplicas(replicas)
+		replicasCtx, cancelReplicas := context.WithCancel(context.Background())
+		defer cancelReplicas()
+		go replicas.Run(replicasCtx, time.Duration(cfg.DB.ReplicaCheckSeconds)*time.Second)
+	}
+
+	if cfg.DB.MigrateOnBoot {
+		// the migrations, and the wait for another instance applying them, are not bound by the statement timeout
+		migrationsDB, err := postgres.Open(secretsProvider, nil)
+		if err != nil {
+			return nil, err
+		}
+		n, err := migrations.Up(context.Background(), migrationsDB)
+		_ = migrationsDB.Close()
+		if err != nil {
+			return nil, err
+		}
+		zaplog.Logger.Info("Applied migrations: ", n)
+	}
 
-	jwt, err := jwt.New(
-		cfg.JWT.SigningAlgorithm,
-		os.Getenv("JWT_SECRET"),
-		cfg.JWT.DurationMinutes,
-		cfg.JWT.MinSecretLength)
+	jwt, err := service.JWT(cfg, secretsProvider)
 	if err != nil {
 		return nil, err
 	}
+	sec := service.Secure(cfg)
+
+	flags := featureflags.NewStore(nil)
+	flags.OnRefresh(func(flags *featureflags.Store) {
+		boil.DebugMode = flags.Enabled(featureflags.SQLDebug, featureflags.Subject{})
+	})
+	// the flags stay off until the next refresh when they cannot be loaded, e.g. before the migrations ran
+	if err := flags.Refresh(context.Background()); err != nil {
+		zaplog.Error(context.Background(), "feature flags not loaded", zap.Error(err))
+	}
+	flagsCtx, cancelFlags := context.WithCancel(context.Background())
+	defer cancelFlags()
+	go flags.Run(flagsCtx, time.Duration(cfg.Flags.RefreshSeconds)*time.Second)
+
+	// the hot keys of the configuration are applied when it is reloaded, see config.Store
+	store := config.NewStore(cfg, config.Reload)
+	store.Subscribe(func(cfg *config.Configuration) {
+		sec.SetMinPasswordStrength(cfg.App.MinPasswordStr)
+		jwt.SetTTL(cfg.JWT.DurationMinutes)
+		_ = zaplog.Level.UnmarshalText([]byte(cfg.Log.Level))
+	})
+	watchCtx, cancelWatch := context.WithCancel(context.Background())
+	defer cancelWatch()
+	go store.Watch(watchCtx, configPollInterval, append(config.EnvFiles(), os.Getenv("CONFIG_FILE"))...)
 
-	e := server.New()
+	pruneCtx, cancelPrune := context.WithCancel(context.Background())
+	defer cancelPrune()
+	go audit.RunPruner(pruneCtx, auditPruneInterval, func() int {
+		return store.Get().Audit.RetentionDays
+	})
+
+	// admin endpoints are served on a separate port
+	admin := http.NewServeMux()
+	admin.Handle("/metrics", metrics.Handler())
+	admin.Handle("/log/level", zaplog.Level)
+
+	subscriptions := server.NewSubscriptions()
+	serverCfg := &server.Config{
+		Port:                cfg.Server.Port,
+		ReadTimeoutSeconds:  cfg.Server.ReadTimeout,
+		WriteTimeoutSeconds: cfg.Server.WriteTimeout,
+		Debug:               cfg.Server.Debug,
+		AdminPort:           cfg.Admin.Port,
+		AdminHandler:        admin,
+		Tracer: &tracer.Config{
+			ServiceName: cfg.Tracing.ServiceName,
+			Exporter:    cfg.Tracing.Exporter,
+			Endpoint:    cfg.Tracing.Endpoint,
+			Insecure:    cfg.Tracing.Insecure,
+			Headers:     map[string]string{"signoz-access-token": cfg.Tracing.AccessToken},
+			SampleRatio: cfg.Tracing.SampleRatio,
+		},
+		BodyLog: &bodylog.Config{
+			Enabled:      cfg.BodyLog.Enabled,
+			MaxBodyBytes: cfg.BodyLog.MaxBytes,
+			RedactFields: cfg.BodyLog.RedactFields,
+		},
+		Readiness: controller.NewReadiness(time.Duration(cfg.Health.CheckTimeoutSeconds)*time.Second).
+			Add("postgres", controller.PostgresCheck).
+			Add("redis", rediscache.Ping).
+			Add("migrations", controller.MigrationsCheck),
+		Headers: &secure.HeadersConfig{
+			HSTSMaxAgeSeconds:     cfg.Security.HSTSMaxAgeSeconds,
+			ContentSecurityPolicy: cfg.Security.ContentSecurityPolicy,
+			RouteContentSecurityPolicies: map[string]string{
+				playgroundPath: cfg.Security.PlaygroundCSP,
+			},
+			ReferrerPolicy:    cfg.Security.ReferrerPolicy,
+			PermissionsPolicy: cfg.Security.PermissionsPolicy,
+		},
+		CORS: &secure.CORSConfig{
+			AllowOrigins: func() []string {
+				return store.Get().Cors.AllowOrigins
+			},
+			AllowMethods:     cfg.Cors.AllowMethods,
+			AllowHeaders:     cfg.Cors.AllowHeaders,
+			AllowCredentials: cfg.Cors.AllowCredentials,
+		},
+		DrainSeconds:           cfg.Server.DrainSeconds,
+		ShutdownTimeoutSeconds: cfg.Server.ShutdownTimeout,
+		TLSCertFile:            cfg.Server.TLSCertFile,
+		TLSKeyFile:             cfg.Server.TLSKeyFile,
+		H2C:                    cfg.Server.H2C,
+		RedirectPort:           cfg.Server.RedirectPort,
+		Subscriptions:          subscriptions,
+		Closers: []server.Closer{
+			{Name: "postgres", Close: func(ctx context.Context) error { return db.Close() }},
+			{Name: "redis", Close: func(ctx context.Context) error { return rediscache.Close() }},
+		},
+	}
+	if replicas != nil {
+		serverCfg.Closers = append(serverCfg.Closers, server.Closer{
+			Name:  "postgres replicas",
+			Close: func(ctx context.Context) error { return replicas.Close() },
+		})
+	}
+	e := server.New(serverCfg)
 
 	gqlMiddleware := authMw.GqlMiddleware()
 	// throttlerMiddleware puts the current user's IP address into context of gqlgen
@@ -58,23 +239,55 @@ func Start(cfg *config.Configuration) (*echo.Echo, error) {
 
 	observers := map[string]chan *graphql.User{}
 	graphqlHandler := handler.New(graphql.NewExecutableSchema(graphql.Config{
-		Resolvers: &resolver.Resolver{Observers: observers},
+		Resolvers: &resolver.Resolver{
+			Observers: observers,
+			UserRepo:  daos.NewUserRepository(),
+			RoleRepo:  daos.NewRoleRepository(),
+			AuditRepo: daos.NewAuditEventRepository(),
+			FlagRepo:  daos.NewFeatureFlagRepository(),
+			Config:    store,
+			Secure:    sec,
+			JWT:       jwt,
+			Cache:     rediscache.New(),
+			Throttle:  throttle.New(),
+			Mailer:    mailer.NewLogMailer(),
+			Clock:     clock.New(),
+			Flags:     flags,
+		},
 	}))
 
-	if os.Getenv("ENVIRONMENT_NAME") == "local" {
-		boil.DebugMode = true
+	operationTimeouts, err := cfg.Limits.OperationTimeouts()
+	if err != nil {
+		return nil, err
 	}
+	timeoutCfg := &timeout.Config{
+		Default: time.Duration(cfg.Limits.OperationTimeoutSeconds) * time.Second,
+		Fields:  operationTimeouts,
+	}
+	// the uploads are bound by the MaxUploadSize of the multipart transport instead
+	bodyLimitMiddleware := middleware.BodyLimitWithConfig(middleware.BodyLimitConfig{
+		Skipper: func(c echo.Context) bool {
+			return strings.HasPrefix(c.Request().Header.Get(echo.HeaderContentType), echo.MIMEMultipartForm)
+		},
+		Limit: strconv.FormatInt(cfg.Limits.BodyBytes, 10),
+	})
 
 	// graphql apis
 	graphqlHandler.AroundOperations(func(ctx context.Context, next graphql2.OperationHandler) graphql2.ResponseHandler {
-		return authMw.GraphQLMiddleware(ctx, jwt, next)
+		ctx = zaplog.WithOperation(ctx, graphql2.GetOperationContext(ctx).OperationName)
+		// the deadline also bounds the lookup of the user by the auth middleware
+		return timeout.GraphQLMiddleware(ctx, timeoutCfg, func(ctx context.Context) graphql2.ResponseHandler {
+			return authMw.GraphQLMiddleware(ctx, jwt, func(ctx context.Context) graphql2.ResponseHandler {
+				return readreplica.GraphQLMiddleware(ctx, replicas, next)
+			})
+		})
 	})
 	e.POST(graphQLPathname, func(c echo.Context) error {
 		req := c.Request()
 		res := c.Response()
 		graphqlHandler.ServeHTTP(res, req)
 		return nil
-	}, gqlMiddleware, throttlerMiddleware)
+	}, bodyLimitMiddleware, gqlMiddleware, throttlerMiddleware)
 
 	e.GET(graphQLPathname, func(c echo.Context) error {
 		req := c.Request()
@@ -83,39 +296,68 @@ func Start(cfg *config.Configuration) (*echo.Echo, error) {
 		return nil
 	}, gqlMiddleware, throttlerMiddleware)
 
+	websocketInit := authMw.WebsocketInit(jwt)
 	graphqlHandler.AddTransport(transport.Websocket{
 		KeepAlivePingInterval: 10 * time.Second,
+		InitFunc: func(ctx context.Context, initPayload transport.InitPayload) (context.Context, error) {
+			ctx, err := websocketInit(ctx, initPayload)
+			if err != nil {
+				return nil, err
+			}
+			return subscriptions.Context(ctx), nil
+		},
 		Upgrader: websocket.Upgrader{
-			CheckOrigin: func(r *http.Request) bool {
-				return true
-			},
+			CheckOrigin: checkOrigin(func() []string {
+				return store.Get().Websocket.AllowOrigins
+			}),
 		},
 	})
 
 	graphqlHandler.AddTransport(transport.Options{})
 	graphqlHandler.AddTransport(transport.GET{})
 	graphqlHandler.AddTransport(transport.POST{})
-	graphqlHandler.AddTransport(transport.MultipartForm{})
+	graphqlHandler.AddTransport(transport.MultipartForm{
+		MaxUploadSize: cfg.Limits.MaxUploadBytes,
+		MaxMemory:     cfg.Limits.MaxMemoryBytes,
+	})
 
 	graphqlHandler.SetQueryCache(lru.New(1000))
 
+	graphqlHandler.Use(metrics.GraphQLExtension{})
+	graphqlHandler.Use(&tracer.GraphQLExtension{})
 	graphqlHandler.Use(extension.Introspection{})
 	graphqlHandler.Use(extension.AutomaticPersistedQuery{
 		Cache: lru.New(100),
 	})
 
 	// graphql playground
-	e.GET("/playground", func(c echo.Context) error {
+	e.GET(playgroundPath, func(c echo.Context) error {
 		req := c.Request()
 		res := c.Response()
 		playgroundHandler.ServeHTTP(res, req)
 		return nil
 	})
-	server.Start(e, &server.Config{
-		Port:                cfg.Server.Port,
-		ReadTimeoutSeconds:  cfg.Server.ReadTimeout,
-		WriteTimeoutSeconds: cfg.Server.WriteTimeout,
-		Debug:               cfg.Server.Debug,
-	})
+
+	server.Start(e, serverCfg)
 	return e, nil
 }
+
+// checkOrigin accepts the websocket upgrades from the origin of the server, from the allowed origins and from
+// clients that send no origin, which are not browsers
+func checkOrigin(allowOrigins func() []string) func(r *http.Request) bool {
+	return func(r *http.Request) bool {
+		origin := r.Header.Get(echo.HeaderOrigin)
+		if origin == "" {
+			return true
+		}
+		if u, err := url.Parse(origin); err == nil && strings.EqualFold(u.Host, r.Host) {
+			return true
+		}
+		for _, allowed := range allowOrigins() {
+			if allowed == "*" || strings.EqualFold(allowed, origin) {
+				return true
+			}
+		}
+		return false
+	}
+}
@@ -9,12 +9,12 @@ import (
 	"log"
 	"net/http"
 	"net/http/httptest"
-	"os"
 	"reflect"
 	"strings"
 	"testing"
 	"time"
 
+	"go-template/daos"
 	graphql "go-template/gqlmodels"
 	"go-template/internal/config"
 	"go-template/internal/server"
@@ -39,10 +39,9 @@ func TestStart(t *testing.T) {
 		cfg *config.Configuration
 	}
 	tests := []struct {
-		name        string
-		args        args
-		wantErr     bool
-		setDbCalled bool
+		name    string
+		args    args
+		wantErr bool
 
 		getTransportCalled           bool
 		postTransportCalled          bool
@@ -72,23 +71,33 @@ func TestStart(t *testing.T) {
 		},
 	}
 
-	patches := ApplyFunc(os.Getenv, func(key string) (value string) {
-		if key == "JWT_SECRET" {
-			return testutls.MockJWTSecret
-		}
-		return ""
-	})
-	defer patches.Reset()
-	ApplyFunc(sql.Open, func(driverName string, dataSourceName string) (*sql.DB, error) {
+	// Start reads the credentials with the env secrets provider of the mock config
+	credentials := map[string]string{
+		"JWT_SECRET":   testutls.MockJWTSecret,
+		"PSQL_DBNAME":  "go_template",
+		"PSQL_HOST":    "localhost",
+		"PSQL_USER":    "go_template_role",
+		"PSQL_PASS":    "go_template_role456",
+		"PSQL_PORT":    "5432",
+		"PSQL_SSLMODE": "disable",
+	}
+	for key, value := range credentials {
+		t.Setenv(key, value)
+	}
+	patches := ApplyFunc(sql.Open, func(driverName string, dataSourceName string) (*sql.DB, error) {
 		fmt.Print("sql.Open called\n")
 		return nil, nil
 	})
+	defer patches.Reset()
+	ApplyFunc(daos.FindAllFeatureFlags, func(ctx context.Context) ([]daos.FeatureFlag, error) {
+		return []daos.FeatureFlag{}, nil
+	})
 	ApplyFunc(server.Start, func(e *echo.Echo, cfg *server.Config) {
 		fmt.Print("Fake server started\n")
 	})
 	e := echo.New()
 
-	ApplyFunc(server.New, func() *echo.Echo {
+	ApplyFunc(server.New, func(cfg *server.Config) *echo.Echo {
 		return e
 	})
 
@@ -99,10 +108,8 @@ func TestStart(t *testing.T) {
 
 	for _, tt := range tests {
 		t.Run(tt.name, func(t *testing.T) {
-			ApplyFunc(boil.SetDB, func(db boil.Executor) {
-				fmt.Print("boil.SetDB called\n")
-				tt.setDbCalled = true
-			})
+			// Start hands its pool to sqlboiler
+			boil.SetDB(nil)
 
 			if tt.getTransportCalled || tt.postTransportCalled ||
 				tt.optionsTransportCalled || tt.multipartFormTransportCalled {
@@ -152,7 +159,7 @@ func TestStart(t *testing.T) {
 			} else {
 				_, err := Start(tt.args.cfg)
 				if err != nil != tt.wantErr {
-					t.Errorf("Start() error = %v, wantErr %v", err, tt.wantErr)
+					t.Fatalf("Start() error = %v, wantErr %v", err, tt.wantErr)
 				}
 				jsonRes, err := testutls.MakeRequest(testutls.RequestParameters{
 					E:           e,
@@ -166,12 +173,28 @@ func TestStart(t *testing.T) {
 					log.Fatal(err)
 				}
 
-				assert.Equal(t, tt.setDbCalled, true)
+				assert.NotNil(t, boil.GetDB())
 
 				// check if it returns schema correctly
-				assert.NotNil(t, jsonRes["data"].(map[string]interface{})["__schema"])
+				data, ok := jsonRes["data"].(map[string]interface{})
+				if !ok {
+					t.Fatalf("the introspection query returned no data: %v", jsonRes)
+				}
+				assert.NotNil(t, data["__schema"])
 
+				// bodies over the limit are refused before reaching gqlgen
 				_, res, err := testutls.SimpleMakeRequest(testutls.RequestParameters{
+					E:           e,
+					Pathname:    graphQLPathname,
+					HttpMethod:  "POST",
+					RequestBody: strings.Repeat(" ", int(tt.args.cfg.Limits.BodyBytes)+1),
+				})
+				if err != nil {
+					log.Fatal(err)
+				}
+				assert.Equal(t, http.StatusRequestEntityTooLarge, res.StatusCode)
+
+				_, res, err = testutls.SimpleMakeRequest(testutls.RequestParameters{
 					E:          e,
 					Pathname:   "/playground",
 					HttpMethod: "GET",
@@ -204,10 +227,52 @@ func TestStart(t *testing.T) {
 				if err != nil {
 					t.Fatalf("%v", err)
 				}
-				// check if the playground is returned
-				assert.Contains(t, string(p), "{\"type\":\"connection_ack\"}\n")
+				// the connection is refused without a valid token
+				assert.Contains(t, string(p), `"type":"connection_error"`)
+				assert.Contains(t, string(p), "Invalid authorization token")
 			}
 
 		})
 	}
 }
+
+func TestCheckOrigin(t *testing.T) {
+	cases := map[string]struct {
+		origin       string
+		allowOrigins []string
+		want         bool
+	}{
+		"Success_NoOrigin": {
+			want: true,
+		},
+		"Success_SameOrigin": {
+			origin: "http://example.com",
+			want:   true,
+		},
+		"Success_AllowedOrigin": {
+			origin:       "https://app.example.org",
+			allowOrigins: []string{"https://app.example.org"},
+			want:         true,
+		},
+		"Success_Wildcard": {
+			origin:       "https://app.example.org",
+			allowOrigins: []string{"*"},
+			want:         true,
+		},
+		"Failure_OtherOrigin": {
+			origin:       "https://evil.example.net",
+			allowOrigins: []string{"https://app.example.org"},
+			want:         false,
+		},
+	}
+	for name, tt := range cases {
+		t.Run(name, func(t *testing.T) {
+			req := httptest.NewRequest(http.MethodGet, "http://example.com/graphql", nil)
+			if tt.origin != "" {
+				req.Header.Set(echo.HeaderOrigin, tt.origin)
+			}
+			check := checkOrigin(func() []string { return tt.allowOrigins })
+			assert.Equal(t, tt.want, check(req))
+		})
+	}
+}
@@ -0,0 +1,21 @@
+// Package clock abstracts the current time so that it can be fixed in tests
+package clock
+
+import "time"
+
+// Clock tells the current time
+type Clock interface {
+	Now() time.Time
+}
+
+// New returns the clock of the system
+func New() Clock {
+	return systemClock{}
+}
+
+type systemClock struct{}
+
+// Now returns the current time in UTC
+func (systemClock) Now() time.Time {
+	return time.Now().UTC()
+}
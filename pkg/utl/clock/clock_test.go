@@ -0,0 +1,17 @@
+package clock_test
+
+import (
+	"testing"
+	"time"
+
+	"go-template/pkg/utl/clock"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestNow(t *testing.T) {
+	before := time.Now()
+	now := clock.New().Now()
+	assert.Equal(t, time.UTC, now.Location())
+	assert.False(t, now.Before(before))
+}
@@ -2,6 +2,7 @@ package cnvrttogql
 
 import (
 	"context"
+	"go-template/daos"
 	graphql "go-template/gqlmodels"
 	"go-template/internal/constants"
 	"go-template/models"
@@ -44,6 +45,13 @@ func UserToGraphQlUser(u *models.User, count int) *graphql.User {
 		Address:   convert.NullDotStringToPointerString(u.Address),
 		Active:    convert.NullDotBoolToPointerBool(u.Active),
 		Role:      RoleToGraphqlRole(role, count),
+
+		LastLogin:          convert.NullDotTimeToPointerInt(u.LastLogin),
+		LastPasswordChange: convert.NullDotTimeToPointerInt(u.LastPasswordChange),
+		CreatedAt:          convert.NullDotTimeToPointerInt(u.CreatedAt),
+		UpdatedAt:          convert.NullDotTimeToPointerInt(u.UpdatedAt),
+		DeletedAt:          convert.NullDotTimeToPointerInt(u.DeletedAt),
+		Version:            u.Version,
 	}
 }
 
@@ -70,3 +78,56 @@ func RoleToGraphqlRole(r *models.Role, count int) *graphql.Role {
 		Users:       UsersToGraphQlUsers(users, count),
 	}
 }
+
+// FeatureFlagToGraphQlFeatureFlag converts type daos.FeatureFlag into pointer type graphql.FeatureFlag
+func FeatureFlagToGraphQlFeatureFlag(f daos.FeatureFlag) *graphql.FeatureFlag {
+	userIDs := make([]string, len(f.UserIDs))
+	for i, id := range f.UserIDs {
+		userIDs[i] = strconv.Itoa(id)
+	}
+	roles := f.Roles
+	if roles == nil {
+		roles = []string{}
+	}
+	return &graphql.FeatureFlag{
+		Name:              f.Name,
+		Description:       convert.NullDotStringToPointerString(f.Description),
+		Enabled:           f.Enabled,
+		RolloutPercentage: f.RolloutPercentage,
+		Roles:             roles,
+		UserIds:           userIDs,
+		CreatedAt:         convert.NullDotTimeToPointerInt(f.CreatedAt),
+		UpdatedAt:         convert.NullDotTimeToPointerInt(f.UpdatedAt),
+	}
+}
+
+// AuditEventsToGraphQlAuditEvents converts array of type daos.AuditEvent into array of pointer type graphql.AuditEvent
+func AuditEventsToGraphQlAuditEvents(events []daos.AuditEvent) []*graphql.AuditEvent {
+	r := []*graphql.AuditEvent{}
+	for _, e := range events {
+		r = append(r, AuditEventToGraphQlAuditEvent(e))
+	}
+	return r
+}
+
+// AuditEventToGraphQlAuditEvent converts type daos.AuditEvent into pointer type graphql.AuditEvent
+func AuditEventToGraphQlAuditEvent(e daos.AuditEvent) *graphql.AuditEvent {
+	event := &graphql.AuditEvent{
+		ID:         strconv.FormatInt(e.ID, 10),
+		Action:     e.Action,
+		TargetType: e.TargetType,
+		TargetID:   convert.NullDotStringToPointerString(e.TargetID),
+		IP:         convert.NullDotStringToPointerString(e.IP),
+		RequestID:  convert.NullDotStringToPointerString(e.RequestID),
+		CreatedAt:  int(e.CreatedAt.UnixMilli()),
+	}
+	if e.ActorID.Valid {
+		actorID := strconv.Itoa(e.ActorID.Int)
+		event.ActorID = &actorID
+	}
+	if e.Diff.Valid {
+		diff := string(e.Diff.JSON)
+		event.Diff = &diff
+	}
+	return event
+}
@@ -0,0 +1,30 @@
+// Package mailer sends emails to the users of the service
+package mailer
+
+import (
+	"context"
+
+	"go-template/pkg/utl/zaplog"
+
+	"go.uber.org/zap"
+)
+
+// Mailer sends an email to a single recipient
+type Mailer interface {
+	Send(ctx context.Context, to string, subject string, body string) error
+}
+
+// NewLogMailer returns a mailer that writes the emails to the log instead of delivering them.
+// It is used until an email provider is configured.
+func NewLogMailer() Mailer {
+	return logMailer{}
+}
+
+type logMailer struct{}
+
+// Send logs the recipient and the subject of the email, the body is left out as it may hold secrets
+func (logMailer) Send(ctx context.Context, to string, subject string, body string) error {
+	zaplog.Info(ctx, "email not delivered, no provider configured",
+		zap.String("to", to), zap.String("subject", subject), zap.Int("body_bytes", len(body)))
+	return nil
+}
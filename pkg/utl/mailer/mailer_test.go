@@ -0,0 +1,29 @@
+package mailer_test
+
+import (
+	"context"
+	"testing"
+
+	"go-template/pkg/utl/mailer"
+	"go-template/pkg/utl/zaplog"
+
+	"github.com/stretchr/testify/assert"
+	"go.uber.org/zap"
+	"go.uber.org/zap/zaptest/observer"
+)
+
+func TestLogMailer(t *testing.T) {
+	core, logs := observer.New(zap.InfoLevel)
+	old := zaplog.Logger
+	zaplog.SetLogger(zap.New(core).Sugar())
+	defer zaplog.SetLogger(old)
+
+	err := mailer.NewLogMailer().Send(context.Background(), "mac@wednesday.is", "Welcome", "secret link")
+
+	assert.Nil(t, err)
+	assert.Equal(t, 1, logs.Len())
+	fields := logs.All()[0].ContextMap()
+	assert.Equal(t, "mac@wednesday.is", fields["to"])
+	assert.Equal(t, "Welcome", fields["subject"])
+	assert.NotContains(t, fields, "body")
+}
@@ -1,15 +1,32 @@
 package rediscache
 
 import (
+	"context"
 	"encoding/json"
 	"fmt"
 	"os"
+	"sync"
+	"time"
 
 	redigo "github.com/gomodule/redigo/redis"
 )
 
+const (
+	// maxIdle is the number of connections kept open between calls
+	maxIdle = 10
+	// idleTimeout is the duration after which an idle connection is closed
+	idleTimeout = 4 * time.Minute
+)
+
+var (
+	poolMu sync.Mutex
+	pool   *redigo.Pool
+	// dial connects to redis, the tests replace it with a mock connection
+	dial = redigo.Dial
+)
+
 func redisDial() (redigo.Conn, error) {
-	conn, err := redigo.Dial("tcp", os.Getenv("REDIS_ADDRESS"))
+	conn, err := dial("tcp", os.Getenv("REDIS_ADDRESS"))
 	// Connection error handling
 	if err != nil {
 		return conn, err
@@ -17,27 +34,109 @@ func redisDial() (redigo.Conn, error) {
 	return conn, err
 }
 
+func newPool(maxIdle int) *redigo.Pool {
+	return &redigo.Pool{
+		MaxIdle:     maxIdle,
+		IdleTimeout: idleTimeout,
+		Dial: func() (redigo.Conn, error) {
+			conn, err := redisDial()
+			if err != nil {
+				return nil, err
+			}
+			// the pooled connections fail the commands run with a context when the dialed one has no support for it
+			if _, ok := conn.(redigo.ConnWithContext); !ok {
+				return contextConn{conn}, nil
+			}
+			return conn, nil
+		},
+	}
+}
+
+// contextConn runs the commands of a connection without context support, ignoring the context
+type contextConn struct {
+	redigo.Conn
+}
+
+func (c contextConn) DoContext(ctx context.Context, cmd string, args ...interface{}) (interface{}, error) {
+	return c.Conn.Do(cmd, args...)
+}
+
+func (c contextConn) ReceiveContext(ctx context.Context) (interface{}, error) {
+	return c.Conn.Receive()
+}
+
+// getConn returns a connection of the pool, it must be closed to be returned to the pool
+func getConn(ctx context.Context) (redigo.Conn, error) {
+	poolMu.Lock()
+	if pool == nil {
+		pool = newPool(maxIdle)
+	}
+	p := pool
+	poolMu.Unlock()
+
+	conn, err := p.GetContext(ctx)
+	if err != nil {
+		return nil, err
+	}
+	if err := conn.Err(); err != nil {
+		conn.Close()
+		return nil, err
+	}
+	return conn, nil
+}
+
+// Close closes the idle connections of the pool and the connections returned to it afterwards
+func Close() error {
+	poolMu.Lock()
+	defer poolMu.Unlock()
+	if pool == nil {
+		return nil
+	}
+	return pool.Close()
+}
+
 // SetKeyValue ...
-func SetKeyValue(key string, data interface{}) error {
-	conn, err := redisDial()
+func SetKeyValue(key string, data interface{}, ctx context.Context) error {
+	conn, err := getConn(ctx)
 	if err != nil {
 		return fmt.Errorf("error in redis connection %s", err)
 	}
+	defer conn.Close()
 	b, err := json.Marshal(data)
 	if err != nil {
 		return err
 	}
-	_, err = conn.Do("SET", key, string(b))
+	_, err = doContext(ctx, conn, "SET", key, string(b))
 	return err
 }
 
 // GetKeyValue ...
-func GetKeyValue(key string) (interface{}, error) {
-	conn, err := redisDial()
+func GetKeyValue(key string, ctx context.Context) (interface{}, error) {
+	conn, err := getConn(ctx)
 	if err != nil {
 		return nil, fmt.Errorf("error in redis connection %s", err)
 	}
+	defer conn.Close()
 
-	reply, err := conn.Do("GET", key)
+	reply, err := doContext(ctx, conn, "GET", key)
 	return reply, err
 }
+
+// Ping checks that redis is reachable and answering within the deadline of the context
+func Ping(ctx context.Context) error {
+	conn, err := getConn(ctx)
+	if err != nil {
+		return fmt.Errorf("error in redis connection %s", err)
+	}
+	defer conn.Close()
+	_, err = doContext(ctx, conn, "PING")
+	return err
+}
+
+// doContext runs the command with the deadline of the context on connections supporting it
+func doContext(ctx context.Context, conn redigo.Conn, cmd string, args ...interface{}) (interface{}, error) {
+	if _, ok := conn.(redigo.ConnWithContext); ok {
+		return redigo.DoContext(conn, ctx, cmd, args...)
+	}
+	return conn.Do(cmd, args...)
+}
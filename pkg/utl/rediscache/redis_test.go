@@ -1,15 +1,17 @@
 package rediscache
 
 import (
+	"context"
 	"encoding/json"
 	"fmt"
+	"os"
 	"reflect"
 	"testing"
 
-	. "github.com/agiledragon/gomonkey/v2"
 	"github.com/gomodule/redigo/redis"
 	redigo "github.com/gomodule/redigo/redis"
 	redigomock "github.com/rafaeljusto/redigomock/v3"
+	"github.com/stretchr/testify/assert"
 )
 
 var redigoConn = redigomock.NewConn()
@@ -19,6 +21,19 @@ const (
 	FailedCase  = "Failed"
 )
 
+func TestMain(m *testing.M) {
+	// connections are not reused between tests, each test dials its own mock
+	pool = newPool(0)
+	os.Exit(m.Run())
+}
+
+// setDial replaces the dialer of the pool until the test ends
+func setDial(t *testing.T, fn func(string, string, ...redigo.DialOption) (redigo.Conn, error)) {
+	old := dial
+	dial = fn
+	t.Cleanup(func() { dial = old })
+}
+
 func Test_redisDial(t *testing.T) {
 
 	tests := []struct {
@@ -36,14 +51,17 @@ func Test_redisDial(t *testing.T) {
 		},
 	}
 
+	setDial(t, func(string, string, ...redis.DialOption) (redis.Conn, error) {
+		return redigoConn, nil
+	})
 	for _, tt := range tests {
 		t.Run(tt.name, func(t *testing.T) {
 			if tt.wantErr {
-				ApplyFunc(redigo.Dial, func(string, string, ...redis.DialOption) (redis.Conn, error) {
+				setDial(t, func(string, string, ...redis.DialOption) (redis.Conn, error) {
 					return nil, fmt.Errorf("some error")
 				})
 			} else {
-				ApplyFunc(redigo.Dial, func(string, string, ...redis.DialOption) (redis.Conn, error) {
+				setDial(t, func(string, string, ...redis.DialOption) (redis.Conn, error) {
 					return redigoConn, nil
 				})
 			}
@@ -88,39 +106,29 @@ func TestSetKeyValue(t *testing.T) {
 			name: ErrorMarshal,
 			args: args{
 				key:  "",
-				data: 1,
+				data: make(chan int),
 			},
 			wantErr: true,
 		},
 	}
-	ApplyFunc(redigo.Dial, func(string, string, ...redis.DialOption) (redis.Conn, error) {
+	setDial(t, func(string, string, ...redis.DialOption) (redis.Conn, error) {
 		return redigoConn, nil
 	})
 
 	for _, tt := range tests {
 		t.Run(tt.name, func(t *testing.T) {
 
-			var patches *Patches
 			b, _ := json.Marshal(tt.args.data)
 			if tt.name == FailedCase {
-				patches = ApplyFunc(redigo.Dial, func(string, string, ...redis.DialOption) (redis.Conn, error) {
+				setDial(t, func(string, string, ...redis.DialOption) (redis.Conn, error) {
 					return nil, fmt.Errorf("some error")
 				})
 			}
-			if tt.name == ErrorMarshal {
-				patchJson := ApplyFunc(json.Marshal, func(v any) ([]byte, error) {
-					return nil, fmt.Errorf(ErrMsgMarshal)
-				})
-				defer patchJson.Reset()
-			}
 			redigoConn.Command("SET", tt.args.key, string(b)).Expect("something")
 
-			if err := SetKeyValue(tt.args.key, tt.args.data); (err != nil) != tt.wantErr {
+			if err := SetKeyValue(tt.args.key, tt.args.data, context.Background()); (err != nil) != tt.wantErr {
 				t.Errorf("SetKeyValue() error = %v, wantErr %v", err, tt.wantErr)
 			}
-			if patches != nil {
-				patches.Reset()
-			}
 		})
 	}
 }
@@ -150,17 +158,19 @@ func TestGetKeyValue(t *testing.T) {
 			wantErr: true,
 		},
 	}
+	setDial(t, func(string, string, ...redis.DialOption) (redis.Conn, error) {
+		return redigoConn, nil
+	})
 	for _, tt := range tests {
 		t.Run(tt.name, func(t *testing.T) {
-			var patches *Patches
 			if tt.wantErr {
-				patches = ApplyFunc(redigo.Dial, func(string, string, ...redis.DialOption) (redis.Conn, error) {
+				setDial(t, func(string, string, ...redis.DialOption) (redis.Conn, error) {
 					return nil, fmt.Errorf("some error")
 				})
 			}
 			redigoConn.Command("GET", tt.args.key).Expect(tt.want)
 
-			got, err := GetKeyValue(tt.args.key)
+			got, err := GetKeyValue(tt.args.key, context.Background())
 			if (err != nil) != tt.wantErr {
 				t.Errorf("GetKeyValue() error = %v, wantErr %v", err, tt.wantErr)
 				return
@@ -168,9 +178,72 @@ func TestGetKeyValue(t *testing.T) {
 			if !reflect.DeepEqual(got, tt.want) {
 				t.Errorf("GetKeyValue() = %v, want %v", got, tt.want)
 			}
-			if patches != nil {
-				patches.Reset()
+		})
+	}
+}
+
+func TestPing(t *testing.T) {
+	tests := []struct {
+		name    string
+		dialErr error
+		pingErr error
+		wantErr bool
+	}{
+		{
+			name: SuccessCase,
+		},
+		{
+			name:    FailedCase,
+			dialErr: fmt.Errorf("some error"),
+			wantErr: true,
+		},
+		{
+			name:    "Failed_Ping",
+			pingErr: fmt.Errorf("LOADING"),
+			wantErr: true,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			conn := redigomock.NewConn()
+			setDial(t, func(string, string, ...redis.DialOption) (redis.Conn, error) {
+				return conn, tt.dialErr
+			})
+			if tt.pingErr != nil {
+				conn.Command("PING").ExpectError(tt.pingErr)
+			} else {
+				conn.Command("PING").Expect("PONG")
+			}
+
+			if err := Ping(context.Background()); (err != nil) != tt.wantErr {
+				t.Errorf("Ping() error = %v, wantErr %v", err, tt.wantErr)
 			}
 		})
 	}
 }
+
+func TestGetConn(t *testing.T) {
+	defer func() { pool = newPool(0) }()
+	pool = newPool(1)
+	dials := 0
+	setDial(t, func(string, string, ...redis.DialOption) (redis.Conn, error) {
+		dials++
+		conn := redigomock.NewConn()
+		conn.Command("PING").Expect("PONG")
+		return conn, nil
+	})
+
+	for i := 0; i < 2; i++ {
+		conn, err := getConn(context.Background())
+		assert.Nil(t, err)
+		// the mocks have no context support, the commands run without the deadline
+		_, err = doContext(context.Background(), conn, "PING")
+		assert.Nil(t, err)
+		assert.Nil(t, conn.Close())
+	}
+	assert.Equal(t, 1, dials, "idle connections are reused")
+
+	assert.Nil(t, Close())
+	_, err := getConn(context.Background())
+	assert.NotNil(t, err, "the pool is closed")
+}
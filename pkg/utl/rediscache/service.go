@@ -8,24 +8,42 @@ import (
 	"time"
 
 	"go-template/daos"
+	"go-template/internal/service/metrics"
 	"go-template/models"
 	resultwrapper "go-template/pkg/utl/resultwrapper"
 
 	redigo "github.com/gomodule/redigo/redis"
 )
 
-// Service ...
+// Service reads the users and roles through the cache and counts visits
 type Service interface {
-	GetUser(id int, ctx context.Context) (models.User, error)
-	GetRole(id int, ctx context.Context) (models.Role, error)
-	IncVisits(path string) (int, error)
-	StartVisits(path string, exp time.Duration) error
+	GetUser(id int, ctx context.Context) (*models.User, error)
+	GetRole(id int, ctx context.Context) (*models.Role, error)
+	IncVisits(path string, ctx context.Context) (int, error)
+	StartVisits(path string, exp time.Duration, ctx context.Context) error
+}
+
+// New returns the redis backed Service
+func New() Service {
+	return service{}
+}
+
+type service struct{}
+
+func (service) GetUser(id int, ctx context.Context) (*models.User, error) { return GetUser(id, ctx) }
+
+func (service) GetRole(id int, ctx context.Context) (*models.Role, error) { return GetRole(id, ctx) }
+
+func (service) IncVisits(path string, ctx context.Context) (int, error) { return IncVisits(path, ctx) }
+
+func (service) StartVisits(path string, exp time.Duration, ctx context.Context) error {
+	return StartVisits(path, exp, ctx)
 }
 
 // GetUser gets user from redis, if present, else from the database
 func GetUser(userID int, ctx context.Context) (*models.User, error) {
 	// get user cache key
-	cachedUserValue, err := GetKeyValue(fmt.Sprintf("user%d", userID))
+	cachedUserValue, err := GetKeyValue(fmt.Sprintf("user%d", userID), ctx)
 	if err != nil {
 		return nil, err
 	}
@@ -36,14 +54,16 @@ func GetUser(userID int, ctx context.Context) (*models.User, error) {
 		if err != nil {
 			return nil, fmt.Errorf("%s", err)
 		}
+		metrics.CacheRequests.WithLabelValues("user", metrics.CacheHit).Inc()
 		return user, err
 	}
+	metrics.CacheRequests.WithLabelValues("user", metrics.CacheMiss).Inc()
 	user, err = daos.FindUserByID(userID, ctx)
 	if err != nil {
 		return nil, resultwrapper.ResolverSQLError(err, "data")
 	}
 	// setting user cache key
-	err = SetKeyValue(fmt.Sprintf("user%d", userID), user)
+	err = SetKeyValue(fmt.Sprintf("user%d", userID), user, ctx)
 	if err != nil {
 		return nil, fmt.Errorf("%s", err)
 	}
@@ -53,7 +73,7 @@ func GetUser(userID int, ctx context.Context) (*models.User, error) {
 // GetRole gets role from redis, if present, else from the database
 func GetRole(roleID int, ctx context.Context) (*models.Role, error) {
 	// get role cache key
-	cachedRoleValue, err := GetKeyValue(fmt.Sprintf("role%d", roleID))
+	cachedRoleValue, err := GetKeyValue(fmt.Sprintf("role%d", roleID), ctx)
 	if err != nil {
 		return nil, err
 	}
@@ -64,14 +84,16 @@ func GetRole(roleID int, ctx context.Context) (*models.Role, error) {
 		if err != nil {
 			return nil, fmt.Errorf("%s", err)
 		}
+		metrics.CacheRequests.WithLabelValues("role", metrics.CacheHit).Inc()
 		return role, err
 	}
+	metrics.CacheRequests.WithLabelValues("role", metrics.CacheMiss).Inc()
 	role, err = daos.FindRoleByID(roleID, ctx)
 	if err != nil {
 		return nil, resultwrapper.ResolverSQLError(err, "data")
 	}
 	// setting role cache key
-	err = SetKeyValue(fmt.Sprintf("role%d", roleID), role)
+	err = SetKeyValue(fmt.Sprintf("role%d", roleID), role, ctx)
 	if err != nil {
 		return nil, fmt.Errorf("%s", err)
 	}
@@ -80,20 +102,20 @@ func GetRole(roleID int, ctx context.Context) (*models.Role, error) {
 
 // IncVisits Increases the no. of visits by a particular visitor on a
 // particular graphQL path by one, or returns 1 if visiting 1st time.
-func IncVisits(path string) (int, error) {
-	conn, err := redisDial()
+func IncVisits(path string, ctx context.Context) (int, error) {
+	conn, err := getConn(ctx)
 	if err != nil {
 		return 0, fmt.Errorf("error in redis connection %s", err)
 	}
 	defer conn.Close()
 
-	return redigo.Int(conn.Do("INCR", path))
+	return redigo.Int(doContext(ctx, conn, "INCR", path))
 }
 
 // StartVisits is called when the visiter is first time entering the
 // given path or no entry of the visiter is present because of time-out, It sets the path with expiry as exp
-func StartVisits(path string, exp time.Duration) error {
-	conn, err := redisDial()
+func StartVisits(path string, exp time.Duration, ctx context.Context) error {
+	conn, err := getConn(ctx)
 	if err != nil {
 		return fmt.Errorf("error in redis connection %s", err)
 	}
@@ -101,9 +123,47 @@ func StartVisits(path string, exp time.Duration) error {
 
 	ttl := math.Ceil(exp.Seconds())
 
-	_, err = conn.Do("SETEX", path, int(ttl), 1)
+	_, err = doContext(ctx, conn, "SETEX", path, int(ttl), 1)
 	if err != nil {
 		return err
 	}
 	return nil
 }
+
+// NewWriteWindows returns the redis backed daos.WriteWindows, which every instance of the app shares
+func NewWriteWindows() daos.WriteWindows {
+	return writeWindows{}
+}
+
+type writeWindows struct{}
+
+func (writeWindows) Start(ctx context.Context, userID int, window time.Duration) error {
+	return StartWriteWindow(userID, window, ctx)
+}
+
+func (writeWindows) Running(ctx context.Context, userID int) (bool, error) {
+	return WriteWindowRunning(userID, ctx)
+}
+
+// StartWriteWindow starts the write window of the user, its key expires when the window is over
+func StartWriteWindow(userID int, window time.Duration, ctx context.Context) error {
+	conn, err := getConn(ctx)
+	if err != nil {
+		return fmt.Errorf("error in redis connection %s", err)
+	}
+	defer conn.Close()
+
+	_, err = doContext(ctx, conn, "SET", fmt.Sprintf("write-window-%d", userID), 1, "PX", window.Milliseconds())
+	return err
+}
+
+// WriteWindowRunning returns whether the write window of the user is running
+func WriteWindowRunning(userID int, ctx context.Context) (bool, error) {
+	conn, err := getConn(ctx)
+	if err != nil {
+		return false, fmt.Errorf("error in redis connection %s", err)
+	}
+	defer conn.Close()
+
+	return redigo.Bool(doContext(ctx, conn, "EXISTS", fmt.Sprintf("write-window-%d", userID)))
+}
@@ -19,7 +19,6 @@ import (
 
 	"github.com/DATA-DOG/go-sqlmock"
 	"github.com/agiledragon/gomonkey/v2"
-	. "github.com/agiledragon/gomonkey/v2"
 	"github.com/gomodule/redigo/redis"
 	redigo "github.com/gomodule/redigo/redis"
 	redigomock "github.com/rafaeljusto/redigomock/v3"
@@ -224,18 +223,12 @@ func TestGetUser(t *testing.T) {
 	mock, db, _ := testutls.SetupMockDB(t)
 
 	for _, tt := range tests {
-		ApplyFunc(redisDial, func() (redis.Conn, error) {
+		setDial(t, func(string, string, ...redis.DialOption) (redis.Conn, error) {
 			return conn, nil
 		})
 
 		t.Run(tt.name, func(t *testing.T) {
 
-			if tt.name == ErrorUnmarshal {
-				patchJson := ApplyFunc(json.Marshal, func(v any) ([]byte, error) {
-					return []byte{}, fmt.Errorf("json error")
-				})
-				defer patchJson.Reset()
-			}
 			if tt.args.cacheMiss {
 				conn.Command("GET", fmt.Sprintf("user%d", tt.args.userID)).Expect(nil)
 
@@ -251,6 +244,8 @@ func TestGetUser(t *testing.T) {
 
 			} else if tt.name == ErrorSetKeyValue {
 				conn.Command("GET", fmt.Sprintf("role%d", tt.args.userID)).Expect(nil)
+			} else if tt.name == ErrorUnmarshal {
+				conn.Command("GET", fmt.Sprintf("user%d", tt.args.userID)).Expect([]byte("{"))
 			} else {
 				b, _ := json.Marshal(tt.want)
 				conn.Command("GET", fmt.Sprintf("user%d", tt.args.userID)).Expect(b)
@@ -392,19 +387,12 @@ func TestGetRole(t *testing.T) {
 
 	for _, tt := range tests {
 
-		ApplyFunc(redisDial, func() (redis.Conn, error) {
+		setDial(t, func(string, string, ...redis.DialOption) (redis.Conn, error) {
 			return conn, nil
 		})
 
 		t.Run(tt.name, func(t *testing.T) {
 
-			if tt.name == ErrorUnmarshal {
-				patchJson := ApplyFunc(json.Unmarshal, func(data []byte, v any) error {
-					return fmt.Errorf(ErrMsgUnmarshal)
-				})
-				defer patchJson.Reset()
-			}
-
 			if tt.args.cacheMiss {
 				conn.Command("GET", fmt.Sprintf("role%d", tt.args.roleID)).Expect(nil)
 
@@ -420,6 +408,8 @@ func TestGetRole(t *testing.T) {
 				conn.Command("GET", fmt.Sprintf("role%d", tt.args.roleID)).ExpectError(fmt.Errorf(ErrMsgGetKeyValue))
 			} else if tt.name == ErrorSetKeyValue {
 				conn.Command("GET", fmt.Sprintf("role%d", tt.args.roleID)).Expect(nil)
+			} else if tt.name == ErrorUnmarshal {
+				conn.Command("GET", fmt.Sprintf("role%d", tt.args.roleID)).Expect([]byte("{"))
 			} else {
 				b, _ := json.Marshal(tt.want)
 				conn.Command("GET", fmt.Sprintf("role%d", tt.args.roleID)).Expect(b)
@@ -466,22 +456,21 @@ func TestIncVisits(t *testing.T) {
 		},
 	}
 
-	ApplyFunc(redisDial, func() (redis.Conn, error) {
+	setDial(t, func(string, string, ...redis.DialOption) (redis.Conn, error) {
 		return conn, nil
 	})
 	for _, tt := range tests {
 
 		if tt.name == ErrorRedisDial {
-			patch := gomonkey.ApplyFunc(redisDial, func() (redigo.Conn, error) {
+			setDial(t, func(string, string, ...redigo.DialOption) (redigo.Conn, error) {
 				return nil, fmt.Errorf(ErrMsgFromRedisDial)
 			})
-			defer patch.Reset()
 		}
 
 		t.Run(tt.name, func(t *testing.T) {
 
 			conn.Command("INCR", tt.args.path).Expect([]byte(fmt.Sprint(tt.want)))
-			got, err := IncVisits(tt.args.path)
+			got, err := IncVisits(tt.args.path, context.Background())
 			if (err != nil) != tt.wantErr {
 				t.Errorf("IncVisits() error = %v, wantErr %v", err, tt.wantErr)
 				return
@@ -528,7 +517,7 @@ func TestStartVisits(t *testing.T) {
 			errMsg:  fmt.Errorf(ErrMsgFromRedisDial),
 		},
 	}
-	ApplyFunc(redisDial, func() (redis.Conn, error) {
+	setDial(t, func(string, string, ...redis.DialOption) (redis.Conn, error) {
 		return conn, nil
 	})
 
@@ -540,10 +529,9 @@ func TestStartVisits(t *testing.T) {
 	for _, tt := range tests {
 
 		if tt.name == ErrorRedisDial {
-			patch := gomonkey.ApplyFunc(redisDial, func() (redigo.Conn, error) {
+			setDial(t, func(string, string, ...redigo.DialOption) (redigo.Conn, error) {
 				return nil, fmt.Errorf(ErrMsgFromRedisDial)
 			})
-			defer patch.Reset()
 		}
 
 		t.Run(tt.name, func(t *testing.T) {
@@ -555,7 +543,7 @@ func TestStartVisits(t *testing.T) {
 				defer patch.Reset()
 			}
 			conn.Command("SETEX", tt.args.path, int(math.Ceil(time.Second.Seconds())), 1).Expect(1)
-			err := StartVisits(tt.args.path, time.Second)
+			err := StartVisits(tt.args.path, time.Second, context.Background())
 
 			if (err != nil) != tt.wantErr {
 				t.Errorf("StartVisits() error = %v, wantErr %v", err, tt.wantErr)
@@ -565,3 +553,55 @@ func TestStartVisits(t *testing.T) {
 		})
 	}
 }
+
+func TestWriteWindows(t *testing.T) {
+	tests := map[string]struct {
+		dialErr     error
+		running     bool
+		wantRunning bool
+		wantErr     bool
+	}{
+		"Success_Running": {
+			running:     true,
+			wantRunning: true,
+		},
+		"Success_Over": {},
+		ErrorRedisDial: {
+			dialErr: fmt.Errorf(ErrMsgFromRedisDial),
+			wantErr: true,
+		},
+	}
+	for name, tt := range tests {
+		t.Run(name, func(t *testing.T) {
+			conn := redigomock.NewConn()
+			setDial(t, func(string, string, ...redigo.DialOption) (redigo.Conn, error) {
+				if tt.dialErr != nil {
+					return nil, tt.dialErr
+				}
+				return conn, nil
+			})
+			start := conn.Command("SET", "write-window-1", 1, "PX", int64(5000)).Expect("OK")
+			exists := int64(0)
+			if tt.running {
+				exists = 1
+			}
+			conn.Command("EXISTS", "write-window-1").Expect(exists)
+
+			windows := NewWriteWindows()
+			err := windows.Start(context.Background(), 1, 5*time.Second)
+			if (err != nil) != tt.wantErr {
+				t.Errorf("Start() error = %v, wantErr %v", err, tt.wantErr)
+			}
+			running, err := windows.Running(context.Background(), 1)
+			if (err != nil) != tt.wantErr {
+				t.Errorf("Running() error = %v, wantErr %v", err, tt.wantErr)
+			}
+			if running != tt.wantRunning {
+				t.Errorf("Running() = %v, want %v", running, tt.wantRunning)
+			}
+			if !tt.wantErr && conn.Stats(start) != 1 {
+				t.Errorf("the window was not started")
+			}
+		})
+	}
+}
@@ -4,27 +4,35 @@ import (
 	"fmt"
 	"hash"
 	"strconv"
+	"sync/atomic"
 	"time"
 
 	zxcvbn "github.com/nbutton23/zxcvbn-go"
 	"golang.org/x/crypto/bcrypt"
 )
 
-// New initializes security service
-func New(minPWStr int, h hash.Hash) *Service {
-	return &Service{minPWStr: minPWStr, h: h}
+// New initializes security service, newHash returns the hash of the tokens
+func New(minPWStr int, newHash func() hash.Hash) *Service {
+	return &Service{minPWStr: int32(minPWStr), newHash: newHash}
 }
 
 // Service holds security related methods
 type Service struct {
-	minPWStr int
-	h        hash.Hash
+	// minPWStr is accessed atomically so that it can be changed while serving requests
+	minPWStr int32
+	// newHash is called for every token, a hash cannot be shared by concurrent requests
+	newHash func() hash.Hash
+}
+
+// SetMinPasswordStrength changes the minimum zxcvbn score of the passwords accepted by Password
+func (s *Service) SetMinPasswordStrength(minPWStr int) {
+	atomic.StoreInt32(&s.minPWStr, int32(minPWStr))
 }
 
 // Password checks whether password is secure enough using zxcvbn library
 func (s *Service) Password(pass string, inputs ...string) bool {
 	pwStrength := zxcvbn.PasswordStrength(pass, inputs)
-	return pwStrength.Score >= s.minPWStr
+	return pwStrength.Score >= int(atomic.LoadInt32(&s.minPWStr))
 }
 
 // Hash hashes the password using bcrypt
@@ -40,7 +48,7 @@ func (*Service) HashMatchesPassword(hash, password string) bool {
 
 // Token generates new unique token
 func (s *Service) Token(str string) string {
-	s.h.Reset()
-	fmt.Fprintf(s.h, "%s%s", str, strconv.Itoa(time.Now().Nanosecond()))
-	return fmt.Sprintf("%x", s.h.Sum(nil))
+	h := s.newHash()
+	fmt.Fprintf(h, "%s%s", str, strconv.Itoa(time.Now().Nanosecond()))
+	return fmt.Sprintf("%x", h.Sum(nil))
 }
@@ -2,6 +2,8 @@ package secure_test
 
 import (
 	"crypto/sha1"
+	"fmt"
+	"sync"
 	"testing"
 
 	"go-template/pkg/utl/secure"
@@ -43,6 +45,14 @@ func TestPassword(t *testing.T) {
 	}
 }
 
+func TestSetMinPasswordStrength(t *testing.T) {
+	s := secure.New(1, nil)
+	assert.True(t, s.Password("callgophers"))
+
+	s.SetMinPasswordStrength(4)
+	assert.False(t, s.Password("callgophers"))
+}
+
 func TestHashAndMatch(t *testing.T) {
 	cases := []struct {
 		name string
@@ -65,8 +75,31 @@ func TestHashAndMatch(t *testing.T) {
 }
 
 func TestToken(t *testing.T) {
-	s := secure.New(1, sha1.New())
+	s := secure.New(1, sha1.New)
 	token := "token"
 	tokenized := s.Token(token)
 	assert.NotEqual(t, tokenized, token)
 }
+
+func TestTokenConcurrent(t *testing.T) {
+	s := secure.New(1, sha1.New)
+
+	// the logins share the service, go test -race reports the tokens sharing a hash
+	tokens := make([]string, 50)
+	var wg sync.WaitGroup
+	for i := range tokens {
+		wg.Add(1)
+		go func(i int) {
+			defer wg.Done()
+			tokens[i] = s.Token(fmt.Sprintf("user%d", i))
+		}(i)
+	}
+	wg.Wait()
+
+	unique := map[string]bool{}
+	for _, token := range tokens {
+		assert.Len(t, token, 2*sha1.Size)
+		unique[token] = true
+	}
+	assert.Len(t, unique, len(tokens))
+}
@@ -6,6 +6,7 @@ import (
 	"os"
 	"time"
 
+	"go-template/internal/service/metrics"
 	rediscache "go-template/pkg/utl/rediscache"
 
 	"github.com/99designs/gqlgen/graphql"
@@ -18,6 +19,22 @@ const (
 	userIPAdress key = "userIPAdress"
 )
 
+// Throttler limits the number of times a query is tried
+type Throttler interface {
+	Check(ctx context.Context, limit int, dur time.Duration) error
+}
+
+// New returns the redis backed Throttler
+func New() Throttler {
+	return throttler{}
+}
+
+type throttler struct{}
+
+func (throttler) Check(ctx context.Context, limit int, dur time.Duration) error {
+	return Check(ctx, limit, dur)
+}
+
 // Check function checks weather the given IP address has already
 // tried a given query path 'limit' number of times within past 'dur'
 func Check(ctx context.Context, limit int, dur time.Duration) error {
@@ -30,15 +47,16 @@ func Check(ctx context.Context, limit int, dur time.Duration) error {
 	ip := ctx.Value(userIPAdress).(string)
 	key := fmt.Sprintf("rate-limit-%s-%s", query, ip)
 
-	num, err := rediscache.IncVisits(key)
+	num, err := rediscache.IncVisits(key, ctx)
 	if err != nil {
 		return fmt.Errorf("Internal error")
 	}
 
 	if num > limit {
+		metrics.RateLimitRejections.WithLabelValues(query).Inc()
 		return fmt.Errorf("You reached the rate limit for this query")
 	} else if num == 1 {
-		err := rediscache.StartVisits(key, dur)
+		err := rediscache.StartVisits(key, dur, ctx)
 		if err != nil {
 			return fmt.Errorf("Internal error")
 		}
@@ -47,6 +65,12 @@ func Check(ctx context.Context, limit int, dur time.Duration) error {
 	return nil
 }
 
+// IPFromContext returns the IP address placed in the context by GqlMiddleware, or an empty string
+func IPFromContext(ctx context.Context) string {
+	ip, _ := ctx.Value(userIPAdress).(string)
+	return ip
+}
+
 // GqlMiddleware returns a middleware that takes IP address
 // from echo context and place it in the context of gqlgen resolvers.
 func GqlMiddleware() echo.MiddlewareFunc {
@@ -5,7 +5,6 @@ import (
 	"context"
 	"fmt"
 	"net/http"
-	"os"
 	"testing"
 	"time"
 
@@ -24,11 +23,11 @@ func TestCheck(t *testing.T) {
 		ctx            context.Context
 		limit          int
 		dur            time.Duration
-		isLocal        bool
 		visits         int
 		visitsErr      error
 		startVisitsErr error
 		ip             string
+		isLocal        bool
 	}
 	var ctx context.Context = testutls.MockCtx{}
 	tests := []struct {
@@ -39,10 +38,11 @@ func TestCheck(t *testing.T) {
 		{
 			name: "Success_Local",
 			args: args{
-				ctx:     ctx,
-				limit:   10,
-				dur:     time.Second,
-				isLocal: true,
+				ctx:       ctx,
+				limit:     10,
+				dur:       time.Second,
+				visitsErr: fmt.Errorf("redis unavailable"),
+				isLocal:   true,
 			},
 		},
 		{
@@ -109,24 +109,19 @@ func TestCheck(t *testing.T) {
 	for _, tt := range tests {
 		t.Run(tt.name, func(t *testing.T) {
 			tt.args.ctx = context.WithValue(tt.args.ctx, userIPAdress, tt.args.ip)
+			environment := "develop"
+			if tt.args.isLocal {
+				environment = "local"
+			}
+			t.Setenv("ENVIRONMENT_NAME", environment)
 
-			patches := ApplyFunc(os.Getenv, func(key string) string {
-				if key == "ENVIRONMENT_NAME" {
-					if tt.args.isLocal {
-						return "local"
-					}
-
-				}
-				return ""
-			})
-			defer patches.Reset()
-			ApplyFunc(rediscache.IncVisits, func(path string) (int, error) {
+			ApplyFunc(rediscache.IncVisits, func(path string, ctx context.Context) (int, error) {
 				if tt.args.visitsErr != nil {
 					return 0, tt.args.visitsErr
 				}
 				return tt.args.visits, nil
 			})
-			ApplyFunc(rediscache.StartVisits, func(path string, exp time.Duration) error {
+			ApplyFunc(rediscache.StartVisits, func(path string, exp time.Duration, ctx context.Context) error {
 				return tt.args.startVisitsErr
 			})
 
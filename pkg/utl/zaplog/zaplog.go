@@ -3,47 +3,134 @@ package zaplog
 import (
 	"context"
 	"fmt"
-	"os"
 
 	"github.com/labstack/echo/v4"
+	"go.opentelemetry.io/otel/trace"
 	"go.uber.org/zap"
+	"go.uber.org/zap/zapcore"
 )
 
 var RequestIdCtxKey = &ContextKey{echo.HeaderXRequestID}
 
+var (
+	userIDCtxKey    = &ContextKey{"user_id"}
+	operationCtxKey = &ContextKey{"operation"}
+)
+
 type ContextKey struct {
 	Name string
 }
 
+const (
+	// FormatJSON writes one JSON object per entry, it is the format expected by log aggregators
+	FormatJSON = "json"
+	// FormatConsole writes human readable entries
+	FormatConsole = "console"
+)
+
+// Level is the minimum level of the logger. It can be changed at runtime and served over http
+// to read and update it, e.g. curl -X PUT -d '{"level":"debug"}' localhost:9100/log/level
+var Level = zap.NewAtomicLevelAt(zap.InfoLevel)
+
+var base *zap.Logger
+
 var Logger = InitLogger()
 
 func SetLogger(logger *zap.SugaredLogger) *zap.SugaredLogger {
 	Logger = logger
+	base = logger.Desugar()
 	return Logger
 }
 
-func getRequestID(c context.Context) string {
-	return fmt.Sprintf("\nRequest-ID: %v", c.Value(RequestIdCtxKey))
-}
-func Info(c context.Context, args ...interface{}) {
-	Logger.Info(getRequestID(c), "\n", args, "\n")
-}
-func Debug(c context.Context, args ...interface{}) {
-	Logger.Debug(getRequestID(c), "\n", args, "\n")
-}
 func InitLogger() *zap.SugaredLogger {
-	var zapLogger *zap.Logger
-	var err error
-	if os.Getenv("ENVIRONMENT_NAME") == "production" {
-		zapLogger, err = zap.NewProduction()
-	} else {
-		zapLogger, err = zap.NewDevelopment()
+	zapLogger, err := newLogger(FormatJSON)
+	if err != nil {
+		panic(err)
 	}
+	base = zapLogger
+	return zapLogger.Sugar()
+}
 
+// Configure sets the level and the output format of the logger
+func Configure(level string, format string) error {
+	if level != "" {
+		if err := Level.UnmarshalText([]byte(level)); err != nil {
+			return fmt.Errorf("invalid log level %s: %w", level, err)
+		}
+	}
+	zapLogger, err := newLogger(format)
 	if err != nil {
-		panic(err)
+		return err
 	}
+	SetLogger(zapLogger.Sugar())
+	return nil
+}
 
-	return zapLogger.Sugar()
+func newLogger(format string) (*zap.Logger, error) {
+	cfg := zap.NewProductionConfig()
+	cfg.Level = Level
+	cfg.EncoderConfig.TimeKey = "timestamp"
+	cfg.EncoderConfig.EncodeTime = zapcore.ISO8601TimeEncoder
+	switch format {
+	case "", FormatJSON:
+		cfg.Encoding = FormatJSON
+	case FormatConsole:
+		cfg.Encoding = FormatConsole
+		cfg.EncoderConfig.EncodeLevel = zapcore.CapitalColorLevelEncoder
+	default:
+		return nil, fmt.Errorf("invalid log format %s", format)
+	}
+	return cfg.Build()
+}
+
+// WithUserID returns a context whose log entries carry the id of the authenticated user
+func WithUserID(ctx context.Context, userID int) context.Context {
+	return context.WithValue(ctx, userIDCtxKey, userID)
+}
+
+// WithOperation returns a context whose log entries carry the name of the graphql operation
+func WithOperation(ctx context.Context, operation string) context.Context {
+	return context.WithValue(ctx, operationCtxKey, operation)
+}
+
+// Fields returns the request id, trace and span ids, user id and operation name found in the context
+func Fields(ctx context.Context) []zap.Field {
+	var fields []zap.Field
+	if ctx == nil {
+		return fields
+	}
+	if rid, ok := ctx.Value(RequestIdCtxKey).(string); ok && rid != "" {
+		fields = append(fields, zap.String("request_id", rid))
+	}
+	if sc := trace.SpanContextFromContext(ctx); sc.IsValid() {
+		fields = append(fields, zap.String("trace_id", sc.TraceID().String()), zap.String("span_id", sc.SpanID().String()))
+	}
+	if userID, ok := ctx.Value(userIDCtxKey).(int); ok && userID != 0 {
+		fields = append(fields, zap.Int("user_id", userID))
+	}
+	if operation, ok := ctx.Value(operationCtxKey).(string); ok && operation != "" {
+		fields = append(fields, zap.String("operation", operation))
+	}
+	return fields
+}
+
+// FromContext returns the logger enriched with the fields found in the context
+func FromContext(ctx context.Context) *zap.Logger {
+	return base.With(Fields(ctx)...)
+}
+
+func Debug(ctx context.Context, msg string, fields ...zap.Field) {
+	FromContext(ctx).WithOptions(zap.AddCallerSkip(1)).Debug(msg, fields...)
+}
+
+func Info(ctx context.Context, msg string, fields ...zap.Field) {
+	FromContext(ctx).WithOptions(zap.AddCallerSkip(1)).Info(msg, fields...)
+}
+
+func Warn(ctx context.Context, msg string, fields ...zap.Field) {
+	FromContext(ctx).WithOptions(zap.AddCallerSkip(1)).Warn(msg, fields...)
+}
 
+func Error(ctx context.Context, msg string, fields ...zap.Field) {
+	FromContext(ctx).WithOptions(zap.AddCallerSkip(1)).Error(msg, fields...)
 }
@@ -3,153 +3,172 @@ package zaplog
 import (
 	"context"
 	"fmt"
-	"os"
+	"net/http"
+	"net/http/httptest"
+	"strings"
 	"testing"
 
 	"github.com/agiledragon/gomonkey/v2"
 	"github.com/stretchr/testify/assert"
+	"go.opentelemetry.io/otel/trace"
 	"go.uber.org/zap"
 	"go.uber.org/zap/zapcore"
 	"go.uber.org/zap/zaptest/observer"
 )
 
 const (
-	ErrorFromProduction = "Error production"
-	ErrMsgProduction    = "ZAP_NEW_PRODUCTION_ERROR"
-	InformationTest     = "test info"
-	InfoMessage         = "This is an info log"
-	DebugMessage        = "This is a debug log"
-	NewCase             = "New Case"
+	ErrorFromBuild  = "Error build"
+	ErrMsgBuild     = "ZAP_BUILD_ERROR"
+	InformationTest = "test info"
+	InfoMessage     = "This is an info log"
+	ContextCase     = "Context fields"
 )
 
-type SugaredLogger struct {
-	*zap.SugaredLogger
+func observe(level zapcore.Level) *observer.ObservedLogs {
+	observedZapCore, observedLogs := observer.New(level)
+	_ = SetLogger(zap.New(observedZapCore).Sugar())
+	return observedLogs
 }
 
-func TestInfo(t *testing.T) {
-	type args struct {
-		c   context.Context
-		msg string
+func TestLevels(t *testing.T) {
+	tests := []struct {
+		name  string
+		log   func(ctx context.Context, msg string, fields ...zap.Field)
+		level zapcore.Level
+	}{
+		{name: "Debug", log: Debug, level: zap.DebugLevel},
+		{name: "Info", log: Info, level: zap.InfoLevel},
+		{name: "Warn", log: Warn, level: zap.WarnLevel},
+		{name: "Error", log: Error, level: zap.ErrorLevel},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			observedLogs := observe(zap.DebugLevel)
+			tt.log(context.Background(), InfoMessage, zap.String("key", "value"))
+			assert.Equal(t, 1, observedLogs.Len())
+			log := observedLogs.All()[0]
+			assert.Equal(t, InfoMessage, log.Message)
+			assert.Equal(t, tt.level, log.Level)
+			assert.Equal(t, map[string]interface{}{"key": "value"}, log.ContextMap())
+		})
 	}
+}
+
+func TestFields(t *testing.T) {
+	traceID, _ := trace.TraceIDFromHex("4bf92f3577b34da6a3ce929d0e0e4736")
+	spanID, _ := trace.SpanIDFromHex("00f067aa0ba902b7")
+	spanCtx := trace.NewSpanContext(trace.SpanContextConfig{TraceID: traceID, SpanID: spanID})
 
 	tests := []struct {
 		name string
-		args args
+		ctx  context.Context
+		want map[string]interface{}
 	}{
 		{
 			name: InformationTest,
-			args: args{
-				c:   context.Background(),
-				msg: InfoMessage,
-			},
+			ctx:  context.Background(),
+			want: map[string]interface{}{},
 		},
 		{
-			name: ErrorFromProduction,
-			args: args{
-				c:   context.Background(),
-				msg: InfoMessage,
-			},
-		},
-		{
-			name: NewCase,
-			args: args{
-				c:   context.Background(),
-				msg: InfoMessage,
+			name: ContextCase,
+			ctx: WithOperation(
+				WithUserID(
+					trace.ContextWithSpanContext(
+						context.WithValue(context.Background(), RequestIdCtxKey, "rid"),
+						spanCtx,
+					), 1),
+				"me"),
+			want: map[string]interface{}{
+				"request_id": "rid",
+				"trace_id":   traceID.String(),
+				"span_id":    spanID.String(),
+				"user_id":    int64(1),
+				"operation":  "me",
 			},
 		},
 	}
 	for _, tt := range tests {
 		t.Run(tt.name, func(t *testing.T) {
-
-			observedZapCore, observedLogs := observer.New(zap.InfoLevel)
-			observedLogger := zap.New(observedZapCore).Sugar()
-
-			_ = SetLogger(observedLogger)
-			Info(tt.args.c, tt.args.msg)
-			assert.Equal(t, 1, observedLogs.Len())
-			log := observedLogs.All()[0]
-			assert.Equal(t, fmt.Sprintf("\nRequest-ID: <nil>\n[%s]\n", tt.args.msg), log.Message)
-			assert.Equal(t, zapcore.Level(0), log.Level)
+			observedLogs := observe(zap.InfoLevel)
+			Info(tt.ctx, InfoMessage)
+			assert.Equal(t, tt.want, observedLogs.All()[0].ContextMap())
 		})
 	}
 }
 
-func TestDebug(t *testing.T) {
-	type args struct {
-		c   context.Context
-		msg string
-	}
+func TestConfigure(t *testing.T) {
+	defer Level.SetLevel(zap.InfoLevel)
 	tests := []struct {
-		name string
-		args args
+		name    string
+		level   string
+		format  string
+		want    zapcore.Level
+		wantErr bool
 	}{
 		{
-			name: InformationTest,
-			args: args{
-				c:   context.Background(),
-				msg: DebugMessage,
-			},
+			name:   "Success",
+			level:  "debug",
+			format: FormatConsole,
+			want:   zap.DebugLevel,
+		},
+		{
+			name:    "Failure_InvalidLevel",
+			level:   "verbose",
+			want:    zap.InfoLevel,
+			wantErr: true,
+		},
+		{
+			name:    "Failure_InvalidFormat",
+			level:   "warn",
+			format:  "xml",
+			want:    zap.WarnLevel,
+			wantErr: true,
 		},
 	}
 	for _, tt := range tests {
 		t.Run(tt.name, func(t *testing.T) {
-
-			observedZapCore, observedLogs := observer.New(zap.DebugLevel)
-			observedLogger := zap.New(observedZapCore).Sugar()
-			_ = SetLogger(observedLogger)
-			Debug(tt.args.c, tt.args.msg)
-			assert.Equal(t, 1, observedLogs.Len())
-			log := observedLogs.All()[0]
-			assert.Equal(t, fmt.Sprintf("\nRequest-ID: <nil>\n[%s]\n", tt.args.msg), log.Message)
-			assert.Equal(t, zapcore.Level(-1), log.Level)
+			Level.SetLevel(zap.InfoLevel)
+			err := Configure(tt.level, tt.format)
+			assert.Equal(t, tt.wantErr, err != nil)
+			assert.Equal(t, tt.want, Level.Level())
 		})
 	}
 }
 
+func TestLevelHandler(t *testing.T) {
+	defer Level.SetLevel(zap.InfoLevel)
+	res := httptest.NewRecorder()
+	Level.ServeHTTP(res, httptest.NewRequest(http.MethodPut, "/log/level", strings.NewReader(`{"level":"error"}`)))
+
+	assert.Equal(t, http.StatusOK, res.Code)
+	assert.Equal(t, zap.ErrorLevel, Level.Level())
+}
+
 func TestInitLogger(t *testing.T) {
-	mockZapLog := zap.Logger{}
-	mockZapLoggerSugar := mockZapLog.Sugar()
 	tests := []struct {
 		name     string
 		panicErr bool
-		res      *zap.SugaredLogger
 	}{
 		{
 			name: "production",
-			res:  mockZapLoggerSugar,
 		},
 		{
-			name:     "panic error",
+			name:     ErrorFromBuild,
 			panicErr: true,
 		},
 	}
 
 	for _, tt := range tests {
-
 		t.Run(tt.name, func(t *testing.T) {
-
-			patchEnv := gomonkey.ApplyFunc(os.Getenv, func(key string) string {
-				return "production"
-			})
-			defer patchEnv.Reset()
-
-			patchProduction := gomonkey.ApplyFunc(zap.NewProduction, func(options ...zap.Option) (*zap.Logger, error) {
-				if tt.panicErr {
-					return &mockZapLog, fmt.Errorf(ErrMsgProduction)
-				} else {
-					return &mockZapLog, nil
-				}
-			})
-
-			defer patchProduction.Reset()
-
 			if tt.panicErr {
+				patch := gomonkey.ApplyFunc(newLogger, func(format string) (*zap.Logger, error) {
+					return nil, fmt.Errorf(ErrMsgBuild)
+				})
+				defer patch.Reset()
 				assert.Panics(t, func() { InitLogger() })
 			} else {
-				response := InitLogger()
-				assert.Equal(t, tt.res, response)
+				assert.NotNil(t, InitLogger())
 			}
-
 		})
 	}
 }
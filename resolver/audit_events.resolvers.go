@@ -0,0 +1,60 @@
+package resolver
+
+// This file will be automatically regenerated based on the schema, any resolver implementations
+// will be copied through when generating and any unknown code will be moved to the end.
+
+import (
+	"context"
+	"fmt"
+	"go-template/daos"
+	"go-template/gqlmodels"
+	"go-template/pkg/utl/cnvrttogql"
+	"go-template/pkg/utl/resultwrapper"
+	"strconv"
+	"time"
+
+	null "github.com/volatiletech/null/v8"
+)
+
+// AuditEvents is the resolver for the auditEvents field.
+func (r *queryResolver) AuditEvents(
+	ctx context.Context,
+	filter *gqlmodels.AuditEventFilter,
+	pagination *gqlmodels.AuditEventPagination,
+) (*gqlmodels.AuditEventsPayload, error) {
+	var where daos.AuditEventFilter
+	if filter != nil {
+		where.Action = null.StringFromPtr(filter.Action)
+		where.TargetType = null.StringFromPtr(filter.TargetType)
+		where.TargetID = null.StringFromPtr(filter.TargetID)
+		if filter.ActorID != nil {
+			actorID, err := strconv.Atoi(*filter.ActorID)
+			if err != nil {
+				return nil, fmt.Errorf("invalid actor id %s", *filter.ActorID)
+			}
+			where.ActorID = null.IntFrom(actorID)
+		}
+		if filter.From != nil {
+			where.From = null.TimeFrom(time.UnixMilli(int64(*filter.From)))
+		}
+		if filter.To != nil {
+			where.To = null.TimeFrom(time.UnixMilli(int64(*filter.To)))
+		}
+	}
+	limit, page := defaultAuditEventsLimit, 0
+	if pagination != nil {
+		if pagination.Limit > 0 {
+			limit = pagination.Limit
+		}
+		page = pagination.Page
+	}
+
+	events, count, err := r.AuditRepo.FindAllWithCount(where, limit, page*limit, ctx)
+	if err != nil {
+		return nil, resultwrapper.ResolverSQLError(err, "data")
+	}
+	return &gqlmodels.AuditEventsPayload{
+		Total:       int(count),
+		AuditEvents: cnvrttogql.AuditEventsToGraphQlAuditEvents(events),
+	}, nil
+}
@@ -0,0 +1,120 @@
+package resolver_test
+
+import (
+	"context"
+	"fmt"
+	"testing"
+	"time"
+
+	"go-template/daos"
+	fm "go-template/gqlmodels"
+	"go-template/pkg/utl/convert"
+	"go-template/resolver"
+	"go-template/testutls"
+
+	"github.com/golang/mock/gomock"
+	"github.com/stretchr/testify/assert"
+	"github.com/volatiletech/null/v8"
+)
+
+func TestAuditEvents(t *testing.T) {
+	createdAt := time.Date(2023, 1, 1, 0, 0, 0, 0, time.UTC)
+	actorID := "1"
+	invalidID := "one"
+	from := int(createdAt.UnixMilli())
+	cases := []struct {
+		name       string
+		filter     *fm.AuditEventFilter
+		pagination *fm.AuditEventPagination
+		daoErr     error
+		wantFilter daos.AuditEventFilter
+		wantLimit  int
+		wantOffset int
+		wantResp   *fm.AuditEventsPayload
+		wantErr    bool
+	}{
+		{
+			name:      "Success_DefaultPagination",
+			wantLimit: 50,
+			wantResp: &fm.AuditEventsPayload{
+				Total: 1,
+				AuditEvents: []*fm.AuditEvent{{
+					ID:         "7",
+					Action:     "user_updated",
+					ActorID:    &actorID,
+					TargetType: "user",
+					TargetID:   convert.StringToPointerString("2"),
+					Diff:       convert.StringToPointerString(`{"address":{"to":"Pune"}}`),
+					CreatedAt:  from,
+				}},
+			},
+		},
+		{
+			name: "Success_Filter",
+			filter: &fm.AuditEventFilter{
+				Action:  convert.StringToPointerString("user_updated"),
+				ActorID: &actorID,
+				From:    &from,
+			},
+			pagination: &fm.AuditEventPagination{Limit: 10, Page: 2},
+			wantFilter: daos.AuditEventFilter{
+				Action:  null.StringFrom("user_updated"),
+				ActorID: null.IntFrom(1),
+				From:    null.TimeFrom(time.UnixMilli(int64(from))),
+			},
+			wantLimit:  10,
+			wantOffset: 20,
+			wantResp: &fm.AuditEventsPayload{
+				Total: 1,
+				AuditEvents: []*fm.AuditEvent{{
+					ID:         "7",
+					Action:     "user_updated",
+					ActorID:    &actorID,
+					TargetType: "user",
+					TargetID:   convert.StringToPointerString("2"),
+					Diff:       convert.StringToPointerString(`{"address":{"to":"Pune"}}`),
+					CreatedAt:  from,
+				}},
+			},
+		},
+		{
+			name:    "Failure_InvalidActorID",
+			filter:  &fm.AuditEventFilter{ActorID: &invalidID},
+			wantErr: true,
+		},
+		{
+			name:      "Failure_Dao",
+			daoErr:    fmt.Errorf("connection refused"),
+			wantLimit: 50,
+			wantErr:   true,
+		},
+	}
+	for _, tt := range cases {
+		t.Run(tt.name, func(t *testing.T) {
+			// the events are read unless the filter is invalid
+			ctrl := gomock.NewController(t)
+			audits := testutls.NewMockAuditEventRepository(ctrl)
+			if tt.wantLimit != 0 {
+				events := []daos.AuditEvent{{
+					ID:         7,
+					Action:     "user_updated",
+					ActorID:    null.IntFrom(1),
+					TargetType: "user",
+					TargetID:   null.StringFrom("2"),
+					Diff:       null.JSONFrom([]byte(`{"address":{"to":"Pune"}}`)),
+					CreatedAt:  createdAt,
+				}}
+				if tt.daoErr != nil {
+					events = nil
+				}
+				audits.EXPECT().FindAllWithCount(tt.wantFilter, tt.wantLimit, tt.wantOffset, gomock.Any()).
+					Return(events, int64(len(events)), tt.daoErr)
+			}
+
+			r := resolver.Resolver{AuditRepo: audits}
+			resp, err := r.Query().AuditEvents(context.Background(), tt.filter, tt.pagination)
+			assert.Equal(t, tt.wantErr, err != nil)
+			assert.Equal(t, tt.wantResp, resp)
+		})
+	}
+}
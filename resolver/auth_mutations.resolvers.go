@@ -5,57 +5,75 @@ package resolver
 
 import (
 	"context"
+	"database/sql"
+	"errors"
 	"fmt"
 	"go-template/daos"
 	"go-template/gqlmodels"
-	"go-template/internal/config"
+	"go-template/internal/audit"
 	"go-template/internal/middleware/auth"
-	"go-template/internal/service"
 	"go-template/pkg/utl/convert"
 	"go-template/pkg/utl/resultwrapper"
+	"strconv"
 
 	null "github.com/volatiletech/null/v8"
 )
 
 // Login is the resolver for the login field.
 func (r *mutationResolver) Login(ctx context.Context, username string, password string) (*gqlmodels.LoginResponse, error) {
-	u, err := daos.FindUserByUserName(username, ctx)
-	if err != nil {
-		return nil, err
-	}
-	// loading configurations
-	cfg, err := loadConfig()
-	if err != nil {
-		return nil, err
-	}
-	// creating new secure and token generation service
-	sec := service.Secure(cfg)
-	tg, err := service.JWT(cfg)
-	if err != nil {
-		return nil, fmt.Errorf("error in creating auth service ")
-	}
-
-	if !u.Password.Valid || (!sec.HashMatchesPassword(u.Password.String, password)) {
-		return nil, fmt.Errorf("username or password does not exist ")
-	}
-
-	if !u.Active.Valid || (!u.Active.Bool) {
-		return nil, resultwrapper.ErrUnauthorized
-	}
-
-	token, err := tg.GenerateToken(u)
-	if err != nil {
-		return nil, resultwrapper.ErrUnauthorized
+	var resp *gqlmodels.LoginResponse
+	// a failed attempt rolls the transaction back, so it is recorded afterwards
+	var failed *audit.Event
+	err := daos.WithTx(ctx, func(ctx context.Context) error {
+		failed = nil
+		u, err := r.UserRepo.FindByUserName(username, ctx)
+		if errors.Is(err, sql.ErrNoRows) {
+			failed = &audit.Event{
+				Action:     audit.LoginFailed,
+				TargetType: audit.TargetUser,
+				After:      map[string]string{"username": username},
+			}
+		}
+		if err != nil {
+			return err
+		}
+		if !u.Password.Valid || (!r.Secure.HashMatchesPassword(u.Password.String, password)) {
+			failed = &audit.Event{Action: audit.LoginFailed, TargetType: audit.TargetUser, TargetID: strconv.Itoa(u.ID)}
+			return fmt.Errorf("username or password does not exist ")
+		}
+
+		if !u.Active.Valid || (!u.Active.Bool) {
+			failed = &audit.Event{Action: audit.LoginFailed, TargetType: audit.TargetUser, TargetID: strconv.Itoa(u.ID)}
+			return resultwrapper.ErrUnauthorized
+		}
+
+		token, err := r.JWT.GenerateToken(u)
+		if err != nil {
+			return resultwrapper.ErrUnauthorized
+		}
+
+		refreshToken := r.Secure.Token(token)
+		u.Token = null.StringFrom(refreshToken)
+		_, err = r.UserRepo.Update(*u, ctx)
+		if err != nil {
+			return err
+		}
+		audit.Record(ctx, r.AuditRepo, audit.Event{
+			Action:     audit.LoginSucceeded,
+			ActorID:    u.ID,
+			TargetType: audit.TargetUser,
+			TargetID:   strconv.Itoa(u.ID),
+		})
+		resp = &gqlmodels.LoginResponse{Token: token, RefreshToken: refreshToken}
+		return nil
+	})
+	if failed != nil {
+		audit.Record(ctx, r.AuditRepo, *failed)
 	}
-
-	refreshToken := sec.Token(token)
-	u.Token = null.StringFrom(refreshToken)
-	_, err = daos.UpdateUser(*u, ctx)
 	if err != nil {
 		return nil, err
 	}
-
-	return &gqlmodels.LoginResponse{Token: token, RefreshToken: refreshToken}, nil
+	return resp, nil
 }
 
 // ChangePassword is the resolver for the changePassword field.
@@ -65,23 +83,16 @@ func (r *mutationResolver) ChangePassword(
 	newPassword string,
 ) (*gqlmodels.ChangePasswordResponse, error) {
 	userID := auth.UserIDFromContext(ctx)
-	u, err := daos.FindUserByID(userID, ctx)
+	u, err := r.UserRepo.FindByID(userID, ctx)
 	if err != nil {
 		return nil, resultwrapper.ResolverSQLError(err, "data")
 	}
 
-	// loading configurations
-	cfg, err := loadConfig()
-	if err != nil {
-		return nil, err
-	}
-	// creating new secure service
-	sec := service.Secure(cfg)
-	if !sec.HashMatchesPassword(convert.NullDotStringToString(u.Password), oldPassword) {
+	if !r.Secure.HashMatchesPassword(convert.NullDotStringToString(u.Password), oldPassword) {
 		return nil, fmt.Errorf("incorrect old password")
 	}
 
-	if !sec.Password(newPassword,
+	if !r.Secure.Password(newPassword,
 		convert.NullDotStringToString(u.FirstName),
 		convert.NullDotStringToString(u.LastName),
 		convert.NullDotStringToString(u.Username),
@@ -89,34 +100,38 @@ func (r *mutationResolver) ChangePassword(
 		return nil, fmt.Errorf("insecure password")
 	}
 
-	u.Password = null.StringFrom(sec.Hash(newPassword))
-	_, err = daos.UpdateUser(*u, ctx)
+	before := *u
+	u.Password = null.StringFrom(r.Secure.Hash(newPassword))
+	after, err := r.UserRepo.Update(*u, ctx)
 	if err != nil {
 		return nil, resultwrapper.ResolverSQLError(err, "new information")
 	}
+	audit.Record(ctx, r.AuditRepo, audit.Event{
+		Action:     audit.PasswordChanged,
+		TargetType: audit.TargetUser,
+		TargetID:   strconv.Itoa(u.ID),
+		Before:     before,
+		After:      after,
+	})
 	return &gqlmodels.ChangePasswordResponse{Ok: true}, err
 }
 
 // RefreshToken is the resolver for the refreshToken field.
 func (r *mutationResolver) RefreshToken(ctx context.Context, token string) (*gqlmodels.RefreshTokenResponse, error) {
-	user, err := daos.FindUserByToken(token, ctx)
+	user, err := r.UserRepo.FindByToken(token, ctx)
 	if err != nil {
 		return nil, resultwrapper.ResolverSQLError(err, "token")
 	}
-	// loading configurations
-	cfg, err := loadConfig()
-	if err != nil {
-		return nil, err
-	}
-	// creating new secure and token generation service
-	tg, err := service.JWT(cfg)
-	if err != nil {
-		return nil, fmt.Errorf("error in creating auth service ")
-	}
-	resp, err := tg.GenerateToken(user)
+	resp, err := r.JWT.GenerateToken(user)
 	if err != nil {
 		return nil, err
 	}
+	audit.Record(ctx, r.AuditRepo, audit.Event{
+		Action:     audit.TokenRefreshed,
+		ActorID:    user.ID,
+		TargetType: audit.TargetUser,
+		TargetID:   strconv.Itoa(user.ID),
+	})
 	return &gqlmodels.RefreshTokenResponse{Token: resp}, nil
 }
 
@@ -124,17 +139,3 @@ func (r *mutationResolver) RefreshToken(ctx context.Context, token string) (*gql
 func (r *Resolver) Mutation() gqlmodels.MutationResolver { return &mutationResolver{r} }
 
 type mutationResolver struct{ *Resolver }
-
-// !!! WARNING !!!
-// The code below was going to be deleted when updating resolvers. It has been copied here so you have
-// one last chance to move it out of harms way if you want. There are two reasons this happens:
-//   - When renaming or deleting a resolver the old code will be put in here. You can safely delete
-//     it when you're done.
-//   - You have helper methods in this file. Move them out to keep these resolver files clean.
-func loadConfig() (*config.Configuration, error) {
-	cfg, err := config.Load()
-	if err != nil {
-		return nil, fmt.Errorf("error in loading config")
-	}
-	return cfg, nil
-}
@@ -2,27 +2,24 @@ package resolver_test
 
 import (
 	"context"
+	"database/sql"
 	"database/sql/driver"
 	"fmt"
-	"reflect"
-	"regexp"
 	"strings"
 	"testing"
 
+	"go-template/daos"
 	fm "go-template/gqlmodels"
-	"go-template/internal/config"
-	"go-template/internal/jwt"
+	"go-template/internal/audit"
 	"go-template/internal/service"
 	"go-template/models"
-	"go-template/pkg/utl/convert"
 	"go-template/pkg/utl/resultwrapper"
 	"go-template/resolver"
 	"go-template/testutls"
 
-	"github.com/DATA-DOG/go-sqlmock"
-	"github.com/agiledragon/gomonkey/v2"
+	"github.com/golang/mock/gomock"
 	"github.com/stretchr/testify/assert"
-	"github.com/volatiletech/sqlboiler/v4/boil"
+	"github.com/volatiletech/null/v8"
 )
 
 const (
@@ -62,6 +59,16 @@ const (
 	ReqToken                   = "refresh_token"
 )
 
+// loginUser returns the user found by username in the login tests
+func loginUser(hash string, active bool) *models.User {
+	return &models.User{
+		ID:       testutls.MockID,
+		Password: null.StringFrom(hash),
+		Active:   null.BoolFrom(active),
+		RoleID:   null.IntFrom(1),
+	}
+}
+
 func TestLogin(
 	t *testing.T,
 ) {
@@ -70,11 +77,16 @@ func TestLogin(
 		Password string
 	}
 	cases := []struct {
-		name     string
-		req      args
-		wantResp *fm.LoginResponse
-		wantErr  bool
-		err      error
+		name      string
+		req       args
+		user      *models.User
+		findErr   error
+		update    bool
+		updateErr error
+		audit     string
+		wantResp  *fm.LoginResponse
+		wantErr   bool
+		err       error
 	}{
 		{
 			name: ErrorFindingUser,
@@ -82,6 +94,7 @@ func TestLogin(
 				UserName: TestUsername,
 				Password: TestPassword,
 			},
+			findErr: fmt.Errorf(ErrorMsgFindingUser),
 			wantErr: true,
 			err:     fmt.Errorf(ErrorMsgFindingUser),
 		},
@@ -91,6 +104,8 @@ func TestLogin(
 				UserName: testutls.MockEmail,
 				Password: TestPassword,
 			},
+			user:    loginUser(TestPasswordHash, true),
+			audit:   audit.LoginFailed,
 			wantErr: true,
 			err:     fmt.Errorf(ErrorMsgPasswordValidation),
 		},
@@ -100,33 +115,18 @@ func TestLogin(
 				UserName: testutls.MockEmail,
 				Password: OldPassword,
 			},
+			user:    loginUser(OldPasswordHash, false),
+			audit:   audit.LoginFailed,
 			wantErr: true,
 			err:     resultwrapper.ErrUnauthorized,
 		},
-		{
-			name: ErrorFromConfig,
-			req: args{
-				UserName: testutls.MockEmail,
-				Password: OldPassword,
-			},
-			wantErr: true,
-			err:     fmt.Errorf(ErrorMsgFromConfig),
-		},
-		{
-			name: ErrorFromJwt,
-			req: args{
-				UserName: testutls.MockEmail,
-				Password: OldPassword,
-			},
-			wantErr: true,
-			err:     fmt.Errorf(ErrorMsgFromJwt),
-		},
 		{
 			name: ErrorFromGenerateToken,
 			req: args{
 				UserName: testutls.MockEmail,
 				Password: OldPassword,
 			},
+			user:    loginUser(OldPasswordHash, true),
 			wantErr: true,
 			err:     resultwrapper.ErrUnauthorized,
 		},
@@ -136,8 +136,11 @@ func TestLogin(
 				UserName: testutls.MockEmail,
 				Password: OldPassword,
 			},
-			wantErr: true,
-			err:     fmt.Errorf(ErrorMsgfromUpdateUser),
+			user:      loginUser(OldPasswordHash, true),
+			update:    true,
+			updateErr: fmt.Errorf(ErrorMsgfromUpdateUser),
+			wantErr:   true,
+			err:       fmt.Errorf(ErrorMsgfromUpdateUser),
 		},
 		{
 			name: SuccessCase,
@@ -145,6 +148,9 @@ func TestLogin(
 				UserName: testutls.MockEmail,
 				Password: OldPassword,
 			},
+			user:   loginUser(OldPasswordHash, true),
+			update: true,
+			audit:  audit.LoginSucceeded,
 			wantResp: &fm.LoginResponse{
 				Token:        "jwttokenstring",
 				RefreshToken: TestToken,
@@ -152,124 +158,50 @@ func TestLogin(
 		},
 	}
 
-	// Create a new instance of the resolver
-	resolver1 := resolver.Resolver{}
 	for _, tt := range cases {
 		t.Run(
 			tt.name,
 			func(t *testing.T) {
-
-				// Apply mock functions using go-monkey for cases where certain errors are expected
-				// and defer their resetting
-
-				// Handle the case where there is an error loading the config
-				if tt.name == ErrorFromConfig {
-					patch := gomonkey.ApplyFunc(config.Load, func() (*config.Configuration, error) {
-						return nil, fmt.Errorf(ErrorMsgFromConfig)
-					})
-					defer patch.Reset()
-				}
-
-				// Initialize a new JWT service
-				var tg jwt.Service
-
-				// Handle the case where there is an error creating the JWT service
-				if tt.name == ErrorFromJwt {
-					patch := gomonkey.ApplyFunc(service.JWT, func(cfg *config.Configuration) (jwt.Service, error) {
-
-						return tg, fmt.Errorf(ErrorMsgFromJwt)
-
-					})
-					defer patch.Reset()
-				}
-
-				patch := gomonkey.ApplyFunc(tg.GenerateToken, func(u *models.User) (string, error) {
-					if tt.name == ErrorFromGenerateToken {
-						return "", resultwrapper.ErrUnauthorized
-					} else {
-						return "", nil
-					}
-
-				})
-				defer patch.Reset()
-
-				// Load the environment variables from a .env file
-				err := config.LoadEnvWithFilePrefix(convert.StringToPointerString("./../"))
-				if err != nil {
-					fmt.Print("error loading .env file")
-				}
-				// Create a mock SQL database connection
-				db, mock, err := sqlmock.New()
-				if err != nil {
-					t.Fatalf("an error '%s' was not expected when opening a stub database connection", err)
-				}
-
-				// Inject mock instance into boil.
-				oldDB := boil.GetDB()
-				defer func() {
-					db.Close()
-					boil.SetDB(oldDB)
-				}()
-				boil.SetDB(db)
-
-				// Handle the case where there is an error finding the user
-				if tt.name == ErrorFindingUser {
-					// get user by username
-					mock.ExpectQuery(regexp.QuoteMeta(`SELECT "users".* FROM "users" WHERE (username=$1) LIMIT 1;`)).
-						WithArgs().
-						WillReturnError(fmt.Errorf(ErrorMsgFindingUser))
-				}
-
-				// Handle the case where there is an error validating the password
-				if tt.name == ErrorPasswordValidation {
-					// get user by username
-					rows := sqlmock.NewRows([]string{"id", "password", "active", "role_id"}).
-						AddRow(testutls.MockID, TestPasswordHash, true, 1)
-					mock.ExpectQuery(regexp.QuoteMeta(`SELECT "users".* FROM "users"  WHERE (username=$1) LIMIT 1;`)).
-						WithArgs().
-						WillReturnRows(rows)
+				ctrl := gomock.NewController(t)
+				users := testutls.NewMockUserRepository(ctrl)
+				audits := testutls.NewMockAuditEventRepository(ctrl)
+				users.EXPECT().FindByUserName(tt.req.UserName, gomock.Any()).Return(tt.user, tt.findErr)
+				if tt.update {
+					users.EXPECT().Update(gomock.Any(), gomock.Any()).DoAndReturn(
+						func(user models.User, ctx context.Context) (models.User, error) {
+							return user, tt.updateErr
+						})
 				}
 
-				// Handle the case where the user is not active
-				if tt.name == ErrorActiveStatus {
-					// get user by username
-					rows := sqlmock.NewRows([]string{"id", "password", "active", "role_id"}).
-						AddRow(testutls.MockID, OldPasswordHash, false, 1)
-					mock.ExpectQuery(regexp.QuoteMeta(`SELECT "users".* FROM "users"  WHERE (username=$1) LIMIT 1;`)).
-						WithArgs().
-						WillReturnRows(rows)
+				// Create a new instance of the resolver with a token generator failing when expected
+				tg := testutls.FakeTokenGenerator{Token: "jwttokenstring"}
+				if tt.name == ErrorFromGenerateToken {
+					tg.Err = resultwrapper.ErrUnauthorized
 				}
-
-				// get user by username
-				rows := sqlmock.NewRows([]string{"id", "password", "active", "role_id"}).
-					AddRow(testutls.MockID, OldPasswordHash, true, 1)
-				mock.ExpectQuery(regexp.QuoteMeta(`SELECT "users".* FROM "users"  WHERE (username=$1) LIMIT 1;`)).
-					WithArgs().
-					WillReturnRows(rows)
-
-				// Apply mock behavior for a successful query result
-				if tt.name == SuccessCase || tt.name == ErrorUpdateUser {
-					rows := sqlmock.NewRows([]string{"id", "name"}).
-						AddRow(1, "ADMIN")
-					mock.ExpectQuery(regexp.QuoteMeta(`SELECT "roles".* FROM "roles" WHERE ("id" = $1) LIMIT 1`)).
-						WithArgs([]driver.Value{1}...).
-						WillReturnRows(rows)
+				resolver1 := resolver.Resolver{
+					UserRepo:  users,
+					AuditRepo: audits,
+					Secure:    service.Secure(testutls.MockConfig()),
+					JWT:       tg,
 				}
 
-				// Handle the case where there is an error while updating the user
-				if tt.name == ErrorUpdateUser {
-					fmt.Println(tt.name, " test name ")
-					mock.ExpectExec(regexp.QuoteMeta(`UPDATE "users" `)).WillReturnError(fmt.Errorf(ErrorMsgfromUpdateUser))
+				// the user is found and updated in a transaction, the audit event is written in a savepoint
+				mock, db, _ := testutls.SetupMockDB(t)
+				defer db.Close()
+				mock.ExpectBegin()
+				if tt.wantErr {
+					mock.ExpectRollback()
 				} else {
-					fmt.Println(tt.name, " test name ")
-					result := driver.Result(driver.RowsAffected(1))
-					mock.ExpectExec(regexp.QuoteMeta(`UPDATE "users" `)).WillReturnResult(result)
+					mock.ExpectExec("SAVEPOINT sp_1").WillReturnResult(driver.ResultNoRows)
+					mock.ExpectExec("RELEASE SAVEPOINT sp_1").WillReturnResult(driver.ResultNoRows)
+					mock.ExpectCommit()
+				}
+				if tt.audit != "" {
+					testutls.ExpectAuditEvent(audits, tt.audit)
 				}
-
-				c := context.Background()
 
 				// Call the login mutation with the given arguments and check the response and error against the expected values
-				response, err := resolver1.Mutation().Login(c, tt.req.UserName, tt.req.Password)
+				response, err := resolver1.Mutation().Login(context.Background(), tt.req.UserName, tt.req.Password)
 				if tt.wantResp != nil &&
 					response != nil {
 					tt.wantResp.RefreshToken = response.RefreshToken
@@ -281,14 +213,92 @@ func TestLogin(
 
 					// Assert that the expected error value matches the actual error value
 					assert.Equal(t, true, strings.Contains(err.Error(), tt.err.Error()))
-					assert.Equal(t, tt.wantErr, err != nil)
 				}
-
+				assert.Equal(t, tt.wantErr, err != nil)
+				assert.Nil(t, mock.ExpectationsWereMet())
 			},
 		)
 	}
 }
 
+func TestLoginAudit(t *testing.T) {
+	cases := []struct {
+		name     string
+		password string
+		user     *models.User
+		findErr  error
+		action   string
+		actorID  null.Int
+		targetID null.String
+		diff     null.JSON
+	}{
+		{
+			name:     "UnknownUser",
+			password: OldPassword,
+			findErr:  sql.ErrNoRows,
+			action:   audit.LoginFailed,
+			diff:     null.JSONFrom([]byte(`{"username":{"to":"wednesday"}}`)),
+		},
+		{
+			name:     "WrongPassword",
+			password: TestPassword,
+			user:     loginUser(OldPasswordHash, true),
+			action:   audit.LoginFailed,
+			targetID: null.StringFrom("1"),
+		},
+		{
+			name:     SuccessCase,
+			password: OldPassword,
+			user:     loginUser(OldPasswordHash, true),
+			action:   audit.LoginSucceeded,
+			actorID:  null.IntFrom(testutls.MockID),
+			targetID: null.StringFrom("1"),
+		},
+	}
+	for _, tt := range cases {
+		t.Run(tt.name, func(t *testing.T) {
+			ctrl := gomock.NewController(t)
+			users := testutls.NewMockUserRepository(ctrl)
+			users.EXPECT().FindByUserName(TestUsername, gomock.Any()).Return(tt.user, tt.findErr)
+			audits := testutls.NewMockAuditEventRepository(ctrl)
+			audits.EXPECT().Create(gomock.Any(), gomock.Any()).DoAndReturn(
+				func(event daos.AuditEvent, ctx context.Context) (daos.AuditEvent, error) {
+					assert.Equal(t, daos.AuditEvent{
+						Action:     tt.action,
+						ActorID:    tt.actorID,
+						TargetType: audit.TargetUser,
+						TargetID:   tt.targetID,
+						Diff:       tt.diff,
+					}, event)
+					return event, nil
+				})
+
+			// a successful login is recorded in its transaction, a failed one once the transaction is rolled back
+			mock, db, _ := testutls.SetupMockDB(t)
+			defer db.Close()
+			mock.ExpectBegin()
+			if tt.action == audit.LoginSucceeded {
+				users.EXPECT().Update(gomock.Any(), gomock.Any()).Return(*tt.user, nil)
+				mock.ExpectExec("SAVEPOINT sp_1").WillReturnResult(driver.ResultNoRows)
+				mock.ExpectExec("RELEASE SAVEPOINT sp_1").WillReturnResult(driver.ResultNoRows)
+				mock.ExpectCommit()
+			} else {
+				mock.ExpectRollback()
+			}
+
+			r := resolver.Resolver{
+				UserRepo:  users,
+				AuditRepo: audits,
+				Secure:    service.Secure(testutls.MockConfig()),
+				JWT:       testutls.FakeTokenGenerator{Token: "jwttokenstring"},
+			}
+			_, err := r.Mutation().Login(context.Background(), TestUsername, tt.password)
+			assert.Equal(t, tt.action == audit.LoginFailed, err != nil)
+			assert.Nil(t, mock.ExpectationsWereMet())
+		})
+	}
+}
+
 func TestChangePassword(
 	t *testing.T,
 ) {
@@ -299,10 +309,13 @@ func TestChangePassword(
 		NewPassword string
 	}
 	cases := []struct {
-		name     string
-		req      changeReq
-		wantResp *fm.ChangePasswordResponse
-		wantErr  bool
+		name      string
+		req       changeReq
+		findErr   error
+		update    bool
+		updateErr error
+		wantResp  *fm.ChangePasswordResponse
+		wantErr   bool
 	}{
 		{
 			name: ErrorFindingUser,
@@ -310,6 +323,7 @@ func TestChangePassword(
 				OldPassword: TestPassword,
 				NewPassword: NewPassword,
 			},
+			findErr: fmt.Errorf(ErrorMsgFindingUser),
 			wantErr: true,
 		},
 		{
@@ -334,23 +348,17 @@ func TestChangePassword(
 				OldPassword: OldPassword,
 				NewPassword: NewPassword,
 			},
-			wantErr: true,
+			update:    true,
+			updateErr: fmt.Errorf(ErrorMsgfromUpdateUser),
+			wantErr:   true,
 		},
-		{
-			name: ErrorFromConfig,
-			req: changeReq{
-				OldPassword: OldPassword,
-				NewPassword: testutls.MockEmail,
-			},
-			wantErr: true,
-		},
-
 		{
 			name: SuccessCase,
 			req: changeReq{
 				OldPassword: OldPassword,
 				NewPassword: NewPassword,
 			},
+			update: true,
 			wantResp: &fm.ChangePasswordResponse{
 				Ok: true,
 			},
@@ -358,63 +366,35 @@ func TestChangePassword(
 		},
 	}
 
-	// Create a new instance of the resolver
-	resolver1 := resolver.Resolver{}
 	for _, tt := range cases {
 		t.Run(
 			tt.name,
 			func(t *testing.T) {
-
-				// Handle the case where there is an error while loading the configuration
-				if tt.name == ErrorFromConfig {
-					patch := gomonkey.ApplyFunc(config.Load, func() (*config.Configuration, error) {
-						return nil, fmt.Errorf("error in loading config")
-					})
-					defer patch.Reset()
-				}
-
-				// Load environment variables
-				err := config.LoadEnv()
-				if err != nil {
-					fmt.Print("error loading .env file")
-				}
-
-				// Create a mock SQL database connection
-				db, mock, err := sqlmock.New()
-				if err != nil {
-					t.Fatalf("an error '%s' was not expected when opening a stub database connection", err)
+				ctrl := gomock.NewController(t)
+				users := testutls.NewMockUserRepository(ctrl)
+				audits := testutls.NewMockAuditEventRepository(ctrl)
+				user := &models.User{
+					ID:       testutls.MockID,
+					Email:    null.StringFrom(testutls.MockEmail),
+					Password: null.StringFrom(OldPasswordHash),
 				}
-				// Inject mock instance into boil.
-				oldDB := boil.GetDB()
-				defer func() {
-					db.Close()
-					boil.SetDB(oldDB)
-				}()
-				boil.SetDB(db)
-
-				// Handle the case where there is an error while finding the user
-				if tt.name == ErrorFindingUser {
-					mock.ExpectQuery(regexp.QuoteMeta(`select * from "users" where "id"=$1`)).
-						WithArgs().
-						WillReturnError(fmt.Errorf(""))
+				if tt.findErr != nil {
+					user = nil
 				}
-				// Expect a query to get the user by ID to return a row with mock data entered
-				rows := sqlmock.NewRows([]string{"id", "email", "password"}).
-					AddRow(testutls.MockID, testutls.MockEmail, OldPasswordHash)
-				mock.ExpectQuery(regexp.QuoteMeta(`select * from "users" where "id"=$1`)).
-					WithArgs().
-					WillReturnRows(rows)
-
-					// Handle the case where the password update is successful
-				if tt.name == SuccessCase {
-					result := driver.Result(driver.RowsAffected(1))
-					mock.ExpectExec(regexp.QuoteMeta(`UPDATE "users" `)).WillReturnResult(result)
+				users.EXPECT().FindByID(gomock.Any(), gomock.Any()).Return(user, tt.findErr)
+				if tt.update {
+					users.EXPECT().Update(gomock.Any(), gomock.Any()).DoAndReturn(
+						func(user models.User, ctx context.Context) (models.User, error) {
+							// the new password is hashed before it is stored
+							assert.NotEqual(t, OldPasswordHash, user.Password.String)
+							return user, tt.updateErr
+						})
 				}
+				resolver1 := resolver.Resolver{UserRepo: users, AuditRepo: audits, Secure: service.Secure(testutls.MockConfig())}
 
-				// Handle the case where there is an error while updating the user's password
-				if tt.name == ErrorUpdateUser {
-
-					mock.ExpectExec(regexp.QuoteMeta(`UPDATE "users" `)).WillReturnError(fmt.Errorf("errrorr"))
+				// the password change is audited once it is stored
+				if tt.update && tt.updateErr == nil {
+					testutls.ExpectAuditEvent(audits, audit.PasswordChanged)
 				}
 
 				// Set up the context with the mock user
@@ -439,6 +419,7 @@ func TestRefreshToken(t *testing.T) {
 	cases := []struct {
 		name     string
 		req      string
+		findErr  error
 		wantResp *fm.RefreshTokenResponse
 		wantErr  bool
 		err      error
@@ -446,21 +427,10 @@ func TestRefreshToken(t *testing.T) {
 		{
 			name:    ErrorInvalidToken,
 			req:     TestToken,
+			findErr: fmt.Errorf(ErrorMsginvalidToken),
 			wantErr: true,
 			err:     fmt.Errorf(ErrorMsginvalidToken),
 		},
-		{
-			name:    ErrorFromConfig,
-			req:     ReqToken,
-			wantErr: true,
-			err:     fmt.Errorf(ErrorMsgFromConfig),
-		},
-		{
-			name:    ErrorFromJwt,
-			req:     ReqToken,
-			wantErr: true,
-			err:     fmt.Errorf(ErrorMsgFromJwt),
-		},
 		{
 			name:    ErrorFromGenerateToken,
 			req:     ReqToken,
@@ -476,83 +446,35 @@ func TestRefreshToken(t *testing.T) {
 		},
 	}
 
-	// Create a new instance of the resolver
-	resolver1 := resolver.Resolver{}
 	for _, tt := range cases {
 
 		t.Run(
 			tt.name,
 			func(t *testing.T) {
-
-				// Create a mock SQL database connection
-				db, mock, err := sqlmock.New()
-				if err != nil {
-					t.Fatalf("an error '%s' was not expected when opening a stub database connection", err)
+				ctrl := gomock.NewController(t)
+				users := testutls.NewMockUserRepository(ctrl)
+				audits := testutls.NewMockAuditEventRepository(ctrl)
+				var user *models.User
+				if tt.findErr == nil {
+					user = &models.User{
+						ID:     1,
+						Email:  null.StringFrom(testutls.MockEmail),
+						Token:  null.StringFrom(testutls.MockToken),
+						RoleID: null.IntFrom(1),
+					}
 				}
+				users.EXPECT().FindByToken(tt.req, gomock.Any()).Return(user, tt.findErr)
 
-				// Inject mock instance into boil.
-				oldDB := boil.GetDB()
-				defer func() {
-					db.Close()
-					boil.SetDB(oldDB)
-				}()
-				boil.SetDB(db)
-
-				// Handle the case where authentication token is invalid
-				if tt.name == ErrorInvalidToken {
-					mock.ExpectQuery(regexp.QuoteMeta(`SELECT "users".* FROM "users" WHERE (token=$1) LIMIT 1;`)).
-						WithArgs().
-						WillReturnError(fmt.Errorf(ErrorMsginvalidToken))
+				// Create a new instance of the resolver with a token generator failing when expected
+				tg := testutls.FakeTokenGenerator{Token: "token"}
+				if tt.name == ErrorFromGenerateToken {
+					tg.Err = resultwrapper.ErrUnauthorized
 				}
+				resolver1 := resolver.Resolver{UserRepo: users, AuditRepo: audits, JWT: tg}
 
-				// Handle the case where there is an error loading the config
-
-				patch := gomonkey.ApplyFunc(config.Load, func() (*config.Configuration, error) {
-					if tt.name == ErrorFromConfig {
-						return nil, fmt.Errorf("error in loading config")
-					} else {
-						return &config.Configuration{}, nil
-					}
-				})
-				defer patch.Reset()
-
-				//initialize a jwt service
-				tg := jwt.Service{}
-
-				// Handle the case where there is an error creating the JWT service
-				patchJWT := gomonkey.ApplyFunc(service.JWT, func(cfg *config.Configuration) (jwt.Service, error) {
-					if tt.name == ErrorFromJwt {
-						return tg, fmt.Errorf(ErrorMsgFromJwt)
-					} else {
-						return tg, nil
-					}
-				})
-				defer patchJWT.Reset()
-
-				// Handle the case where there is an error form token generation service
-				patchGenerateToken := gomonkey.ApplyMethod(reflect.TypeOf(tg), "GenerateToken",
-					func(jwt.Service, *models.User) (string, error) {
-						if tt.name == ErrorFromGenerateToken {
-							return "", resultwrapper.ErrUnauthorized
-						} else {
-							return "token", nil
-						}
-					})
-				defer patchGenerateToken.Reset()
-
-				// Expect a query to get the user by ID to return a row with mock data entered
-				rows := sqlmock.NewRows([]string{"id", "email", "token", "role_id"}).
-					AddRow(1, testutls.MockEmail, testutls.MockToken, 1)
-				mock.ExpectQuery(regexp.QuoteMeta(`SELECT "users".* FROM "users" WHERE (token=$1) LIMIT 1;`)).
-					WithArgs().
-					WillReturnRows(rows)
-
-				if tt.name == SuccessCase {
-					rows := sqlmock.NewRows([]string{"id", "name"}).
-						AddRow(1, "ADMIN")
-					mock.ExpectQuery(regexp.QuoteMeta(`SELECT "roles".* FROM "roles" WHERE ("id" = $1) LIMIT 1`)).
-						WithArgs([]driver.Value{1}...).
-						WillReturnRows(rows)
+				// the refresh is audited once the token is generated
+				if !tt.wantErr {
+					testutls.ExpectAuditEvent(audits, audit.TokenRefreshed)
 				}
 
 				// Set up the context with the mock user
@@ -573,7 +495,6 @@ func TestRefreshToken(t *testing.T) {
 					// Assert that the expected error value matches the actual error value
 					assert.Equal(t, true, strings.Contains(err.Error(), tt.err.Error()))
 				}
-
 			},
 		)
 	}
@@ -0,0 +1,125 @@
+package resolver
+
+// This file will be automatically regenerated based on the schema, any resolver implementations
+// will be copied through when generating and any unknown code will be moved to the end.
+
+import (
+	"context"
+	"database/sql"
+	"errors"
+	"fmt"
+	"go-template/daos"
+	"go-template/gqlmodels"
+	"go-template/internal/audit"
+	"go-template/internal/featureflags"
+	"go-template/pkg/utl/cnvrttogql"
+	"go-template/pkg/utl/resultwrapper"
+	"strconv"
+
+	null "github.com/volatiletech/null/v8"
+)
+
+// UpsertFeatureFlag is the resolver for the upsertFeatureFlag field.
+func (r *mutationResolver) UpsertFeatureFlag(
+	ctx context.Context,
+	input gqlmodels.FeatureFlagInput,
+) (*gqlmodels.FeatureFlagPayload, error) {
+	flag := daos.FeatureFlag{
+		Name:              input.Name,
+		Description:       null.StringFromPtr(input.Description),
+		Enabled:           input.Enabled,
+		RolloutPercentage: 100,
+		Roles:             input.Roles,
+	}
+	if input.RolloutPercentage != nil {
+		if *input.RolloutPercentage < 0 || *input.RolloutPercentage > 100 {
+			return nil, fmt.Errorf("rolloutPercentage must be between 0 and 100")
+		}
+		flag.RolloutPercentage = *input.RolloutPercentage
+	}
+	for _, id := range input.UserIds {
+		userID, err := strconv.Atoi(id)
+		if err != nil {
+			return nil, fmt.Errorf("invalid user id %s", id)
+		}
+		flag.UserIDs = append(flag.UserIDs, userID)
+	}
+
+	before, err := r.FlagRepo.FindByName(flag.Name, ctx)
+	if err != nil && !errors.Is(err, sql.ErrNoRows) {
+		return nil, resultwrapper.ResolverSQLError(err, "feature flag")
+	}
+	flag, err = r.FlagRepo.Upsert(flag, ctx)
+	if err != nil {
+		return nil, resultwrapper.ResolverSQLError(err, "feature flag")
+	}
+	event := audit.Event{
+		Action:     audit.FeatureFlagUpserted,
+		TargetType: audit.TargetFeatureFlag,
+		TargetID:   flag.Name,
+		After:      flag,
+	}
+	if before != nil {
+		event.Before = *before
+	}
+	audit.Record(ctx, r.AuditRepo, event)
+	// the other instances pick the change up on their next refresh
+	if err := r.Flags.Refresh(ctx); err != nil {
+		return nil, resultwrapper.ResolverSQLError(err, "feature flags")
+	}
+	return &gqlmodels.FeatureFlagPayload{FeatureFlag: cnvrttogql.FeatureFlagToGraphQlFeatureFlag(flag)}, nil
+}
+
+// DeleteFeatureFlag is the resolver for the deleteFeatureFlag field.
+func (r *mutationResolver) DeleteFeatureFlag(ctx context.Context, name string) (*gqlmodels.FeatureFlagDeletePayload, error) {
+	before, err := r.FlagRepo.FindByName(name, ctx)
+	if errors.Is(err, sql.ErrNoRows) {
+		return nil, fmt.Errorf("feature flag %s not found", name)
+	}
+	if err != nil {
+		return nil, resultwrapper.ResolverSQLError(err, "feature flag")
+	}
+	deleted, err := r.FlagRepo.Delete(name, ctx)
+	if err != nil {
+		return nil, resultwrapper.ResolverSQLError(err, "feature flag")
+	}
+	if deleted == 0 {
+		return nil, fmt.Errorf("feature flag %s not found", name)
+	}
+	audit.Record(ctx, r.AuditRepo, audit.Event{
+		Action:     audit.FeatureFlagDeleted,
+		TargetType: audit.TargetFeatureFlag,
+		TargetID:   name,
+		Before:     *before,
+	})
+	if err := r.Flags.Refresh(ctx); err != nil {
+		return nil, resultwrapper.ResolverSQLError(err, "feature flags")
+	}
+	return &gqlmodels.FeatureFlagDeletePayload{Name: name}, nil
+}
+
+// FeatureFlags is the resolver for the featureFlags field.
+func (r *queryResolver) FeatureFlags(ctx context.Context) ([]*gqlmodels.FeatureFlagValue, error) {
+	subject, err := r.flagSubject(ctx)
+	if err != nil {
+		return nil, resultwrapper.ResolverSQLError(err, "role")
+	}
+
+	values := []*gqlmodels.FeatureFlagValue{}
+	for _, flag := range r.Flags.All() {
+		values = append(values, &gqlmodels.FeatureFlagValue{
+			Name:    flag.Name,
+			Enabled: featureflags.Evaluate(flag, subject),
+		})
+	}
+	return values, nil
+}
+
+// FeatureFlagDefinitions is the resolver for the featureFlagDefinitions field.
+func (r *queryResolver) FeatureFlagDefinitions(ctx context.Context) ([]*gqlmodels.FeatureFlag, error) {
+	flags := []*gqlmodels.FeatureFlag{}
+	for _, flag := range r.Flags.All() {
+		flags = append(flags, cnvrttogql.FeatureFlagToGraphQlFeatureFlag(flag))
+	}
+	return flags, nil
+}
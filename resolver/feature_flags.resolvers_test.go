@@ -0,0 +1,248 @@
+package resolver_test
+
+import (
+	"context"
+	"database/sql"
+	"fmt"
+	"testing"
+
+	"go-template/daos"
+	fm "go-template/gqlmodels"
+	"go-template/internal/audit"
+	"go-template/internal/featureflags"
+	"go-template/internal/middleware/auth"
+	"go-template/models"
+	"go-template/resolver"
+	"go-template/testutls"
+
+	"github.com/golang/mock/gomock"
+	"github.com/stretchr/testify/assert"
+	"github.com/volatiletech/null/v8"
+)
+
+func mockFlags() *featureflags.Store {
+	store := featureflags.NewStore(func(ctx context.Context) ([]daos.FeatureFlag, error) {
+		return []daos.FeatureFlag{
+			{Name: "admin_dashboard", Enabled: true, RolloutPercentage: 100, Roles: []string{"SUPER_ADMIN"}},
+			{Name: "beta", Enabled: true, RolloutPercentage: 0, UserIDs: []int{testutls.MockID}},
+			{Name: "off", RolloutPercentage: 100},
+		}, nil
+	})
+	_ = store.Refresh(context.Background())
+	return store
+}
+
+func TestFeatureFlags(t *testing.T) {
+	cases := []struct {
+		name     string
+		role     string
+		roleErr  error
+		wantResp []*fm.FeatureFlagValue
+		wantErr  bool
+	}{
+		{
+			name: SuccessCase,
+			role: "USER",
+			wantResp: []*fm.FeatureFlagValue{
+				{Name: "admin_dashboard", Enabled: false},
+				{Name: "beta", Enabled: true},
+				{Name: "off", Enabled: false},
+			},
+		},
+		{
+			name: "Success_Admin",
+			role: "SUPER_ADMIN",
+			wantResp: []*fm.FeatureFlagValue{
+				{Name: "admin_dashboard", Enabled: true},
+				{Name: "beta", Enabled: true},
+				{Name: "off", Enabled: false},
+			},
+		},
+		{
+			name:    ErrorFromGetRole,
+			roleErr: fmt.Errorf("redis unavailable"),
+			wantErr: true,
+		},
+	}
+	for _, tt := range cases {
+		t.Run(tt.name, func(t *testing.T) {
+			r := resolver.Resolver{
+				Flags: mockFlags(),
+				Cache: &testutls.FakeCache{Role: &models.Role{Name: tt.role}, RoleErr: tt.roleErr},
+			}
+			ctx := context.WithValue(context.Background(), auth.UserCtxKey, testutls.MockUser())
+			resp, err := r.Query().FeatureFlags(ctx)
+			assert.Equal(t, tt.wantErr, err != nil)
+			assert.Equal(t, tt.wantResp, resp)
+		})
+	}
+}
+
+func TestFeatureFlagDefinitions(t *testing.T) {
+	r := resolver.Resolver{Flags: mockFlags()}
+	resp, err := r.Query().FeatureFlagDefinitions(context.Background())
+	assert.Nil(t, err)
+	assert.Equal(t, 3, len(resp))
+	assert.Equal(t, &fm.FeatureFlag{
+		Name:              "beta",
+		Enabled:           true,
+		RolloutPercentage: 0,
+		Roles:             []string{},
+		UserIds:           []string{fmt.Sprint(testutls.MockID)},
+	}, resp[1])
+}
+
+func TestUpsertFeatureFlag(t *testing.T) {
+	rollout := 50
+	invalidRollout := 120
+	cases := []struct {
+		name      string
+		req       fm.FeatureFlagInput
+		upsert    bool
+		before    *daos.FeatureFlag
+		notFound  bool
+		findErr   error
+		upsertErr error
+		wantResp  *fm.FeatureFlagPayload
+		wantDiff  string
+		wantErr   bool
+	}{
+		{
+			name: SuccessCase,
+			req: fm.FeatureFlagInput{
+				Name:              "beta",
+				Enabled:           true,
+				RolloutPercentage: &rollout,
+				Roles:             []string{"USER"},
+				UserIds:           []string{"1"},
+			},
+			upsert:   true,
+			notFound: true,
+			wantResp: &fm.FeatureFlagPayload{FeatureFlag: &fm.FeatureFlag{
+				Name:              "beta",
+				Enabled:           true,
+				RolloutPercentage: 50,
+				Roles:             []string{"USER"},
+				UserIds:           []string{"1"},
+			}},
+			wantDiff: `{"ID":{"to":1},"Name":{"to":"beta"},"Enabled":{"to":true},"RolloutPercentage":{"to":50},` +
+				`"Roles":{"to":["USER"]},"UserIDs":{"to":[1]}}`,
+		},
+		{
+			name:   "Success_Update",
+			req:    fm.FeatureFlagInput{Name: "beta", Enabled: true},
+			upsert: true,
+			before: &daos.FeatureFlag{ID: 1, Name: "beta", RolloutPercentage: 100},
+			wantResp: &fm.FeatureFlagPayload{FeatureFlag: &fm.FeatureFlag{
+				Name:              "beta",
+				Enabled:           true,
+				RolloutPercentage: 100,
+				Roles:             []string{},
+				UserIds:           []string{},
+			}},
+			wantDiff: `{"Enabled":{"from":false,"to":true}}`,
+		},
+		{
+			name:    "Failure_InvalidRollout",
+			req:     fm.FeatureFlagInput{Name: "beta", RolloutPercentage: &invalidRollout},
+			wantErr: true,
+		},
+		{
+			name:    "Failure_InvalidUserID",
+			req:     fm.FeatureFlagInput{Name: "beta", UserIds: []string{"one"}},
+			wantErr: true,
+		},
+		{
+			name:      "Failure_Upsert",
+			req:       fm.FeatureFlagInput{Name: "beta"},
+			upsert:    true,
+			upsertErr: fmt.Errorf("connection refused"),
+			wantErr:   true,
+		},
+		{
+			name:    "Failure_Find",
+			req:     fm.FeatureFlagInput{Name: "beta"},
+			upsert:  true,
+			findErr: fmt.Errorf("connection refused"),
+			wantErr: true,
+		},
+	}
+	for _, tt := range cases {
+		t.Run(tt.name, func(t *testing.T) {
+			ctrl := gomock.NewController(t)
+			flags := testutls.NewMockFeatureFlagRepository(ctrl)
+			audits := testutls.NewMockAuditEventRepository(ctrl)
+			if tt.upsert {
+				findErr := tt.findErr
+				if tt.notFound {
+					findErr = sql.ErrNoRows
+				}
+				flags.EXPECT().FindByName("beta", gomock.Any()).Return(tt.before, findErr)
+			}
+			if tt.upsert && tt.findErr == nil {
+				flags.EXPECT().Upsert(gomock.Any(), gomock.Any()).DoAndReturn(
+					func(flag daos.FeatureFlag, ctx context.Context) (daos.FeatureFlag, error) {
+						flag.ID = 1
+						return flag, tt.upsertErr
+					})
+			}
+			if tt.wantResp != nil {
+				audits.EXPECT().Create(gomock.Any(), gomock.Any()).DoAndReturn(
+					func(event daos.AuditEvent, ctx context.Context) (daos.AuditEvent, error) {
+						assert.Equal(t, audit.FeatureFlagUpserted, event.Action)
+						assert.Equal(t, null.StringFrom("beta"), event.TargetID)
+						assert.JSONEq(t, tt.wantDiff, string(event.Diff.JSON))
+						return event, nil
+					})
+			}
+			r := resolver.Resolver{Flags: mockFlags(), FlagRepo: flags, AuditRepo: audits}
+			resp, err := r.Mutation().UpsertFeatureFlag(context.Background(), tt.req)
+			assert.Equal(t, tt.wantErr, err != nil)
+			assert.Equal(t, tt.wantResp, resp)
+		})
+	}
+}
+
+func TestDeleteFeatureFlag(t *testing.T) {
+	cases := []struct {
+		name     string
+		findErr  error
+		deleted  int64
+		wantResp *fm.FeatureFlagDeletePayload
+		wantErr  bool
+	}{
+		{
+			name:     SuccessCase,
+			deleted:  1,
+			wantResp: &fm.FeatureFlagDeletePayload{Name: "beta"},
+		},
+		{
+			name:    "Failure_NotFound",
+			findErr: sql.ErrNoRows,
+			wantErr: true,
+		},
+		{
+			name:    "Failure_DeletedConcurrently",
+			wantErr: true,
+		},
+	}
+	for _, tt := range cases {
+		t.Run(tt.name, func(t *testing.T) {
+			ctrl := gomock.NewController(t)
+			flags := testutls.NewMockFeatureFlagRepository(ctrl)
+			audits := testutls.NewMockAuditEventRepository(ctrl)
+			before := &daos.FeatureFlag{ID: 1, Name: "beta", Enabled: true}
+			flags.EXPECT().FindByName("beta", gomock.Any()).Return(before, tt.findErr)
+			if tt.findErr == nil {
+				flags.EXPECT().Delete("beta", gomock.Any()).Return(tt.deleted, nil)
+			}
+			if tt.wantResp != nil {
+				testutls.ExpectAuditEvent(audits, audit.FeatureFlagDeleted)
+			}
+			r := resolver.Resolver{Flags: mockFlags(), FlagRepo: flags, AuditRepo: audits}
+			resp, err := r.Mutation().DeleteFeatureFlag(context.Background(), "beta")
+			assert.Equal(t, tt.wantErr, err != nil)
+			assert.Equal(t, tt.wantResp, resp)
+		})
+	}
+}
@@ -1,9 +1,20 @@
 package resolver
 
 import (
+	"context"
 	"sync"
 
+	"go-template/daos"
 	fm "go-template/gqlmodels"
+	"go-template/internal/config"
+	"go-template/internal/featureflags"
+	"go-template/internal/middleware/auth"
+	"go-template/models"
+	"go-template/pkg/utl/clock"
+	"go-template/pkg/utl/convert"
+	"go-template/pkg/utl/mailer"
+	"go-template/pkg/utl/rediscache"
+	"go-template/pkg/utl/throttle"
 )
 
 // This file will
@@ -18,8 +29,55 @@ import (
 // dependencies you
 // require here.
 
+// errUserModified is returned by updateUser when the user has a newer version than the update is based on
+const errUserModified = "The user was modified by another request, fetch it again and retry"
+
+// defaultAuditEventsLimit is the page size of auditEvents when the pagination sets no limit
+const defaultAuditEventsLimit = 50
+
+// Secure hashes and verifies passwords and generates refresh tokens
+type Secure interface {
+	Hash(password string) string
+	HashMatchesPassword(hash, password string) bool
+	Password(pass string, inputs ...string) bool
+	Token(str string) string
+}
+
+// TokenGenerator generates the access token of a user
+type TokenGenerator interface {
+	GenerateToken(u *models.User) (string, error)
+}
+
 // Resolver ...
 type Resolver struct {
 	sync.Mutex
 	Observers map[string]chan *fm.User
+
+	// dependencies are built once when the api starts
+	UserRepo  daos.UserRepository
+	RoleRepo  daos.RoleRepository
+	AuditRepo daos.AuditEventRepository
+	FlagRepo  daos.FeatureFlagRepository
+	Config    *config.Store
+	Secure    Secure
+	JWT       TokenGenerator
+	Cache     rediscache.Service
+	Throttle  throttle.Throttler
+	Mailer    mailer.Mailer
+	Clock     clock.Clock
+	Flags     *featureflags.Store
+}
+
+// flagSubject returns the subject the feature flags are evaluated for, the user of the context along with their
+// role, or an anonymous subject when there is none
+func (r *Resolver) flagSubject(ctx context.Context) (featureflags.Subject, error) {
+	user := auth.FromContext(ctx)
+	if user == nil {
+		return featureflags.Subject{}, nil
+	}
+	role, err := r.Cache.GetRole(convert.NullDotIntToInt(user.RoleID), ctx)
+	if err != nil {
+		return featureflags.Subject{}, err
+	}
+	return featureflags.Subject{UserID: user.ID, Role: role.Name}, nil
 }
@@ -6,24 +6,24 @@ package resolver
 import (
 	"context"
 	"fmt"
-	"go-template/daos"
 	"go-template/gqlmodels"
+	"go-template/internal/audit"
 	"go-template/internal/constants"
 	"go-template/internal/middleware/auth"
 	"go-template/models"
 	"go-template/pkg/utl/convert"
-	"go-template/pkg/utl/rediscache"
 	"go-template/pkg/utl/resultwrapper"
+	"strconv"
 )
 
 // CreateRole is the resolver for the createRole field.
 func (r *mutationResolver) CreateRole(ctx context.Context, input gqlmodels.RoleCreateInput) (*gqlmodels.RolePayload, error) {
 	userID := auth.UserIDFromContext(ctx)
-	user, err := rediscache.GetUser(userID, ctx)
+	user, err := r.Cache.GetUser(userID, ctx)
 	if err != nil {
 		return &gqlmodels.RolePayload{}, resultwrapper.ResolverSQLError(err, "data")
 	}
-	userRole, err := rediscache.GetRole(convert.NullDotIntToInt(user.RoleID), ctx)
+	userRole, err := r.Cache.GetRole(convert.NullDotIntToInt(user.RoleID), ctx)
 	if err != nil {
 		return &gqlmodels.RolePayload{}, resultwrapper.ResolverSQLError(err, "data")
 	}
@@ -35,10 +35,16 @@ func (r *mutationResolver) CreateRole(ctx context.Context, input gqlmodels.RoleC
 		return &gqlmodels.RolePayload{}, fmt.Errorf("You don't appear to have enough access level for this request ")
 	}
 
-	newRole, err := daos.CreateRole(role, ctx)
+	newRole, err := r.RoleRepo.Create(role, ctx)
 	if err != nil {
 		return nil, resultwrapper.ResolverSQLError(err, "role")
 	}
+	audit.Record(ctx, r.AuditRepo, audit.Event{
+		Action:     audit.RoleCreated,
+		TargetType: audit.TargetRole,
+		TargetID:   strconv.Itoa(newRole.ID),
+		After:      newRole,
+	})
 	return &gqlmodels.RolePayload{Role: &gqlmodels.Role{
 		AccessLevel: newRole.AccessLevel,
 		Name:        newRole.Name,
@@ -3,18 +3,15 @@ package resolver_test
 import (
 	"context"
 	"errors"
-	"go-template/daos"
 	"go-template/internal/constants"
-	"go-template/internal/middleware/auth"
 	"go-template/models"
-	"go-template/pkg/utl/rediscache"
 	"go-template/resolver"
+	"go-template/testutls"
 	"testing"
 
+	"github.com/golang/mock/gomock"
 	"github.com/volatiletech/null/v8"
 
-	"github.com/agiledragon/gomonkey/v2"
-
 	fm "go-template/gqlmodels"
 
 	"github.com/stretchr/testify/assert"
@@ -27,20 +24,27 @@ func TestCreateRole(
 
 	// Define test cases, each case has a name, request input, expected response, and error.
 	cases := []struct {
-		name     string
-		req      fm.RoleCreateInput
-		wantResp *fm.RolePayload
-		wantErr  bool
+		name      string
+		req       fm.RoleCreateInput
+		userErr   error
+		role      *models.Role
+		roleErr   error
+		create    bool
+		createErr error
+		wantResp  *fm.RolePayload
+		wantErr   bool
 	}{
 		{
 			name:     ErrorFromRedisCache,
 			req:      fm.RoleCreateInput{},
+			userErr:  errors.New("redis cache"),
 			wantResp: &fm.RolePayload{},
 			wantErr:  true,
 		},
 		{
 			name:     ErrorFromGetRole,
 			req:      fm.RoleCreateInput{},
+			roleErr:  errors.New("data"),
 			wantResp: &fm.RolePayload{},
 			wantErr:  true,
 		},
@@ -50,7 +54,9 @@ func TestCreateRole(
 				Name:        UserRoleName,
 				AccessLevel: int(constants.UserRole),
 			},
+			role:     &models.Role{AccessLevel: int(constants.UserRole), Name: UserRoleName},
 			wantResp: &fm.RolePayload{},
+			wantErr:  true,
 		},
 
 		{
@@ -59,6 +65,7 @@ func TestCreateRole(
 				Name:        UserRoleName,
 				AccessLevel: int(constants.UserRole),
 			},
+			create: true,
 			wantResp: &fm.RolePayload{Role: &fm.Role{
 
 				AccessLevel: int(constants.UserRole),
@@ -71,106 +78,48 @@ func TestCreateRole(
 				Name:        UserRoleName,
 				AccessLevel: int(constants.UserRole),
 			},
-			wantErr: true,
+			create:    true,
+			createErr: errors.New("error"),
+			wantErr:   true,
 		},
 	}
-	// Create a new resolver instance.
-	resolver1 := resolver.Resolver{}
-
 	// Loop through each test case.
 	for _, tt := range cases {
-
-		// Mocking rediscache.GetUserID function
-		patchUserID := gomonkey.ApplyFunc(auth.UserIDFromContext,
-			func(ctx context.Context) int {
-				return 1
-			})
-
-		// Mocking rediscache.GetUser function
-		patchGetUser := gomonkey.ApplyFunc(rediscache.GetUser,
-			func(userID int, ctx context.Context) (*models.User, error) {
-				return &models.User{
-					RoleID: null.IntFrom(1),
-				}, nil
-			})
-
-		// Mocking rediscache.GetRole function
-		patchGetRole := gomonkey.ApplyFunc(rediscache.GetRole,
-			func(roleID int, ctx context.Context) (*models.Role, error) {
-				return &models.Role{
-					AccessLevel: int(constants.SuperAdminRole),
-					Name:        SuperAdminRoleName,
-				}, nil
-			})
-
-		// Mocking daos.CreateRole function
-		patchCreateRole := gomonkey.ApplyFunc(daos.CreateRole,
-			func(role models.Role, ctx context.Context) (models.Role, error) {
-				return models.Role{
-					AccessLevel: int(constants.UserRole),
-					Name:        UserRoleName,
-				}, nil
-			})
-
-		// Defer resetting of the monkey patches.
-		defer patchUserID.Reset()
-		defer patchGetUser.Reset()
-		defer patchGetRole.Reset()
-		defer patchCreateRole.Reset()
 		t.Run(tt.name,
 			func(t *testing.T) {
 
-				// Apply additional monkey patches based on test case name.
-				if tt.name == ErrorFromRedisCache {
-					patchGetUser := gomonkey.ApplyFunc(rediscache.GetUser,
-						func(userID int, ctx context.Context) (*models.User, error) {
-							return nil, errors.New("redis cache")
-						})
-					defer patchGetUser.Reset()
+				// The cache returns a super admin unless the case expects otherwise.
+				cache := &testutls.FakeCache{
+					User:    &models.User{RoleID: null.IntFrom(1)},
+					UserErr: tt.userErr,
+					Role:    &models.Role{AccessLevel: int(constants.SuperAdminRole), Name: SuperAdminRoleName},
+					RoleErr: tt.roleErr,
 				}
-
-				if tt.name == ErrorFromGetRole {
-					patchGetRole := gomonkey.ApplyFunc(rediscache.GetRole,
-						func(roleID int, ctx context.Context) (*models.Role, error) {
-							return nil, errors.New("data")
-						})
-					defer patchGetRole.Reset()
+				if tt.role != nil {
+					cache.Role = tt.role
 				}
 
-				if tt.name == ErrorUnauthorizedUser {
-					patchGetRole := gomonkey.ApplyFunc(rediscache.GetRole,
-						func(roleID int, ctx context.Context) (*models.Role, error) {
-							return &models.Role{
-								AccessLevel: int(constants.UserRole),
-								Name:        UserRoleName,
-							}, nil
-						})
-					defer patchGetRole.Reset()
+				// the role is only created for super admins
+				ctrl := gomock.NewController(t)
+				roles := testutls.NewMockRoleRepository(ctrl)
+				audits := testutls.NewMockAuditEventRepository(ctrl)
+				if tt.create {
+					want := models.Role{AccessLevel: tt.req.AccessLevel, Name: tt.req.Name}
+					roles.EXPECT().Create(want, gomock.Any()).Return(want, tt.createErr)
 				}
-
-				if tt.name == ErrorFromCreateRole {
-					patchCreateRole := gomonkey.ApplyFunc(daos.CreateRole,
-						func(role models.Role, ctx context.Context) (models.Role, error) {
-							return models.Role{}, errors.New("error")
-						})
-
-					defer patchCreateRole.Reset()
+				if tt.create && tt.createErr == nil {
+					testutls.ExpectAuditEvent(audits, "role_created")
 				}
-
-				// Create a new context
-				c := context.Background()
+				resolver1 := resolver.Resolver{Cache: cache, RoleRepo: roles, AuditRepo: audits}
 
 				// Call the resolver function
-				response, err := resolver1.Mutation().CreateRole(c, tt.req)
+				response, err := resolver1.Mutation().CreateRole(context.Background(), tt.req)
 
 				// Check if the error matches the expected error
-				if tt.wantErr {
-					assert.NotNil(t, err)
-				}
+				assert.Equal(t, tt.wantErr, err != nil)
 
 				// Check if the response matches the expected response
 				assert.Equal(t, tt.wantResp, response)
-
 			})
 	}
 }
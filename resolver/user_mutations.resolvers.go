@@ -5,16 +5,18 @@ package resolver
 
 import (
 	"context"
+	"database/sql"
+	"errors"
 	"fmt"
 	"go-template/daos"
 	"go-template/gqlmodels"
-	"go-template/internal/config"
+	"go-template/internal/audit"
+	"go-template/internal/featureflags"
 	"go-template/internal/middleware/auth"
-	"go-template/internal/service"
 	"go-template/models"
 	"go-template/pkg/utl/cnvrttogql"
 	"go-template/pkg/utl/resultwrapper"
-	"go-template/pkg/utl/throttle"
+	"net/http"
 	"strconv"
 	"time"
 
@@ -23,9 +25,16 @@ import (
 
 // CreateUser is the resolver for the createUser field.
 func (r *mutationResolver) CreateUser(ctx context.Context, input gqlmodels.UserCreateInput) (*gqlmodels.User, error) {
-	err := throttle.Check(ctx, 5, 10*time.Second)
+	subject, err := r.flagSubject(ctx)
 	if err != nil {
-		return nil, err
+		return nil, resultwrapper.ResolverSQLError(err, "role")
+	}
+	if !r.Flags.Enabled(featureflags.ThrottleBypass, subject) {
+		limits := r.Config.Get().Throttle
+		err := r.Throttle.Check(ctx, limits.Limit, time.Duration(limits.WindowSeconds)*time.Second)
+		if err != nil {
+			return nil, err
+		}
 	}
 
 	roleId, _ := strconv.Atoi(input.RoleID)
@@ -42,18 +51,17 @@ func (r *mutationResolver) CreateUser(ctx context.Context, input gqlmodels.UserC
 		RoleID:    null.IntFrom(roleId),
 		Active:    active,
 	}
-	// loading configurations
-	cfg, err := config.Load()
-	if err != nil {
-		return nil, fmt.Errorf("error in loading config ")
-	}
-	// creating new secure service
-	sec := service.Secure(cfg)
-	user.Password = null.StringFrom(sec.Hash(user.Password.String))
-	newUser, err := daos.CreateUser(user, ctx)
+	user.Password = null.StringFrom(r.Secure.Hash(user.Password.String))
+	newUser, err := r.UserRepo.Create(user, ctx)
 	if err != nil {
 		return nil, resultwrapper.ResolverSQLError(err, "user information")
 	}
+	audit.Record(ctx, r.AuditRepo, audit.Event{
+		Action:     audit.UserCreated,
+		TargetType: audit.TargetUser,
+		TargetID:   strconv.Itoa(newUser.ID),
+		After:      newUser,
+	})
 	graphUser := cnvrttogql.UserToGraphQlUser(&newUser, 1)
 
 	r.Lock()
@@ -68,7 +76,7 @@ func (r *mutationResolver) CreateUser(ctx context.Context, input gqlmodels.UserC
 // UpdateUser is the resolver for the updateUser field.
 func (r *mutationResolver) UpdateUser(ctx context.Context, input *gqlmodels.UserUpdateInput) (*gqlmodels.User, error) {
 	userID := auth.UserIDFromContext(ctx)
-	user, _ := daos.FindUserByID(userID, ctx)
+	user, _ := r.UserRepo.FindByID(userID, ctx)
 	var u models.User
 	if user != nil {
 		u = *user
@@ -87,10 +95,31 @@ func (r *mutationResolver) UpdateUser(ctx context.Context, input *gqlmodels.User
 	if input.Address != nil {
 		u.Address = null.StringFromPtr(input.Address)
 	}
-	_, err := daos.UpdateUser(u, ctx)
+	if input.Version != nil && *input.Version != u.Version {
+		return nil, resultwrapper.ResolverWrapperFromMessage(http.StatusConflict, errUserModified)
+	}
+	var err error
+	if input.Version != nil {
+		u, err = r.UserRepo.UpdateAtVersion(u, *input.Version, ctx)
+	} else {
+		u, err = r.UserRepo.Update(u, ctx)
+	}
+	if errors.Is(err, daos.ErrVersionConflict) {
+		return nil, resultwrapper.ResolverWrapperFromMessage(http.StatusConflict, errUserModified)
+	}
+	if errors.Is(err, sql.ErrNoRows) {
+		return nil, resultwrapper.ResolverWrapperFromMessage(http.StatusNotFound, "user not found")
+	}
 	if err != nil {
 		return nil, resultwrapper.ResolverSQLError(err, "new information")
 	}
+	audit.Record(ctx, r.AuditRepo, audit.Event{
+		Action:     audit.UserUpdated,
+		TargetType: audit.TargetUser,
+		TargetID:   strconv.Itoa(u.ID),
+		Before:     *user,
+		After:      u,
+	})
 
 	graphUser := cnvrttogql.UserToGraphQlUser(&u, 1)
 	r.Lock()
@@ -105,13 +134,19 @@ func (r *mutationResolver) UpdateUser(ctx context.Context, input *gqlmodels.User
 // DeleteUser is the resolver for the deleteUser field.
 func (r *mutationResolver) DeleteUser(ctx context.Context) (*gqlmodels.UserDeletePayload, error) {
 	userID := auth.UserIDFromContext(ctx)
-	u, err := daos.FindUserByID(userID, ctx)
+	u, err := r.UserRepo.FindByID(userID, ctx)
 	if err != nil {
 		return nil, resultwrapper.ResolverSQLError(err, "data")
 	}
-	_, err = daos.DeleteUser(*u, ctx)
+	_, err = r.UserRepo.Delete(*u, ctx)
 	if err != nil {
 		return nil, resultwrapper.ResolverSQLError(err, "user")
 	}
+	audit.Record(ctx, r.AuditRepo, audit.Event{
+		Action:     audit.UserDeleted,
+		TargetType: audit.TargetUser,
+		TargetID:   strconv.Itoa(userID),
+		Before:     *u,
+	})
 	return &gqlmodels.UserDeletePayload{ID: fmt.Sprint(userID)}, nil
 }
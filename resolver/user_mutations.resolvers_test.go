@@ -2,65 +2,51 @@ package resolver_test
 
 import (
 	"context"
-	"database/sql/driver"
+	"database/sql"
+	"errors"
 	"fmt"
 	"go-template/daos"
 	fm "go-template/gqlmodels"
+	"go-template/internal/audit"
 	"go-template/internal/config"
+	"go-template/internal/featureflags"
+	"go-template/internal/middleware/auth"
+	"go-template/internal/service"
 	"go-template/models"
 	"go-template/pkg/utl/convert"
-	"go-template/pkg/utl/throttle"
 	"go-template/resolver"
 	"go-template/testutls"
-	"log"
-	"regexp"
 	"testing"
 	"time"
 
-	"github.com/agiledragon/gomonkey/v2"
-	. "github.com/agiledragon/gomonkey/v2"
-	"golang.org/x/crypto/bcrypt"
-
-	"github.com/DATA-DOG/go-sqlmock"
-	"github.com/joho/godotenv"
+	"github.com/golang/mock/gomock"
 	"github.com/stretchr/testify/assert"
-	"github.com/volatiletech/sqlboiler/v4/boil"
+	"github.com/volatiletech/null/v8"
 )
 
-type AnyTime struct{}
-
-// Match satisfies sqlmock.Argument interface
-func (a AnyTime) Match(
-	v driver.Value,
-) bool {
-	_, ok := v.(time.Time)
-	return ok
-}
-
 func TestCreateUser(
 	t *testing.T,
 ) {
 	cases := []struct {
-		name     string
-		req      fm.UserCreateInput
-		wantResp *fm.User
-		wantErr  bool
+		name      string
+		req       fm.UserCreateInput
+		create    bool
+		createErr error
+		wantResp  *fm.User
+		wantErr   bool
 	}{
 		{
-			name:    ErrorFromCreateUser,
-			req:     fm.UserCreateInput{},
-			wantErr: true,
+			name:      ErrorFromCreateUser,
+			req:       fm.UserCreateInput{},
+			create:    true,
+			createErr: errors.New("duplicate key"),
+			wantErr:   true,
 		},
 		{
 			name:    ErrorFromThrottleCheck,
 			req:     fm.UserCreateInput{},
 			wantErr: true,
 		},
-		{
-			name:    ErrorFromConfig,
-			req:     fm.UserCreateInput{},
-			wantErr: true,
-		},
 		{
 			name: SuccessCase,
 			req: fm.UserCreateInput{
@@ -70,6 +56,7 @@ func TestCreateUser(
 				Email:     testutls.MockUser().Email.String,
 				RoleID:    fmt.Sprint(testutls.MockUser().RoleID.Int),
 			},
+			create: true,
 			wantResp: &fm.User{
 				ID:                 fmt.Sprint(testutls.MockUser().ID),
 				Email:              convert.NullDotStringToPointerString(testutls.MockUser().Email),
@@ -82,86 +69,65 @@ func TestCreateUser(
 				LastLogin:          convert.NullDotTimeToPointerInt(testutls.MockUser().LastLogin),
 				LastPasswordChange: convert.NullDotTimeToPointerInt(testutls.MockUser().LastPasswordChange),
 				DeletedAt:          convert.NullDotTimeToPointerInt(testutls.MockUser().DeletedAt),
-				UpdatedAt:          convert.NullDotTimeToPointerInt(testutls.MockUser().UpdatedAt),
+				Version:            1,
 			},
 			wantErr: false,
 		},
 	}
 
-	resolver1 := resolver.Resolver{}
+	// the rate limit is bypassed like in a local setup, the error case enforces it
+	flags := featureflags.NewStore(func(ctx context.Context) ([]daos.FeatureFlag, error) {
+		return []daos.FeatureFlag{{Name: featureflags.ThrottleBypass, Enabled: true, RolloutPercentage: 100}}, nil
+	})
+	_ = flags.Refresh(context.Background())
 	for _, tt := range cases {
 		t.Run(
 			tt.name,
 			func(t *testing.T) {
-
-				if tt.name == ErrorFromThrottleCheck {
-					patch := gomonkey.ApplyFunc(throttle.Check, func(ctx context.Context, limit int, dur time.Duration) error {
-						return fmt.Errorf("Internal error")
-					})
-					defer patch.Reset()
+				ctrl := gomock.NewController(t)
+				users := testutls.NewMockUserRepository(ctrl)
+				audits := testutls.NewMockAuditEventRepository(ctrl)
+				resolver1 := resolver.Resolver{
+					UserRepo:  users,
+					AuditRepo: audits,
+					Config:    config.NewStore(testutls.MockConfig(), nil),
+					Flags:     flags,
+					Secure:    service.Secure(testutls.MockConfig()),
+					Throttle:  testutls.FakeThrottler{},
 				}
 
-				if tt.name == ErrorFromConfig {
-					patch := gomonkey.ApplyFunc(config.Load, func() (*config.Configuration, error) {
-						return nil, fmt.Errorf("error in loading config")
-					})
-					defer patch.Reset()
-
+				if tt.name == ErrorFromThrottleCheck {
+					resolver1.Flags = featureflags.NewStore(nil)
+					resolver1.Throttle = testutls.FakeThrottler{Err: fmt.Errorf("Internal error")}
 				}
 
-				err := config.LoadEnvWithFilePrefix(convert.StringToPointerString("./../"))
-				if err != nil {
-					log.Fatal(err)
+				// the password is hashed before the user is inserted, the insert sets the id and the timestamps
+				if tt.create {
+					users.EXPECT().Create(gomock.Any(), gomock.Any()).DoAndReturn(
+						func(user models.User, ctx context.Context) (models.User, error) {
+							assert.Equal(t, tt.req.Username, user.Username.String)
+							assert.NotEqual(t, tt.req.Password, user.Password.String)
+							created := *testutls.MockUser()
+							created.CreatedAt = null.TimeFrom(time.Now())
+							created.UpdatedAt = created.CreatedAt
+							created.Version = 1
+							return created, tt.createErr
+						})
 				}
-				mock, db, _ := testutls.SetupMockDB(t)
-				oldDB := boil.GetDB()
-				defer func() {
-					db.Close()
-					boil.SetDB(oldDB)
-				}()
-				boil.SetDB(db)
-
-				if tt.name == ErrorFromCreateUser {
-					// insert new user
-					mock.ExpectQuery(regexp.QuoteMeta(`INSERT INTO "users"`)).
-						WithArgs().
-						WillReturnError(fmt.Errorf(""))
+				if tt.create && tt.createErr == nil {
+					testutls.ExpectAuditEvent(audits, audit.UserCreated)
 				}
-				// insert new user
-				rows := sqlmock.NewRows([]string{
-					"id", "mobile", "address", "active", "last_login", "last_password_change", "token", "deleted_at",
-				}).
-					AddRow(
-						testutls.MockUser().ID,
-						testutls.MockUser().Mobile,
-						testutls.MockUser().Address,
-						testutls.MockUser().Active,
-						testutls.MockUser().LastLogin,
-						testutls.MockUser().LastPasswordChange,
-						testutls.MockUser().Token,
-						testutls.MockUser().DeletedAt,
-					)
-				ApplyFunc(bcrypt.GenerateFromPassword, func([]uint8, int) ([]uint8, error) {
-					var a []uint8
-					return a, nil
-				})
-				mock.ExpectQuery(regexp.QuoteMeta(`INSERT INTO "users"`)).
-					WithArgs(
-						testutls.MockUser().FirstName,
-						testutls.MockUser().LastName,
-						testutls.MockUser().Username,
-						"",
-						testutls.MockUser().Email,
-						testutls.MockUser().RoleID,
-						AnyTime{},
-						AnyTime{},
-					).
-					WillReturnRows(rows)
 
 				c := context.Background()
 				response, err := resolver1.Mutation().
 					CreateUser(c, tt.req)
 				if tt.wantResp != nil {
+					// the timestamps are set when the user is inserted
+					if assert.NotNil(t, response) {
+						assert.NotNil(t, response.CreatedAt)
+						assert.Equal(t, response.CreatedAt, response.UpdatedAt)
+						response.CreatedAt, response.UpdatedAt = nil, nil
+					}
 					assert.Equal(t, tt.wantResp, response)
 				}
 				assert.Equal(t, tt.wantErr, err != nil)
@@ -170,18 +136,92 @@ func TestCreateUser(
 	}
 }
 
+func TestCreateUserThrottleBypass(t *testing.T) {
+	cases := map[string]struct {
+		flag        daos.FeatureFlag
+		anonymous   bool
+		role        string
+		roleErr     error
+		wantCreated bool
+	}{
+		"Success_User": {
+			flag:        daos.FeatureFlag{UserIDs: []int{testutls.MockID}},
+			role:        "USER",
+			wantCreated: true,
+		},
+		"Success_Role": {
+			flag:        daos.FeatureFlag{RolloutPercentage: 100, Roles: []string{"SUPER_ADMIN"}},
+			role:        "SUPER_ADMIN",
+			wantCreated: true,
+		},
+		"Failure_OtherRole": {
+			flag: daos.FeatureFlag{RolloutPercentage: 100, Roles: []string{"SUPER_ADMIN"}},
+			role: "USER",
+		},
+		"Failure_Anonymous": {
+			flag:      daos.FeatureFlag{UserIDs: []int{testutls.MockID}},
+			anonymous: true,
+		},
+		"Failure_GetRole": {
+			flag:    daos.FeatureFlag{RolloutPercentage: 100},
+			roleErr: fmt.Errorf("redis unavailable"),
+		},
+	}
+	for name, tt := range cases {
+		t.Run(name, func(t *testing.T) {
+			flag := tt.flag
+			flag.Name, flag.Enabled = featureflags.ThrottleBypass, true
+			flags := featureflags.NewStore(func(ctx context.Context) ([]daos.FeatureFlag, error) {
+				return []daos.FeatureFlag{flag}, nil
+			})
+			_ = flags.Refresh(context.Background())
+
+			ctrl := gomock.NewController(t)
+			users := testutls.NewMockUserRepository(ctrl)
+			audits := testutls.NewMockAuditEventRepository(ctrl)
+			if tt.wantCreated {
+				users.EXPECT().Create(gomock.Any(), gomock.Any()).Return(*testutls.MockUser(), nil)
+				testutls.ExpectAuditEvent(audits, audit.UserCreated)
+			}
+			resolver1 := resolver.Resolver{
+				UserRepo:  users,
+				AuditRepo: audits,
+				Config:    config.NewStore(testutls.MockConfig(), nil),
+				Flags:     flags,
+				Secure:    service.Secure(testutls.MockConfig()),
+				Cache:     &testutls.FakeCache{Role: &models.Role{Name: tt.role}, RoleErr: tt.roleErr},
+				Throttle:  testutls.FakeThrottler{Err: fmt.Errorf("You reached the rate limit for this query")},
+			}
+
+			// the rate limit is only bypassed for the users the flag is on for
+			ctx := context.Background()
+			if !tt.anonymous {
+				ctx = context.WithValue(ctx, auth.UserCtxKey, testutls.MockUser())
+			}
+			response, err := resolver1.Mutation().CreateUser(ctx, fm.UserCreateInput{RoleID: "1"})
+			assert.Equal(t, tt.wantCreated, err == nil)
+			assert.Equal(t, tt.wantCreated, response != nil)
+		})
+	}
+}
+
 func TestUpdateUser(
 	t *testing.T,
 ) {
+	intPointer := func(i int) *int { return &i }
 	cases := []struct {
-		name     string
-		req      *fm.UserUpdateInput
-		wantResp *fm.User
-		wantErr  bool
+		name      string
+		req       *fm.UserUpdateInput
+		findErr   error
+		update    bool
+		updateErr error
+		wantResp  *fm.User
+		wantErr   bool
 	}{
 		{
 			name:    ErrorFindingUser,
 			req:     &fm.UserUpdateInput{},
+			findErr: errors.New("connection refused"),
 			wantErr: true,
 		},
 		{
@@ -192,7 +232,9 @@ func TestUpdateUser(
 				Mobile:    &testutls.MockUser().Mobile.String,
 				Address:   &testutls.MockUser().Address.String,
 			},
-			wantErr: true,
+			update:    true,
+			updateErr: errors.New("error for update user"),
+			wantErr:   true,
 		},
 		{
 			name: SuccessCase,
@@ -202,62 +244,97 @@ func TestUpdateUser(
 				Mobile:    &testutls.MockUser().Mobile.String,
 				Address:   &testutls.MockUser().Address.String,
 			},
+			update: true,
 			wantResp: &fm.User{
 				ID:        "0",
 				FirstName: &testutls.MockUser().FirstName.String,
 				LastName:  &testutls.MockUser().LastName.String,
 				Mobile:    &testutls.MockUser().Mobile.String,
 				Address:   &testutls.MockUser().Address.String,
+				Version:   3,
 			},
 			wantErr: false,
 		},
+		{
+			name: "StaleVersion",
+			req: &fm.UserUpdateInput{
+				FirstName: &testutls.MockUser().FirstName.String,
+				Version:   intPointer(1),
+			},
+			wantErr: true,
+		},
+		{
+			name: "UpdatedConcurrently",
+			req: &fm.UserUpdateInput{
+				FirstName: &testutls.MockUser().FirstName.String,
+				Version:   intPointer(2),
+			},
+			update:    true,
+			updateErr: daos.ErrVersionConflict,
+			wantErr:   true,
+		},
+		{
+			name: "UserDeleted",
+			req: &fm.UserUpdateInput{
+				FirstName: &testutls.MockUser().FirstName.String,
+			},
+			update:    true,
+			updateErr: sql.ErrNoRows,
+			wantErr:   true,
+		},
 	}
 
-	resolver1 := resolver.Resolver{}
 	for _, tt := range cases {
 		t.Run(
 			tt.name,
 			func(t *testing.T) {
+				ctrl := gomock.NewController(t)
+				users := testutls.NewMockUserRepository(ctrl)
+				audits := testutls.NewMockAuditEventRepository(ctrl)
+				var user *models.User
+				if tt.findErr == nil {
+					user = &models.User{FirstName: testutls.MockUser().FirstName, Version: 2}
+				}
+				users.EXPECT().FindByID(gomock.Any(), gomock.Any()).Return(user, tt.findErr)
 
-				if tt.name == ErrorUpdateUser {
-
-					patch := gomonkey.ApplyFunc(daos.UpdateUser,
+				// the update bumps the version and the update time, the version is only checked when the input has one
+				update := func(user models.User) (models.User, error) {
+					user.Version++
+					user.UpdatedAt = null.TimeFrom(time.Now())
+					return user, tt.updateErr
+				}
+				if tt.update && tt.req.Version != nil {
+					users.EXPECT().UpdateAtVersion(gomock.Any(), *tt.req.Version, gomock.Any()).DoAndReturn(
+						func(user models.User, version int, ctx context.Context) (models.User, error) {
+							return update(user)
+						})
+				} else if tt.update {
+					users.EXPECT().Update(gomock.Any(), gomock.Any()).DoAndReturn(
 						func(user models.User, ctx context.Context) (models.User, error) {
-							return user, fmt.Errorf("error for update user")
+							return update(user)
 						})
-					defer patch.Reset()
 				}
-				err := config.LoadEnvWithFilePrefix(convert.StringToPointerString("./../"))
-				if err != nil {
-					log.Fatal(err)
-				}
-				mock, db, _ := testutls.SetupMockDB(t)
-				oldDB := boil.GetDB()
-				defer func() {
-					db.Close()
-					boil.SetDB(oldDB)
-				}()
-				boil.SetDB(db)
-
-				if tt.name == ErrorFindingUser {
-					mock.ExpectQuery(regexp.QuoteMeta(`UPDATE "users"`)).WithArgs().WillReturnError(fmt.Errorf(""))
+				if tt.update && tt.updateErr == nil {
+					testutls.ExpectAuditEvent(audits, audit.UserUpdated)
 				}
-
-				rows := sqlmock.NewRows([]string{"first_name"}).AddRow(testutls.MockUser().FirstName)
-				mock.ExpectQuery(regexp.QuoteMeta(`select * from "users"`)).WithArgs(0).WillReturnRows(rows)
-
-				// update users with new information
-				result := driver.Result(driver.RowsAffected(1))
-				mock.ExpectExec(regexp.QuoteMeta(`UPDATE "users"`)).WillReturnResult(result)
+				resolver1 := resolver.Resolver{UserRepo: users, AuditRepo: audits}
 
 				c := context.Background()
 				ctx := context.WithValue(c, testutls.UserKey, testutls.MockUser())
 				response, err := resolver1.Mutation().UpdateUser(ctx, tt.req)
 				if tt.wantResp != nil &&
 					response != nil {
+					assert.NotNil(t, response.UpdatedAt)
+					response.UpdatedAt = nil
 					assert.Equal(t, tt.wantResp, response)
 				}
 				assert.Equal(t, tt.wantErr, err != nil)
+				if errors.Is(tt.updateErr, daos.ErrVersionConflict) || tt.name == "StaleVersion" {
+					assert.EqualError(t, err, "The user was modified by another request, fetch it again and retry")
+				}
+				if errors.Is(tt.updateErr, sql.ErrNoRows) {
+					assert.EqualError(t, err, "user not found")
+				}
 			},
 		)
 	}
@@ -267,17 +344,21 @@ func TestDeleteUser(
 	t *testing.T,
 ) {
 	cases := []struct {
-		name     string
-		wantResp *fm.UserDeletePayload
-		wantErr  bool
+		name      string
+		findErr   error
+		deleteErr error
+		wantResp  *fm.UserDeletePayload
+		wantErr   bool
 	}{
 		{
 			name:    ErrorFindingUser,
+			findErr: errors.New("connection refused"),
 			wantErr: true,
 		},
 		{
-			name:    ErrorDeleteUser,
-			wantErr: true,
+			name:      ErrorDeleteUser,
+			deleteErr: errors.New("error for delete user"),
+			wantErr:   true,
 		},
 		{
 			name: SuccessCase,
@@ -288,52 +369,24 @@ func TestDeleteUser(
 		},
 	}
 
-	resolver1 := resolver.Resolver{}
 	for _, tt := range cases {
 		t.Run(
 			tt.name,
 			func(t *testing.T) {
-				if tt.name == ErrorDeleteUser {
-
-					patch := gomonkey.ApplyFunc(daos.DeleteUser,
-						func(user models.User, ctx context.Context) (int64, error) {
-							return 0, fmt.Errorf("error for delete user")
-						})
-					defer patch.Reset()
+				ctrl := gomock.NewController(t)
+				users := testutls.NewMockUserRepository(ctrl)
+				audits := testutls.NewMockAuditEventRepository(ctrl)
+				if tt.findErr != nil {
+					users.EXPECT().FindByID(gomock.Any(), gomock.Any()).Return(nil, tt.findErr)
+				} else {
+					user := &models.User{ID: 1}
+					users.EXPECT().FindByID(gomock.Any(), gomock.Any()).Return(user, nil)
+					users.EXPECT().Delete(*user, gomock.Any()).Return(int64(1), tt.deleteErr)
 				}
-
-				err := godotenv.Load(
-					"../.env.local",
-				)
-				if err != nil {
-					fmt.Print("error loading .env file")
-				}
-				db, mock, err := sqlmock.New()
-				if err != nil {
-					t.Fatalf("an error '%s' was not expected when opening a stub database connection", err)
-				}
-				oldDB := boil.GetDB()
-				defer func() {
-					db.Close()
-					boil.SetDB(oldDB)
-				}()
-				boil.SetDB(db)
-
-				if tt.name == ErrorFindingUser {
-					mock.ExpectQuery(regexp.QuoteMeta(`select * from "users" where "id"=$1`)).
-						WithArgs().
-						WillReturnError(fmt.Errorf(""))
+				if !tt.wantErr {
+					testutls.ExpectAuditEvent(audits, audit.UserDeleted)
 				}
-				// get user by id
-				rows := sqlmock.NewRows([]string{"id"}).
-					AddRow(1)
-				mock.ExpectQuery(regexp.QuoteMeta(`select * from "users" where "id"=$1`)).
-					WithArgs().
-					WillReturnRows(rows)
-				// delete user
-				result := driver.Result(driver.RowsAffected(1))
-				mock.ExpectExec(regexp.QuoteMeta(`DELETE FROM "users" WHERE "id"=$1`)).
-					WillReturnResult(result)
+				resolver1 := resolver.Resolver{UserRepo: users, AuditRepo: audits}
 
 				c := context.Background()
 				ctx := context.WithValue(c, testutls.UserKey, testutls.MockUser())
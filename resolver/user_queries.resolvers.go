@@ -5,11 +5,9 @@ package resolver
 
 import (
 	"context"
-	"go-template/daos"
 	"go-template/gqlmodels"
 	"go-template/internal/middleware/auth"
 	"go-template/pkg/utl/cnvrttogql"
-	"go-template/pkg/utl/rediscache"
 	"go-template/pkg/utl/resultwrapper"
 
 	"github.com/volatiletech/sqlboiler/v4/queries/qm"
@@ -18,7 +16,7 @@ import (
 // Me is the resolver for the me field.
 func (r *queryResolver) Me(ctx context.Context) (*gqlmodels.User, error) {
 	userID := auth.UserIDFromContext(ctx)
-	user, err := rediscache.GetUser(userID, ctx)
+	user, err := r.Cache.GetUser(userID, ctx)
 	if err != nil {
 		return &gqlmodels.User{}, resultwrapper.ResolverSQLError(err, "data")
 	}
@@ -35,7 +33,7 @@ func (r *queryResolver) Users(ctx context.Context, pagination *gqlmodels.UserPag
 		}
 	}
 
-	users, count, err := daos.FindAllUsersWithCount(queryMods, ctx)
+	users, count, err := r.UserRepo.FindAllWithCount(queryMods, ctx)
 	if err != nil {
 		return nil, resultwrapper.ResolverSQLError(err, "data")
 	}
@@ -2,30 +2,17 @@ package resolver_test
 
 import (
 	"context"
-	"encoding/json"
 	"errors"
 	"fmt"
-	"log"
-	"regexp"
 	"testing"
 
 	fm "go-template/gqlmodels"
-	"go-template/internal/config"
 	"go-template/models"
 	"go-template/pkg/utl/cnvrttogql"
-	"go-template/pkg/utl/convert"
-	"go-template/pkg/utl/rediscache"
 	"go-template/resolver"
 	"go-template/testutls"
 
-	"github.com/DATA-DOG/go-sqlmock"
-	"github.com/agiledragon/gomonkey/v2"
-	. "github.com/agiledragon/gomonkey/v2"
-	"github.com/gomodule/redigo/redis"
-	"github.com/joho/godotenv"
-	"github.com/volatiletech/sqlboiler/v4/boil"
-
-	"github.com/rafaeljusto/redigomock"
+	"github.com/golang/mock/gomock"
 	"github.com/stretchr/testify/assert"
 )
 
@@ -51,66 +38,24 @@ func TestMe(
 			name:     ErrorFromRedisCache,
 			args:     args{user: testutls.MockUser()},
 			wantResp: nil,
+			wantErr:  true,
 		},
 	}
 
-	err := config.LoadEnvWithFilePrefix(convert.StringToPointerString("./../"))
-	if err != nil {
-		log.Fatal(err)
-	}
-	_, db, _ := testutls.SetupMockDB(t)
-	oldDb := boil.GetDB()
-	boil.SetDB(db)
-	defer func() {
-		db.Close()
-		boil.SetDB(oldDb)
-	}()
-	conn := redigomock.NewConn()
-	ApplyFunc(
-		redis.Dial,
-		func(network string, address string, options ...redis.DialOption) (redis.Conn, error) {
-			return conn, nil
-		},
-	)
-	//
-	resolver1 := resolver.Resolver{}
 	for _, tt := range cases {
-
-		if tt.name == ErrorFromRedisCache {
-			patchGetUser := gomonkey.ApplyFunc(rediscache.GetUser,
-				func(userID int, ctx context.Context) (*models.User, error) {
-					return nil, errors.New("redis cache")
-				})
-			defer patchGetUser.Reset()
-		}
-		_, db, err := testutls.SetupEnvAndDB(t, testutls.Parameters{EnvFileLocation: `../.env.local`})
-		if err != nil {
-			panic("failed to setup env and db")
-		}
-		oldDb := boil.GetDB()
-		boil.SetDB(db)
-		defer func() {
-			db.Close()
-			boil.SetDB(oldDb)
-		}()
-		conn := redigomock.NewConn()
-		ApplyFunc(
-			redis.Dial,
-			func(network string, address string, options ...redis.DialOption) (redis.Conn, error) {
-				return conn, nil
-			},
-		)
 		t.Run(
 			tt.name,
 			func(t *testing.T) {
+				cache := &testutls.FakeCache{User: tt.args.user}
+				if tt.name == ErrorFromRedisCache {
+					cache.UserErr = errors.New("redis cache")
+				}
+				resolver1 := resolver.Resolver{Cache: cache}
 
-				b, _ := json.Marshal(tt.args.user)
-				conn.Command("GET", "user0").Expect(b)
 				c := context.Background()
 				ctx := context.WithValue(c, testutls.UserKey, testutls.MockUser())
-				response, _ := resolver1.Query().Me(ctx)
-				if tt.wantResp != nil &&
-					response != nil {
+				response, err := resolver1.Query().Me(ctx)
+				if tt.wantResp != nil {
 					assert.Equal(t, tt.wantResp, response)
 				}
 				assert.Equal(t, tt.wantErr, err != nil)
@@ -126,108 +71,57 @@ func TestUsers(
 	cases := []struct {
 		name       string
 		pagination *fm.UserPagination
-		wantResp   []*models.User
+		queryMods  int
+		users      models.UserSlice
+		err        error
+		wantResp   *fm.UsersPayload
 		wantErr    bool
 	}{
 		{
 			name:    ErrorFindingUser,
+			err:     fmt.Errorf("connection refused"),
 			wantErr: true,
 		},
 		{
-			name:    "pagination",
-			wantErr: false,
+			name: "pagination",
 			pagination: &fm.UserPagination{
 				Limit: 1,
 				Page:  1,
 			},
-			wantResp: testutls.MockUsers(),
+			// the limit and the offset
+			queryMods: 2,
+			users:     testutls.MockUsers(),
+			wantResp: &fm.UsersPayload{
+				Total: 1,
+				Users: cnvrttogql.UsersToGraphQlUsers(testutls.MockUsers(), 1),
+			},
 		},
 		{
-			name:     SuccessCase,
-			wantErr:  false,
-			wantResp: testutls.MockUsers(),
+			name:  SuccessCase,
+			users: testutls.MockUsers(),
+			wantResp: &fm.UsersPayload{
+				Total: 1,
+				Users: cnvrttogql.UsersToGraphQlUsers(testutls.MockUsers(), 1),
+			},
 		},
 	}
 
-	// Create a new instance of the resolver.
-	resolver1 := resolver.Resolver{}
 	for _, tt := range cases {
 		t.Run(
 			tt.name,
 			func(t *testing.T) {
-
-				// Load environment variables from the .env.local file.
-				err := godotenv.Load(
-					"../.env.local",
-				)
-				if err != nil {
-					fmt.Print("error loading .env file")
-				}
-
-				// Create a new mock database connection.
-				db, mock, err := sqlmock.New()
-				if err != nil {
-					t.Fatalf("an error '%s' was not expected when opening a stub database connection", err)
-				}
-				// Inject mock instance into boil.
-				oldDB := boil.GetDB()
-				defer func() {
-					db.Close()
-					boil.SetDB(oldDB)
-				}()
-				boil.SetDB(db)
-
-				//fail on finding user case
-				if tt.name == ErrorFindingUser {
-					mock.ExpectQuery(regexp.QuoteMeta(`select * from "users" where "id"=$1`)).
-						WithArgs().
-						WillReturnError(fmt.Errorf(""))
-				}
-
-				if tt.name == "pagination" {
-					rows := sqlmock.
-						NewRows([]string{"id", "email", "first_name", "last_name", "mobile", "username", "address"}).
-						AddRow(testutls.MockID, testutls.MockEmail, "First", "Last", "+911234567890", "username", "22 Jump Street")
-					mock.ExpectQuery(regexp.QuoteMeta(`SELECT "users".* FROM "users" LIMIT 1 OFFSET 1;`)).WithArgs().WillReturnRows(rows)
-
-					rowCount := sqlmock.NewRows([]string{"count"}).
-						AddRow(1)
-					mock.ExpectQuery(regexp.QuoteMeta(`SELECT COUNT(*) FROM "users" LIMIT 1;`)).
-						WithArgs().
-						WillReturnRows(rowCount)
-
-				} else {
-					rows := sqlmock.
-						NewRows([]string{"id", "email", "first_name", "last_name", "mobile", "username", "address"}).
-						AddRow(testutls.MockID, testutls.MockEmail, "First", "Last", "+911234567890", "username", "22 Jump Street")
-					mock.ExpectQuery(regexp.QuoteMeta(`SELECT "users".* FROM "users";`)).WithArgs().WillReturnRows(rows)
-
-					rowCount := sqlmock.NewRows([]string{"count"}).
-						AddRow(1)
-					mock.ExpectQuery(regexp.QuoteMeta(`SELECT COUNT(*) FROM "users";`)).
-						WithArgs().
-						WillReturnRows(rowCount)
-
-				}
-				// Define a mock result set for user queries.
-
-				// Define a mock result set.
+				ctrl := gomock.NewController(t)
+				users := testutls.NewMockUserRepository(ctrl)
+				users.EXPECT().FindAllWithCount(gomock.Len(tt.queryMods), gomock.Any()).
+					Return(tt.users, int64(len(tt.users)), tt.err)
+				resolver1 := resolver.Resolver{UserRepo: users}
 
 				// Create a new context with a mock user.
-				c := context.Background()
-				ctx := context.WithValue(c, testutls.UserKey, testutls.MockUser())
+				ctx := context.WithValue(context.Background(), testutls.UserKey, testutls.MockUser())
 
 				// Query for users using the resolver and get the response and error.
-				response, err := resolver1.Query().
-					Users(ctx, tt.pagination)
-
-				// Check if the response matches the expected response length.
-				if tt.wantResp != nil &&
-					response != nil {
-					assert.Equal(t, len(tt.wantResp), len(response.Users))
-
-				}
-				// Check if the error matches the expected error value.
+				response, err := resolver1.Query().Users(ctx, tt.pagination)
+				assert.Equal(t, tt.wantResp, response)
 				assert.Equal(t, tt.wantErr, err != nil)
 			},
 		)
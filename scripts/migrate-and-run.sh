@@ -2,11 +2,13 @@
 
 echo $ENVIRONMENT_NAME
 
-./migrations
-
 if [[ $ENVIRONMENT_NAME == "docker" ]]; then
+    # the seeder needs the tables before the server starts
+    ./migrations up || exit 1
     echo "seeding"
-    ./seeder
+    ./seeder run || exit 1
 fi
 
-./server
\ No newline at end of file
+# the server applies the pending migrations itself, one replica at a time
+export DB_MIGRATE_ON_BOOT=true
+./server
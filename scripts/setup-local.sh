@@ -4,11 +4,10 @@ set -a && source .env.local && set +a
 
 export PSQL_HOST=localhost
 # drop first
-go run ./cmd/migrations/main.go down
+go run ./cmd/migrations/main.go down 0
 
 # run migrations
-go run ./cmd/migrations/main.go
+go run ./cmd/migrations/main.go up
 
 # seed data
-go run ./cmd/seeder/main.go
-go run ./cmd/seeder/exec/seed.go
\ No newline at end of file
+go run ./cmd/seeder run
\ No newline at end of file
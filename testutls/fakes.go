@@ -0,0 +1,84 @@
+package testutls
+
+import (
+	"context"
+	"sync"
+	"time"
+
+	"go-template/models"
+)
+
+// FakeTokenGenerator returns the configured token, or error, for every user
+type FakeTokenGenerator struct {
+	Token string
+	Err   error
+}
+
+func (f FakeTokenGenerator) GenerateToken(u *models.User) (string, error) {
+	return f.Token, f.Err
+}
+
+// FakeCache returns the configured user and role instead of reading them from redis
+type FakeCache struct {
+	User    *models.User
+	Role    *models.Role
+	UserErr error
+	RoleErr error
+	Visits  int
+}
+
+func (f *FakeCache) GetUser(id int, ctx context.Context) (*models.User, error) {
+	return f.User, f.UserErr
+}
+
+func (f *FakeCache) GetRole(id int, ctx context.Context) (*models.Role, error) {
+	return f.Role, f.RoleErr
+}
+
+func (f *FakeCache) IncVisits(path string, ctx context.Context) (int, error) {
+	f.Visits++
+	return f.Visits, nil
+}
+
+func (f *FakeCache) StartVisits(path string, exp time.Duration, ctx context.Context) error {
+	f.Visits = 1
+	return nil
+}
+
+// FakeThrottler returns the configured error for every check
+type FakeThrottler struct {
+	Err error
+}
+
+func (f FakeThrottler) Check(ctx context.Context, limit int, dur time.Duration) error {
+	return f.Err
+}
+
+// FakeClock always returns Time
+type FakeClock struct {
+	Time time.Time
+}
+
+func (f FakeClock) Now() time.Time {
+	return f.Time
+}
+
+// Mail is an email recorded by FakeMailer
+type Mail struct {
+	To      string
+	Subject string
+	Body    string
+}
+
+// FakeMailer records the emails instead of sending them
+type FakeMailer struct {
+	sync.Mutex
+	Sent []Mail
+}
+
+func (f *FakeMailer) Send(ctx context.Context, to string, subject string, body string) error {
+	f.Lock()
+	defer f.Unlock()
+	f.Sent = append(f.Sent, Mail{To: to, Subject: subject, Body: body})
+	return nil
+}
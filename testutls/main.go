@@ -1,6 +1,7 @@
 package testutls
 
 import (
+	"context"
 	"database/sql"
 	"database/sql/driver"
 	"fmt"
@@ -9,11 +10,13 @@ import (
 	"testing"
 	"time"
 
+	"go-template/daos"
 	"go-template/internal/config"
 	"go-template/models"
 
 	"github.com/DATA-DOG/go-sqlmock"
 	jwt "github.com/dgrijalva/jwt-go"
+	"github.com/golang/mock/gomock"
 	"github.com/joho/godotenv"
 	"github.com/volatiletech/null/v8"
 	"github.com/volatiletech/sqlboiler/v4/boil"
@@ -129,6 +132,28 @@ func SetupMockDB(t *testing.T) (mock sqlmock.Sqlmock, db *sql.DB, err error) {
 	return mock, db, nil
 }
 
+// ExpectAuditEvent expects the audit event of action to be written with events
+func ExpectAuditEvent(events *MockAuditEventRepository, action string) {
+	events.EXPECT().Create(auditAction(action), gomock.Any()).DoAndReturn(
+		func(event daos.AuditEvent, ctx context.Context) (daos.AuditEvent, error) {
+			event.ID = 1
+			event.CreatedAt = time.Now()
+			return event, nil
+		})
+}
+
+// auditAction matches the audit events of an action
+type auditAction string
+
+func (a auditAction) Matches(x interface{}) bool {
+	event, ok := x.(daos.AuditEvent)
+	return ok && event.Action == string(a)
+}
+
+func (a auditAction) String() string {
+	return "is an audit event of " + string(a)
+}
+
 type QueryData struct {
 	Actions    *[]driver.Value
 	Query      string
@@ -138,14 +163,22 @@ type QueryData struct {
 func MockConfig() *config.Configuration {
 	return &config.Configuration{
 		DB: &config.Database{
-			LogQueries: true,
-			Timeout:    5,
+			LogQueries:          true,
+			Timeout:             5,
+			WriteWindowSeconds:  5,
+			ReplicaCheckSeconds: 10,
+			MaxOpenConns:        25,
+			MaxIdleConns:        5,
+			MaxLifetimeSeconds:  1800,
+			SlowQueryMillis:     200,
 		},
 		Server: &config.Server{
-			Port:         ":9000",
-			Debug:        true,
-			ReadTimeout:  10,
-			WriteTimeout: 5,
+			Port:            ":9000",
+			Debug:           true,
+			ReadTimeout:     10,
+			WriteTimeout:    5,
+			DrainSeconds:    5,
+			ShutdownTimeout: 10,
 		},
 		JWT: &config.JWT{
 			MinSecretLength:  64,
@@ -157,6 +190,62 @@ func MockConfig() *config.Configuration {
 		App: &config.Application{
 			MinPasswordStr: 1,
 		},
+		Admin: &config.Admin{
+			Port: ":9100",
+		},
+		Tracing: &config.Tracing{
+			ServiceName: "goTemplate",
+			Endpoint:    "localhost:4317",
+			Insecure:    true,
+			SampleRatio: 1,
+		},
+		Log: &config.Log{
+			Level:  "info",
+			Format: "json",
+		},
+		Health: &config.Health{
+			CheckTimeoutSeconds: 2,
+		},
+		Secrets: &config.Secrets{
+			Provider: "env",
+		},
+		Throttle: &config.Throttle{
+			Limit:         5,
+			WindowSeconds: 10,
+		},
+		Cors: &config.Cors{
+			AllowOrigins: []string{"*"},
+			AllowMethods: []string{"HEAD", "POST", "GET", "PATCH", "DELETE", "PUT"},
+			AllowHeaders: []string{"Authorization", "Content-Type", "X-Request-ID"},
+		},
+		Security: &config.Security{
+			ContentSecurityPolicy: "default-src 'none'; frame-ancestors 'none'; base-uri 'none'; form-action 'none'",
+			PlaygroundCSP: "default-src 'self'; " +
+				"script-src 'self' 'unsafe-inline' https://cdn.jsdelivr.net; " +
+				"style-src 'self' 'unsafe-inline' https://cdn.jsdelivr.net; " +
+				"img-src 'self' data: https://cdn.jsdelivr.net; font-src 'self' data: https://cdn.jsdelivr.net; " +
+				"connect-src 'self' ws: wss:; frame-ancestors 'none'",
+			ReferrerPolicy:    "no-referrer",
+			PermissionsPolicy: "accelerometer=(), camera=(), geolocation=(), microphone=(), payment=(), usb=()",
+		},
+		Websocket: &config.Websocket{},
+		Limits: &config.Limits{
+			BodyBytes:               1 << 20,
+			MaxUploadBytes:          32 << 20,
+			MaxMemoryBytes:          8 << 20,
+			OperationTimeoutSeconds: 10,
+		},
+		Flags: &config.FeatureFlags{
+			RefreshSeconds: 30,
+		},
+		Audit: &config.Audit{
+			RetentionDays: 90,
+		},
+		BodyLog: &config.BodyLog{
+			Enabled:      true,
+			MaxBytes:     4096,
+			RedactFields: []string{"password", "oldPassword", "newPassword", "token", "refreshToken"},
+		},
 	}
 }
 func IsInTests() bool {